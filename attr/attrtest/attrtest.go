@@ -0,0 +1,299 @@
+package attrtest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/attrvalue"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestType runs a conformance test suite against `typ` and the attr.Value
+// implementations it creates. The suite is run for a null value, an unknown
+// value, and each of the `known` values, which must be valid for `typ`.
+//
+// For each value, the suite verifies that:
+//
+// * ValueFromTerraform creates an attr.Value whose Type is equal to `typ`.
+//
+// * The attr.Value is equal to itself and to every other attr.Value created
+// from the same data, and its ToTerraformValue method returns the data it
+// was created from.
+//
+// * The attr.Value survives tfsdk.ConvertValue, tfsdk.ValueAs, and being
+// written to and read from a tfsdk.Plan, both as an attribute and as a struct
+// field, unchanged.
+//
+// It also verifies that `typ` is equal to itself, has a String
+// representation, and that ApplyTerraform5AttributePathStep agrees with
+// TerraformType.
+func TestType(t *testing.T, typ attr.Type, known ...tftypes.Value) {
+	t.Helper()
+
+	ctx := context.Background()
+	tfType := typ.TerraformType(ctx)
+
+	t.Run("type", func(t *testing.T) {
+		testTypeProperties(ctx, t, typ)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		testValue(ctx, t, typ, tftypes.NewValue(tfType, nil))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		testValue(ctx, t, typ, tftypes.NewValue(tfType, tftypes.UnknownValue))
+	})
+
+	for i, in := range known {
+		in := in
+
+		t.Run(fmt.Sprintf("known-%d", i), func(t *testing.T) {
+			if !in.IsKnown() || in.IsNull() {
+				t.Fatalf("expected known value, got %s", in)
+			}
+
+			testValue(ctx, t, typ, in)
+		})
+	}
+}
+
+func testTypeProperties(ctx context.Context, t *testing.T, typ attr.Type) {
+	if !typ.Equal(typ) {
+		t.Errorf("%s: expected type to be equal to itself", typ)
+	}
+
+	if typ.String() == "" {
+		t.Errorf("%T: expected String to return a non-empty string", typ)
+	}
+
+	tfType := typ.TerraformType(ctx)
+
+	if tfType == nil {
+		t.Fatalf("%s: expected TerraformType to return a tftypes.Type", typ)
+	}
+
+	var steps map[string]tftypes.AttributePathStep
+
+	switch tt := tfType.(type) {
+	case tftypes.Object:
+		steps = map[string]tftypes.AttributePathStep{}
+		for name := range tt.AttributeTypes {
+			steps[name] = tftypes.AttributeName(name)
+		}
+	case tftypes.List:
+		steps = map[string]tftypes.AttributePathStep{
+			"element": tftypes.ElementKeyInt(0),
+		}
+	case tftypes.Set:
+		steps = map[string]tftypes.AttributePathStep{
+			"element": tftypes.ElementKeyValue(tftypes.NewValue(tt.ElementType, nil)),
+		}
+	case tftypes.Map:
+		steps = map[string]tftypes.AttributePathStep{
+			"element": tftypes.ElementKeyString("key"),
+		}
+	case tftypes.Tuple:
+		// Tuples have no framework type to compare against.
+		return
+	default:
+		_, err := typ.ApplyTerraform5AttributePathStep(tftypes.AttributeName("test"))
+
+		if err == nil {
+			t.Errorf("%s: expected error applying attribute name step to %s", typ, tfType)
+		}
+
+		return
+	}
+
+	for name, step := range steps {
+		res, err := typ.ApplyTerraform5AttributePathStep(step)
+
+		if err != nil {
+			t.Errorf("%s: unexpected error applying %s step: %s", typ, name, err)
+			continue
+		}
+
+		nestedType, ok := res.(attr.Type)
+
+		if !ok {
+			t.Errorf("%s: expected %s step to return an attr.Type, got %T", typ, name, res)
+			continue
+		}
+
+		if got := nestedType.TerraformType(ctx); !got.Is(expectedStepType(tfType, name)) {
+			t.Errorf("%s: expected %s step to return a type of %s, got %s", typ, name, expectedStepType(tfType, name), got)
+		}
+	}
+}
+
+func expectedStepType(tfType tftypes.Type, name string) tftypes.Type {
+	switch tt := tfType.(type) {
+	case tftypes.Object:
+		return tt.AttributeTypes[name]
+	case tftypes.List:
+		return tt.ElementType
+	case tftypes.Set:
+		return tt.ElementType
+	case tftypes.Map:
+		return tt.AttributeType
+	}
+
+	return nil
+}
+
+func testValue(ctx context.Context, t *testing.T, typ attr.Type, in tftypes.Value) {
+	val, err := typ.ValueFromTerraform(ctx, in)
+
+	if err != nil {
+		t.Fatalf("%s: unexpected error creating value from %s: %s", typ, in, err)
+	}
+
+	if val == nil {
+		t.Fatalf("%s: expected ValueFromTerraform to return a value for %s, got nil", typ, in)
+	}
+
+	if got := val.Type(ctx); got == nil || !typ.Equal(got) || !got.Equal(typ) {
+		t.Errorf("%s: expected value created from %s to have an equal type, got %v", typ, in, got)
+	}
+
+	if !val.Equal(val) {
+		t.Errorf("%s: expected value created from %s to be equal to itself", typ, in)
+	}
+
+	again, err := typ.ValueFromTerraform(ctx, in.Copy())
+
+	if err != nil {
+		t.Fatalf("%s: unexpected error creating value from %s: %s", typ, in, err)
+	}
+
+	if !val.Equal(again) || !again.Equal(val) {
+		t.Errorf("%s: expected values created from %s to be equal to each other", typ, in)
+	}
+
+	if val.Equal(nil) {
+		t.Errorf("%s: expected value created from %s not to be equal to nil", typ, in)
+	}
+
+	raw, err := val.ToTerraformValue(ctx)
+
+	if err != nil {
+		t.Fatalf("%s: unexpected error converting value created from %s to a Terraform value: %s", typ, in, err)
+	}
+
+	if err := tftypes.ValidateValue(in.Type(), raw); err != nil {
+		t.Fatalf("%s: ToTerraformValue returned invalid data for value created from %s: %s", typ, in, err)
+	}
+
+	if got := tftypes.NewValue(in.Type(), raw); !got.Equal(in) {
+		t.Errorf("%s: expected ToTerraformValue to return %s, got %s", typ, in, got)
+	}
+
+	if typeWithValidate, ok := typ.(attr.TypeWithValidate); ok {
+		diags := typeWithValidate.Validate(ctx, in, tftypes.NewAttributePath().WithAttributeName("test"))
+
+		if diags.HasError() {
+			t.Errorf("%s: unexpected errors validating %s: %v", typ, in, diags)
+		}
+	}
+
+	converted, diags := tfsdk.ConvertValue(ctx, val, typ)
+
+	if diags.HasError() {
+		t.Errorf("%s: unexpected errors converting value created from %s: %v", typ, in, diags)
+	} else if !val.Equal(converted) {
+		t.Errorf("%s: expected ConvertValue to return %s, got %s", typ, attrvalue.String(ctx, val), attrvalue.String(ctx, converted))
+	}
+
+	target := reflect.New(reflect.TypeOf(val))
+	diags = tfsdk.ValueAs(ctx, val, target.Interface())
+
+	if diags.HasError() {
+		t.Errorf("%s: unexpected errors using ValueAs with value created from %s: %v", typ, in, diags)
+	} else if got := target.Elem().Interface().(attr.Value); !val.Equal(got) {
+		t.Errorf("%s: expected ValueAs to populate %s, got %s", typ, attrvalue.String(ctx, val), attrvalue.String(ctx, got))
+	}
+
+	testPlanAttribute(ctx, t, typ, val)
+	testPlanStruct(ctx, t, typ, val)
+}
+
+// testPlan returns an empty plan with a single attribute named "test" of
+// type `typ`.
+func testPlan(ctx context.Context, typ attr.Type) tfsdk.Plan {
+	schema := tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"test": {
+				Type:     typ,
+				Optional: true,
+			},
+		},
+	}
+
+	return tfsdk.Plan{
+		Raw: tftypes.NewValue(schema.TerraformType(ctx), map[string]tftypes.Value{
+			"test": tftypes.NewValue(typ.TerraformType(ctx), nil),
+		}),
+		Schema: schema,
+	}
+}
+
+func testPlanAttribute(ctx context.Context, t *testing.T, typ attr.Type, val attr.Value) {
+	plan := testPlan(ctx, typ)
+	path := tftypes.NewAttributePath().WithAttributeName("test")
+
+	diags := plan.SetAttribute(ctx, path, val)
+
+	if diags.HasError() {
+		t.Errorf("%s: unexpected errors setting plan attribute to %s: %v", typ, attrvalue.String(ctx, val), diags)
+		return
+	}
+
+	got, diags := plan.GetAttribute(ctx, path)
+
+	if diags.HasError() {
+		t.Errorf("%s: unexpected errors getting plan attribute: %v", typ, diags)
+		return
+	}
+
+	if !val.Equal(got) {
+		t.Errorf("%s: expected plan attribute to be %s, got %s", typ, attrvalue.String(ctx, val), attrvalue.String(ctx, got))
+	}
+}
+
+func testPlanStruct(ctx context.Context, t *testing.T, typ attr.Type, val attr.Value) {
+	plan := testPlan(ctx, typ)
+	structType := reflect.StructOf([]reflect.StructField{
+		{
+			Name: "Test",
+			Type: reflect.TypeOf(val),
+			Tag:  `tfsdk:"test"`,
+		},
+	})
+
+	in := reflect.New(structType).Elem()
+	in.Field(0).Set(reflect.ValueOf(val))
+
+	diags := plan.Set(ctx, in.Interface())
+
+	if diags.HasError() {
+		t.Errorf("%s: unexpected errors setting plan from struct containing %s: %v", typ, attrvalue.String(ctx, val), diags)
+		return
+	}
+
+	out := reflect.New(structType)
+	diags = plan.Get(ctx, out.Interface())
+
+	if diags.HasError() {
+		t.Errorf("%s: unexpected errors getting plan into struct: %v", typ, diags)
+		return
+	}
+
+	if got := out.Elem().Field(0).Interface().(attr.Value); !val.Equal(got) {
+		t.Errorf("%s: expected plan struct field to be %s, got %s", typ, attrvalue.String(ctx, val), attrvalue.String(ctx, got))
+	}
+}
@@ -0,0 +1,108 @@
+package attrtest_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/attrtest"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTestType(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		typ   attr.Type
+		known []tftypes.Value
+	}
+	tests := map[string]testCase{
+		"string": {
+			typ: types.StringType,
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, ""),
+				tftypes.NewValue(tftypes.String, "hello"),
+			},
+		},
+		"number": {
+			typ: types.NumberType,
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, big.NewFloat(123.456)),
+			},
+		},
+		"bool": {
+			typ: types.BoolType,
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.Bool, true),
+				tftypes.NewValue(tftypes.Bool, false),
+			},
+		},
+		"int64": {
+			typ: types.Int64Type,
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, big.NewFloat(123)),
+			},
+		},
+		"float64": {
+			typ: types.Float64Type,
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, big.NewFloat(123.456)),
+			},
+		},
+		"list": {
+			typ: types.ListType{ElemType: types.StringType},
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "hello"),
+					tftypes.NewValue(tftypes.String, nil),
+					tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}),
+			},
+		},
+		"set": {
+			typ: types.SetType{ElemType: types.StringType},
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "hello"),
+					tftypes.NewValue(tftypes.String, "world"),
+				}),
+			},
+		},
+		"map": {
+			typ: types.MapType{ElemType: types.BoolType},
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.Map{AttributeType: tftypes.Bool}, map[string]tftypes.Value{
+					"yes": tftypes.NewValue(tftypes.Bool, true),
+				}),
+			},
+		},
+		"object": {
+			typ: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"name": types.StringType,
+					"tags": types.ListType{ElemType: types.StringType},
+				},
+			},
+			known: []tftypes.Value{
+				tftypes.NewValue(tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"name": tftypes.String,
+						"tags": tftypes.List{ElementType: tftypes.String},
+					},
+				}, map[string]tftypes.Value{
+					"name": tftypes.NewValue(tftypes.String, "hello"),
+					"tags": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				}),
+			},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			attrtest.TestType(t, test.typ, test.known...)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package attrtest contains a conformance test suite for attr.Type and
+// attr.Value implementations, for use in provider unit tests.
+package attrtest
@@ -0,0 +1,121 @@
+// Command tfsdkcompat compares two provider schemas exported as JSON, by
+// tfsdk.ProviderSchemaJSON or `terraform providers schema -json`, and
+// reports the changes between them.
+//
+//	tfsdkcompat [-provider address] [-upgrades type:version,...] [-json] old.json new.json
+//
+// The -upgrades flag lists the resource types and schema versions the new
+// provider can upgrade states from, such as `example_thing:0,example_thing:1`.
+//
+// It exits with status 1 if any of the changes are breaking, and 2 if the
+// schemas couldn't be compared.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk/tfsdkcompat"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("tfsdkcompat", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tfsdkcompat [-provider address] [-upgrades type:version,...] [-json] old.json new.json")
+		flags.PrintDefaults()
+	}
+
+	address := flags.String("provider", "", "address of the provider to compare, if the files contain several")
+	upgrades := flags.String("upgrades", "", "comma-separated `type:version` pairs the new provider can upgrade resource states from")
+	jsonOutput := flags.Bool("json", false, "write the report as JSON")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	stateUpgrades, err := parseStateUpgrades(*upgrades)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	old, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	new, err := os.ReadFile(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	report, err := tfsdkcompat.CompareJSON(old, new, *address, tfsdkcompat.Options{StateUpgrades: stateUpgrades})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if *jsonOutput {
+		if report.Changes == nil {
+			report.Changes = []tfsdkcompat.Change{}
+		}
+
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	} else {
+		fmt.Fprint(stdout, report.String())
+	}
+
+	if report.Breaking {
+		return 1
+	}
+
+	return 0
+}
+
+// parseStateUpgrades parses the value of the -upgrades flag, a
+// comma-separated list of `type:version` pairs.
+func parseStateUpgrades(value string) (map[string][]int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	upgrades := map[string][]int64{}
+
+	for _, pair := range strings.Split(value, ",") {
+		typeName, version, ok := strings.Cut(pair, ":")
+		if !ok || typeName == "" {
+			return nil, fmt.Errorf("invalid state upgrade %q, expected type:version", pair)
+		}
+
+		v, err := strconv.ParseInt(version, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid state upgrade %q, expected type:version: %w", pair, err)
+		}
+
+		upgrades[typeName] = append(upgrades[typeName], v)
+	}
+
+	return upgrades, nil
+}
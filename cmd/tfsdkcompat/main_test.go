@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	oldFile := filepath.Join(dir, "old.json")
+	newFile := filepath.Join(dir, "new.json")
+	stringFile := filepath.Join(dir, "string.json")
+	numberFile := filepath.Join(dir, "number.json")
+
+	if err := os.WriteFile(oldFile, []byte(`{"format_version": "1.0", "provider_schemas": {"example": {"resource_schemas": {"example_thing": {"block": {}}}}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(newFile, []byte(`{"format_version": "1.0", "provider_schemas": {"example": {}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(stringFile, []byte(`{"format_version": "1.0", "provider_schemas": {"example": {"resource_schemas": {"example_thing": {"block": {"attributes": {"size": {"type": "string", "optional": true}}}}}}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(numberFile, []byte(`{"format_version": "1.0", "provider_schemas": {"example": {"resource_schemas": {"example_thing": {"version": 1, "block": {"attributes": {"size": {"type": "number", "optional": true}}}}}}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		args           []string
+		expectedStatus int
+		expectedOutput string
+	}
+
+	tests := map[string]testCase{
+		"unchanged": {
+			args:           []string{oldFile, oldFile},
+			expectedStatus: 0,
+			expectedOutput: "0 changes, 0 breaking\n",
+		},
+		"removed": {
+			args:           []string{oldFile, newFile},
+			expectedStatus: 1,
+			expectedOutput: "BREAKING resource example_thing: removed\n1 changes, 1 breaking\n",
+		},
+		"json": {
+			args:           []string{"-json", oldFile, oldFile},
+			expectedStatus: 0,
+			expectedOutput: "{\n  \"breaking\": false,\n  \"changes\": []\n}\n",
+		},
+		"state-upgrade-missing": {
+			args:           []string{stringFile, numberFile},
+			expectedStatus: 1,
+			expectedOutput: "BREAKING resource example_thing: size: type changed from string to number\n" +
+				"BREAKING resource example_thing: the schema version was bumped from 0 to 1, but states can't be upgraded from version 0, so Terraform can't decode existing states\n" +
+				"2 changes, 2 breaking\n",
+		},
+		"state-upgrade": {
+			args:           []string{"-upgrades", "example_other:0,example_thing:0", stringFile, numberFile},
+			expectedStatus: 1,
+			expectedOutput: "BREAKING resource example_thing: size: type changed from string to number\n1 changes, 1 breaking\n",
+		},
+		"invalid-upgrades": {
+			args:           []string{"-upgrades", "example_thing", stringFile, numberFile},
+			expectedStatus: 2,
+		},
+		"invalid-upgrade-version": {
+			args:           []string{"-upgrades", "example_thing:zero", stringFile, numberFile},
+			expectedStatus: 2,
+		},
+		"missing-provider": {
+			args:           []string{"-provider", "other", oldFile, newFile},
+			expectedStatus: 2,
+		},
+		"usage": {
+			args:           []string{oldFile},
+			expectedStatus: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			status := run(tc.args, &stdout, &stderr)
+
+			if status != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d; stderr: %s", tc.expectedStatus, status, stderr.String())
+			}
+
+			if diff := cmp.Diff(stdout.String(), tc.expectedOutput); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
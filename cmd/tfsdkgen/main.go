@@ -0,0 +1,96 @@
+// Command tfsdkgen generates tfsdk schemas and model structs from a JSON or
+// YAML spec, as described in the tfsdkgen package.
+//
+//	tfsdkgen [-o output.go] spec.json
+//
+// Specs in files ending in .yaml or .yml are YAML, with the same field names
+// as JSON specs:
+//
+//	package: provider
+//	resources:
+//	  example_thing:
+//	    attributes:
+//	      id: {type: string, computed: true}
+//
+// It's meant to be run by go generate:
+//
+//	//go:generate go run github.com/hashicorp/terraform-plugin-framework/cmd/tfsdkgen -o schemas_gen.go spec.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk/tfsdkgen"
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("tfsdkgen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tfsdkgen [-o output.go] spec.json|spec.yaml")
+		flags.PrintDefaults()
+	}
+
+	output := flags.String("o", "", "file to write the generated code to, instead of stdout")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	in, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	switch filepath.Ext(flags.Arg(0)) {
+	case ".yaml", ".yml":
+		in, err = yaml.YAMLToJSON(in)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", flags.Arg(0), err)
+			return 1
+		}
+	}
+
+	spec, err := tfsdkgen.ParseSpec(in)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", flags.Arg(0), err)
+		return 1
+	}
+
+	out, err := tfsdkgen.Generate(spec)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", flags.Arg(0), err)
+		return 1
+	}
+
+	if *output == "" {
+		if _, err := stdout.Write(out); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+
+		return 0
+	}
+
+	if err := os.WriteFile(*output, out, 0644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	specFile := filepath.Join(dir, "spec.json")
+	invalidFile := filepath.Join(dir, "invalid.json")
+	yamlSpecFile := filepath.Join(dir, "spec.yaml")
+	invalidYAMLFile := filepath.Join(dir, "invalid.yml")
+
+	if err := os.WriteFile(specFile, []byte(`{"package": "provider", "resources": {"example_thing": {"attributes": {"id": {"type": "string", "computed": true}}}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(invalidFile, []byte(`{"package": "provider", "resources": {"example_thing": {}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(yamlSpecFile, []byte("package: provider\nresources:\n  example_thing:\n    attributes:\n      id: {type: string, computed: true}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(invalidYAMLFile, []byte("package: provider\nresources: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		args           []string
+		expectedStatus int
+		expectedOutput string
+		expectedFile   string
+	}
+
+	tests := map[string]testCase{
+		"stdout": {
+			args:           []string{specFile},
+			expectedStatus: 0,
+			expectedOutput: "type exampleThingResourceModel struct",
+		},
+		"output-file": {
+			args:           []string{"-o", filepath.Join(dir, "schemas_gen.go"), specFile},
+			expectedStatus: 0,
+			expectedFile:   filepath.Join(dir, "schemas_gen.go"),
+		},
+		"yaml": {
+			args:           []string{yamlSpecFile},
+			expectedStatus: 0,
+			expectedOutput: "type exampleThingResourceModel struct",
+		},
+		"invalid-yaml": {
+			args:           []string{invalidYAMLFile},
+			expectedStatus: 1,
+		},
+		"invalid": {
+			args:           []string{invalidFile},
+			expectedStatus: 1,
+		},
+		"missing": {
+			args:           []string{filepath.Join(dir, "missing.json")},
+			expectedStatus: 1,
+		},
+		"usage": {
+			args:           []string{},
+			expectedStatus: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			status := run(tc.args, &stdout, &stderr)
+
+			if status != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d; stderr: %s", tc.expectedStatus, status, stderr.String())
+			}
+
+			if !strings.Contains(stdout.String(), tc.expectedOutput) {
+				t.Errorf("expected output to contain %q, got: %s", tc.expectedOutput, stdout.String())
+			}
+
+			if tc.expectedFile != "" {
+				out, err := os.ReadFile(tc.expectedFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.HasPrefix(out, []byte("// Code generated by tfsdkgen. DO NOT EDIT.")) {
+					t.Errorf("expected generated code in %s, got: %s", tc.expectedFile, out)
+				}
+			}
+		})
+	}
+}
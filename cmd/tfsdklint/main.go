@@ -0,0 +1,130 @@
+// Command tfsdklint lints a provider schema exported as JSON, by
+// tfsdk.ProviderSchemaJSON or `terraform providers schema -json`, and
+// reports what it finds.
+//
+//	tfsdklint [-provider address] [-disable rule,rule] [-secrets name,name] [-json] schema.json
+//	tfsdklint -rules
+//
+// It exits with status 1 if anything is found, and 2 if the schema couldn't
+// be linted.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk/tfsdklint"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("tfsdklint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tfsdklint [-provider address] [-disable rule,rule] [-secrets name,name] [-json] schema.json")
+		fmt.Fprintln(stderr, "       tfsdklint -rules")
+		flags.PrintDefaults()
+	}
+
+	address := flags.String("provider", "", "address of the provider to lint, if the file contains several")
+	disable := flags.String("disable", "", "comma-separated codes of the rules not to check")
+	secrets := flags.String("secrets", "", "comma-separated words that mark attributes as secrets")
+	jsonOutput := flags.Bool("json", false, "write the findings as JSON")
+	listRules := flags.Bool("rules", false, "list the rules and exit")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *listRules {
+		for _, rule := range tfsdklint.Rules {
+			fmt.Fprintf(stdout, "%s: %s\n", rule.Code, rule.Description)
+		}
+
+		return 0
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	var opts tfsdklint.Options
+
+	for _, code := range splitList(*disable) {
+		if !knownRule(tfsdklint.RuleCode(code)) {
+			fmt.Fprintf(stderr, "unknown rule %q\n", code)
+			return 2
+		}
+
+		opts.Disabled = append(opts.Disabled, tfsdklint.RuleCode(code))
+	}
+
+	opts.SecretNames = splitList(*secrets)
+
+	in, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	findings, err := tfsdklint.LintJSON(in, *address, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if *jsonOutput {
+		if findings == nil {
+			findings = []tfsdklint.Finding{}
+		}
+
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(findings); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	} else {
+		for _, finding := range findings {
+			fmt.Fprintln(stdout, finding)
+		}
+
+		fmt.Fprintf(stdout, "%d findings\n", len(findings))
+	}
+
+	if len(findings) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+func splitList(s string) []string {
+	var values []string
+
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func knownRule(code tfsdklint.RuleCode) bool {
+	for _, rule := range tfsdklint.Rules {
+		if rule.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
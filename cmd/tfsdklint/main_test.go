@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	cleanFile := filepath.Join(dir, "clean.json")
+	secretFile := filepath.Join(dir, "secret.json")
+
+	if err := os.WriteFile(cleanFile, []byte(`{"format_version": "1.0", "provider_schemas": {"example": {"resource_schemas": {"example_thing": {"block": {"description": "Manages a thing."}}}}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(secretFile, []byte(`{"format_version": "1.0", "provider_schemas": {"example": {"resource_schemas": {"example_thing": {"block": {"description": "Manages a thing.", "attributes": {"token": {"type": "string", "required": true, "description": "The token."}}}}}}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		args           []string
+		expectedStatus int
+		expectedOutput string
+	}
+
+	tests := map[string]testCase{
+		"clean": {
+			args:           []string{cleanFile},
+			expectedStatus: 0,
+			expectedOutput: "0 findings\n",
+		},
+		"findings": {
+			args:           []string{secretFile},
+			expectedStatus: 1,
+			expectedOutput: "resource example_thing: token: attribute looks like a secret, but isn't sensitive (secret_not_sensitive)\n1 findings\n",
+		},
+		"disable": {
+			args:           []string{"-disable", "secret_not_sensitive", secretFile},
+			expectedStatus: 0,
+			expectedOutput: "0 findings\n",
+		},
+		"secrets": {
+			args:           []string{"-secrets", "password", secretFile},
+			expectedStatus: 0,
+			expectedOutput: "0 findings\n",
+		},
+		"json": {
+			args:           []string{"-json", cleanFile},
+			expectedStatus: 0,
+			expectedOutput: "[]\n",
+		},
+		"rules": {
+			args:           []string{"-rules"},
+			expectedStatus: 0,
+			expectedOutput: "missing_description: schemas and attributes need a description\n" +
+				"secret_not_sensitive: attributes that look like secrets need to be sensitive\n" +
+				"computed_with_validators: computed attributes that aren't optional never run their validators\n" +
+				"name_not_snake_case: resource, data source, and attribute names need to be snake_case\n" +
+				"requires_replace_computed: computed attributes that aren't optional shouldn't require replacement\n" +
+				"deprecated_without_replacement: deprecation messages need to say what to use instead\n",
+		},
+		"unknown-rule": {
+			args:           []string{"-disable", "no_such_rule", cleanFile},
+			expectedStatus: 2,
+		},
+		"missing-provider": {
+			args:           []string{"-provider", "other", cleanFile},
+			expectedStatus: 2,
+		},
+		"usage": {
+			args:           []string{cleanFile, secretFile},
+			expectedStatus: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			status := run(tc.args, &stdout, &stderr)
+
+			if status != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d; stderr: %s", tc.expectedStatus, status, stderr.String())
+			}
+
+			if diff := cmp.Diff(stdout.String(), tc.expectedOutput); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
@@ -61,6 +61,75 @@ func (diags Diagnostics) Contains(in Diagnostic) bool {
 	return false
 }
 
+// Errors returns the error severity diagnostics in the collection.
+func (diags Diagnostics) Errors() Diagnostics {
+	return diags.withSeverity(SeverityError)
+}
+
+// ErrorsCount returns the number of error severity diagnostics in the
+// collection.
+func (diags Diagnostics) ErrorsCount() int {
+	return len(diags.Errors())
+}
+
+// Warnings returns the warning severity diagnostics in the collection.
+func (diags Diagnostics) Warnings() Diagnostics {
+	return diags.withSeverity(SeverityWarning)
+}
+
+// WarningsCount returns the number of warning severity diagnostics in the
+// collection.
+func (diags Diagnostics) WarningsCount() int {
+	return len(diags.Warnings())
+}
+
+// withSeverity returns the diagnostics in the collection with the given
+// severity.
+func (diags Diagnostics) withSeverity(severity Severity) Diagnostics {
+	var results Diagnostics
+
+	for _, diag := range diags {
+		if diag.Severity() == severity {
+			results = append(results, diag)
+		}
+	}
+
+	return results
+}
+
+// WithPath returns a copy of the collection with the paths of all
+// DiagnosticWithPath diagnostics prefixed by `path`, so diagnostics returned
+// by helpers working on a nested value point to where that value is found.
+// Diagnostics without a path are returned unchanged.
+//
+// Re-rooted diagnostics are returned as AttributeErrorDiagnostic or
+// AttributeWarningDiagnostic, depending on their severity, keeping any cause
+// and metadata.
+func (diags Diagnostics) WithPath(path *tftypes.AttributePath) Diagnostics {
+	var results Diagnostics
+
+	for _, diag := range diags {
+		diagWithPath, ok := diag.(DiagnosticWithPath)
+
+		if !ok {
+			results.Append(diag)
+			continue
+		}
+
+		steps := append(path.Steps(), diagWithPath.Path().Steps()...)
+		newPath := tftypes.NewAttributePathWithSteps(steps)
+
+		switch diag.Severity() {
+		case SeverityWarning:
+			results.Append(withRichness(diag, NewAttributeWarningDiagnostic(newPath, diag.Summary(), diag.Detail())))
+		default:
+			results.Append(withRichness(diag, NewAttributeErrorDiagnostic(newPath, diag.Summary(), diag.Detail())))
+		}
+	}
+
+	return results
+}
+
 // HasError returns true if the collection has an error severity Diagnostic.
 func (diags Diagnostics) HasError() bool {
 	for _, diag := range diags {
@@ -73,6 +142,8 @@ func (diags Diagnostics) HasError() bool {
 }
 
 // ToTfprotov6Diagnostics converts the diagnostics into the tfprotov6 collection type.
+// The metadata of DiagnosticWithMetadata diagnostics is appended to their
+// detail.
 //
 // Usage of this method outside the framework is not supported nor considered
 // for backwards compatibility promises.
@@ -80,8 +151,18 @@ func (diags Diagnostics) ToTfprotov6Diagnostics() []*tfprotov6.Diagnostic {
 	var results []*tfprotov6.Diagnostic
 
 	for _, diag := range diags {
+		detail := diag.Detail()
+
+		if diagWithMetadata, ok := diag.(DiagnosticWithMetadata); ok && len(diagWithMetadata.Metadata()) > 0 {
+			if detail != "" {
+				detail += "\n\n"
+			}
+
+			detail += metadataString(diagWithMetadata.Metadata())
+		}
+
 		tfprotov6Diagnostic := &tfprotov6.Diagnostic{
-			Detail:   diag.Detail(),
+			Detail:   detail,
 			Severity: diag.Severity().ToTfprotov6DiagnosticSeverity(),
 			Summary:  diag.Summary(),
 		}
@@ -596,3 +596,109 @@ func TestDiagnosticsToTfprotov6Diagnostics(t *testing.T) {
 		})
 	}
 }
+
+func TestDiagnosticsErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diags            diag.Diagnostics
+		expectedErrors   diag.Diagnostics
+		expectedWarnings diag.Diagnostics
+	}{
+		"nil": {},
+		"mixed": {
+			diags: diag.Diagnostics{
+				diag.NewErrorDiagnostic("one summary", "one detail"),
+				diag.NewWarningDiagnostic("two summary", "two detail"),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "three summary", "three detail"),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "four summary", "four detail"),
+			},
+			expectedErrors: diag.Diagnostics{
+				diag.NewErrorDiagnostic("one summary", "one detail"),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "three summary", "three detail"),
+			},
+			expectedWarnings: diag.Diagnostics{
+				diag.NewWarningDiagnostic("two summary", "two detail"),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "four summary", "four detail"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.diags.Errors(), tc.expectedErrors); diff != "" {
+				t.Errorf("Unexpected errors (+wanted, -got): %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.diags.Warnings(), tc.expectedWarnings); diff != "" {
+				t.Errorf("Unexpected warnings (+wanted, -got): %s", diff)
+			}
+
+			if got, expected := tc.diags.ErrorsCount(), len(tc.expectedErrors); got != expected {
+				t.Errorf("Expected %d errors, got %d", expected, got)
+			}
+
+			if got, expected := tc.diags.WarningsCount(), len(tc.expectedWarnings); got != expected {
+				t.Errorf("Expected %d warnings, got %d", expected, got)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsWithPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diags    diag.Diagnostics
+		path     *tftypes.AttributePath
+		expected diag.Diagnostics
+	}{
+		"nil": {
+			path: tftypes.NewAttributePath().WithAttributeName("parent"),
+		},
+		"mixed": {
+			diags: diag.Diagnostics{
+				diag.NewErrorDiagnostic("one summary", "one detail"),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "two summary", "two detail"),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithElementKeyInt(1), "three summary", "three detail"),
+			},
+			path: tftypes.NewAttributePath().WithAttributeName("parent").WithElementKeyString("key"),
+			expected: diag.Diagnostics{
+				diag.NewErrorDiagnostic("one summary", "one detail"),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("parent").WithElementKeyString("key").WithAttributeName("test"), "two summary", "two detail"),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("parent").WithElementKeyString("key").WithElementKeyInt(1), "three summary", "three detail"),
+			},
+		},
+		"empty-path": {
+			diags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "one summary", "one detail"),
+			},
+			path: tftypes.NewAttributePath(),
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "one summary", "one detail"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.diags.WithPath(tc.path)
+
+			if len(got) != len(tc.expected) {
+				t.Fatalf("Expected %d diagnostics, got %d: %v", len(tc.expected), len(got), got)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tc.expected[i]) {
+					t.Errorf("Expected diagnostic %d to be %v, got %v", i, tc.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package diag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ error = DiagnosticsError{}
+
+// DiagnosticsError is an error holding a collection of diagnostics, as
+// returned by Diagnostics.ToError. The full collection, including any
+// warnings, can be retrieved using errors.As and the Diagnostics method, and
+// FromErr converts it back into the original diagnostics.
+type DiagnosticsError struct {
+	diags Diagnostics
+}
+
+// As implements the interface used by errors.As, so the causes of
+// DiagnosticWithCause diagnostics can be found. See Diagnostics.As.
+func (e DiagnosticsError) As(target interface{}) bool {
+	return e.diags.As(target)
+}
+
+// Diagnostics returns the diagnostics the error was created from.
+func (e DiagnosticsError) Diagnostics() Diagnostics {
+	return e.diags
+}
+
+// Error returns the summary and detail of every error severity diagnostic,
+// prefixed by its path, if it has one.
+func (e DiagnosticsError) Error() string {
+	errs := e.diags.Errors()
+
+	if len(errs) == 1 {
+		return diagnosticString(errs[0])
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d errors occurred:", len(errs))
+
+	for _, diag := range errs {
+		b.WriteString("\n\t* ")
+		b.WriteString(diagnosticString(diag))
+	}
+
+	return b.String()
+}
+
+// diagnosticString returns a single string describing `diag`.
+func diagnosticString(diag Diagnostic) string {
+	var b strings.Builder
+
+	if diagWithPath, ok := diag.(DiagnosticWithPath); ok && len(diagWithPath.Path().Steps()) > 0 {
+		b.WriteString(diagWithPath.Path().String())
+		b.WriteString(": ")
+	}
+
+	b.WriteString(diag.Summary())
+
+	if diag.Detail() != "" {
+		b.WriteString(": ")
+		b.WriteString(diag.Detail())
+	}
+
+	return b.String()
+}
+
+// ToError returns the collection as an error, or nil if the collection has
+// no error severity diagnostics. The returned error is a DiagnosticsError.
+func (diags Diagnostics) ToError() error {
+	if !diags.HasError() {
+		return nil
+	}
+
+	return DiagnosticsError{
+		diags: diags,
+	}
+}
+
+// FromErrSummary is the summary of the diagnostics returned by FromErr for
+// errors that don't hold diagnostics of their own.
+const FromErrSummary = "Unexpected Error"
+
+// FromErr returns `err` as diagnostics, or nil if `err` is nil.
+//
+// If the chain of errors wrapped by `err` contains a DiagnosticsError, its
+// diagnostics are returned. Otherwise, a single DiagnosticWithCause error
+// diagnostic is returned with `err` as its cause, FromErrSummary as its
+// summary, and the error message as its detail. If the chain contains a
+// tftypes.AttributePathError, such as those returned by
+// tftypes.AttributePath.NewError, the diagnostic is associated with its path.
+func FromErr(err error) Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var diagsErr DiagnosticsError
+
+	if errors.As(err, &diagsErr) {
+		var diags Diagnostics
+
+		diags.Append(diagsErr.Diagnostics()...)
+
+		return diags
+	}
+
+	var pathErr tftypes.AttributePathError
+
+	if errors.As(err, &pathErr) {
+		detail := err.Error()
+
+		// avoid repeating the path in the detail when the error isn't
+		// wrapped
+		if outer, ok := err.(tftypes.AttributePathError); ok && errors.Unwrap(outer) != nil {
+			detail = errors.Unwrap(outer).Error()
+		}
+
+		return Diagnostics{
+			WithCause(NewAttributeErrorDiagnostic(pathErr.Path, FromErrSummary, detail), err),
+		}
+	}
+
+	return Diagnostics{
+		WithCause(NewErrorDiagnostic(FromErrSummary, err.Error()), err),
+	}
+}
@@ -0,0 +1,134 @@
+package diag_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDiagnosticsToError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diags    diag.Diagnostics
+		expected string
+	}{
+		"nil": {},
+		"warnings": {
+			diags: diag.Diagnostics{
+				diag.NewWarningDiagnostic("one summary", "one detail"),
+			},
+		},
+		"one": {
+			diags: diag.Diagnostics{
+				diag.NewWarningDiagnostic("one summary", "one detail"),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "two summary", "two detail"),
+			},
+			expected: `AttributeName("test"): two summary: two detail`,
+		},
+		"multiple": {
+			diags: diag.Diagnostics{
+				diag.NewErrorDiagnostic("one summary", ""),
+				diag.NewWarningDiagnostic("two summary", "two detail"),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("test"), "three summary", "three detail"),
+			},
+			expected: "2 errors occurred:\n\t* one summary\n\t* AttributeName(\"test\"): three summary: three detail",
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.diags.ToError()
+
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Expected error, got nil")
+			}
+
+			if err.Error() != tc.expected {
+				t.Errorf("Expected error to be %q, got %q", tc.expected, err.Error())
+			}
+
+			var diagsErr diag.DiagnosticsError
+			if !errors.As(err, &diagsErr) {
+				t.Fatalf("Expected a diag.DiagnosticsError, got %T", err)
+			}
+
+			if len(diagsErr.Diagnostics()) != len(tc.diags) {
+				t.Errorf("Expected %d diagnostics, got %d", len(tc.diags), len(diagsErr.Diagnostics()))
+			}
+		})
+	}
+}
+
+func TestFromErr(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("test").WithElementKeyInt(0)
+
+	testCases := map[string]struct {
+		err      error
+		expected diag.Diagnostics
+	}{
+		"nil": {},
+		"plain": {
+			err: errors.New("something went wrong"),
+			expected: diag.Diagnostics{
+				diag.WithCause(diag.NewErrorDiagnostic(diag.FromErrSummary, "something went wrong"), errors.New("something went wrong")),
+			},
+		},
+		"path": {
+			err: path.NewErrorf("invalid value"),
+			expected: diag.Diagnostics{
+				diag.WithCause(diag.NewAttributeErrorDiagnostic(path, diag.FromErrSummary, "invalid value"), path.NewErrorf("invalid value")),
+			},
+		},
+		"wrapped-path": {
+			err: fmt.Errorf("reading config: %w", path.NewErrorf("invalid value")),
+			expected: diag.Diagnostics{
+				diag.WithCause(diag.NewAttributeErrorDiagnostic(path, diag.FromErrSummary, `reading config: AttributeName("test").ElementKeyInt(0): invalid value`), fmt.Errorf("reading config: %w", path.NewErrorf("invalid value"))),
+			},
+		},
+		"wrapped-diagnostics": {
+			err: fmt.Errorf("reading config: %w", diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, "one summary", "one detail"),
+				diag.NewWarningDiagnostic("two summary", "two detail"),
+			}.ToError()),
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, "one summary", "one detail"),
+				diag.NewWarningDiagnostic("two summary", "two detail"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := diag.FromErr(tc.err)
+
+			if len(got) != len(tc.expected) {
+				t.Fatalf("Expected %d diagnostics, got %d: %v", len(tc.expected), len(got), got)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tc.expected[i]) {
+					t.Errorf("Expected diagnostic %d to be %v, got %v", i, tc.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
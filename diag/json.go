@@ -0,0 +1,256 @@
+package diag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/internal/pathstring"
+)
+
+var (
+	_ json.Marshaler   = Diagnostics{}
+	_ json.Unmarshaler = &Diagnostics{}
+	_ json.Marshaler   = ErrorDiagnostic{}
+	_ json.Unmarshaler = &ErrorDiagnostic{}
+	_ json.Marshaler   = WarningDiagnostic{}
+	_ json.Unmarshaler = &WarningDiagnostic{}
+	_ json.Marshaler   = AttributeErrorDiagnostic{}
+	_ json.Unmarshaler = &AttributeErrorDiagnostic{}
+	_ json.Marshaler   = AttributeWarningDiagnostic{}
+	_ json.Unmarshaler = &AttributeWarningDiagnostic{}
+)
+
+// diagnosticJSON is the JSON representation of a diagnostic.
+//
+// Path uses the canonical string form of attribute paths, like
+// `rule[2].port`, and is omitted for diagnostics without a path. The root
+// path is an empty string. Cause is the error message of the cause of a
+// DiagnosticWithCause.
+type diagnosticJSON struct {
+	Severity string            `json:"severity"`
+	Summary  string            `json:"summary"`
+	Detail   string            `json:"detail,omitempty"`
+	Path     *string           `json:"path,omitempty"`
+	Cause    string            `json:"cause,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// MarshalJSON returns the JSON representation of the collection, a list of
+// objects with "severity", "summary", "detail", "path", "cause", and
+// "metadata" properties. Any Diagnostic implementation can be marshaled, and
+// a nil collection is marshaled as an empty list.
+func (diags Diagnostics) MarshalJSON() ([]byte, error) {
+	results := make([]diagnosticJSON, 0, len(diags))
+
+	for _, diag := range diags {
+		results = append(results, toDiagnosticJSON(diag))
+	}
+
+	return json.Marshal(results)
+}
+
+// UnmarshalJSON sets the collection to the diagnostics represented by
+// `data`, as produced by MarshalJSON. Diagnostics are unmarshaled as the
+// generic implementations in this package, and causes are unmarshaled as
+// errors with the same message, so the result is Equal to the original
+// diagnostics where those are generic too.
+func (diags *Diagnostics) UnmarshalJSON(data []byte) error {
+	var in []diagnosticJSON
+
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	results := make(Diagnostics, 0, len(in))
+
+	for _, dj := range in {
+		diag, err := fromDiagnosticJSON(dj)
+
+		if err != nil {
+			return err
+		}
+
+		results = append(results, diag)
+	}
+
+	*diags = results
+
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the diagnostic.
+func (d ErrorDiagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toDiagnosticJSON(d))
+}
+
+// UnmarshalJSON sets the diagnostic to the one represented by `data`, which
+// must be an error diagnostic without a path.
+func (d *ErrorDiagnostic) UnmarshalJSON(data []byte) error {
+	return unmarshalDiagnosticJSON(data, d)
+}
+
+// MarshalJSON returns the JSON representation of the diagnostic.
+func (d WarningDiagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toDiagnosticJSON(d))
+}
+
+// UnmarshalJSON sets the diagnostic to the one represented by `data`, which
+// must be a warning diagnostic without a path.
+func (d *WarningDiagnostic) UnmarshalJSON(data []byte) error {
+	return unmarshalDiagnosticJSON(data, d)
+}
+
+// MarshalJSON returns the JSON representation of the diagnostic.
+func (d AttributeErrorDiagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toDiagnosticJSON(d))
+}
+
+// UnmarshalJSON sets the diagnostic to the one represented by `data`, which
+// must be an error diagnostic with a path.
+func (d *AttributeErrorDiagnostic) UnmarshalJSON(data []byte) error {
+	return unmarshalDiagnosticJSON(data, d)
+}
+
+// MarshalJSON returns the JSON representation of the diagnostic.
+func (d AttributeWarningDiagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toDiagnosticJSON(d))
+}
+
+// UnmarshalJSON sets the diagnostic to the one represented by `data`, which
+// must be a warning diagnostic with a path.
+func (d *AttributeWarningDiagnostic) UnmarshalJSON(data []byte) error {
+	return unmarshalDiagnosticJSON(data, d)
+}
+
+// MarshalDiagnosticJSON returns the JSON representation of any Diagnostic
+// implementation, in the same format as the diagnostics in this package. It
+// is intended for implementing json.Marshaler on custom diagnostic types.
+func MarshalDiagnosticJSON(diag Diagnostic) ([]byte, error) {
+	return json.Marshal(toDiagnosticJSON(diag))
+}
+
+func (d richDiagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toDiagnosticJSON(d))
+}
+
+func (d richAttributeDiagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toDiagnosticJSON(d))
+}
+
+// unmarshalDiagnosticJSON unmarshals `data` into `target`, which must be a
+// pointer to one of the generic diagnostic types, checking that the
+// severity and presence of a path match the type.
+func unmarshalDiagnosticJSON(data []byte, target interface{}) error {
+	var dj diagnosticJSON
+
+	if err := json.Unmarshal(data, &dj); err != nil {
+		return err
+	}
+
+	diag, err := fromDiagnosticJSON(dj)
+
+	if err != nil {
+		return err
+	}
+
+	if dj.Cause != "" || len(dj.Metadata) > 0 {
+		return fmt.Errorf("can't unmarshal a diagnostic with a cause or metadata into %T, use Diagnostics instead", target)
+	}
+
+	switch target := target.(type) {
+	case *ErrorDiagnostic:
+		if d, ok := diag.(ErrorDiagnostic); ok {
+			*target = d
+			return nil
+		}
+	case *WarningDiagnostic:
+		if d, ok := diag.(WarningDiagnostic); ok {
+			*target = d
+			return nil
+		}
+	case *AttributeErrorDiagnostic:
+		if d, ok := diag.(AttributeErrorDiagnostic); ok {
+			*target = d
+			return nil
+		}
+	case *AttributeWarningDiagnostic:
+		if d, ok := diag.(AttributeWarningDiagnostic); ok {
+			*target = d
+			return nil
+		}
+	}
+
+	return fmt.Errorf("can't unmarshal a %s diagnostic into %T", describeDiagnosticJSON(dj), target)
+}
+
+// describeDiagnosticJSON describes the kind of diagnostic `dj` represents,
+// for use in error messages.
+func describeDiagnosticJSON(dj diagnosticJSON) string {
+	if dj.Path != nil {
+		return dj.Severity + " attribute"
+	}
+
+	return dj.Severity
+}
+
+func toDiagnosticJSON(diag Diagnostic) diagnosticJSON {
+	dj := diagnosticJSON{
+		Severity: strings.ToLower(diag.Severity().String()),
+		Summary:  diag.Summary(),
+		Detail:   diag.Detail(),
+	}
+
+	if diagWithPath, ok := diag.(DiagnosticWithPath); ok {
+		path := pathstring.String(diagWithPath.Path())
+		dj.Path = &path
+	}
+
+	if diagWithCause, ok := diag.(DiagnosticWithCause); ok && diagWithCause.Cause() != nil {
+		dj.Cause = diagWithCause.Cause().Error()
+	}
+
+	if diagWithMetadata, ok := diag.(DiagnosticWithMetadata); ok && len(diagWithMetadata.Metadata()) > 0 {
+		dj.Metadata = diagWithMetadata.Metadata()
+	}
+
+	return dj
+}
+
+func fromDiagnosticJSON(dj diagnosticJSON) (Diagnostic, error) {
+	var diag Diagnostic
+
+	switch dj.Severity {
+	case "error":
+		diag = NewErrorDiagnostic(dj.Summary, dj.Detail)
+	case "warning":
+		diag = NewWarningDiagnostic(dj.Summary, dj.Detail)
+	default:
+		return nil, fmt.Errorf("unknown diagnostic severity %q", dj.Severity)
+	}
+
+	if dj.Path != nil {
+		path, err := pathstring.Parse(*dj.Path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if diag.Severity() == SeverityWarning {
+			diag = NewAttributeWarningDiagnostic(path, dj.Summary, dj.Detail)
+		} else {
+			diag = NewAttributeErrorDiagnostic(path, dj.Summary, dj.Detail)
+		}
+	}
+
+	if dj.Cause != "" {
+		diag = WithCause(diag, errors.New(dj.Cause))
+	}
+
+	if len(dj.Metadata) > 0 {
+		diag = WithMetadata(diag, dj.Metadata)
+	}
+
+	return diag, nil
+}
@@ -0,0 +1,155 @@
+package diag_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDiagnosticsJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diags    diag.Diagnostics
+		expected string
+	}{
+		"nil": {
+			expected: `[]`,
+		},
+		"generic": {
+			diags: diag.Diagnostics{
+				diag.NewErrorDiagnostic("one summary", "one detail"),
+				diag.NewWarningDiagnostic("two summary", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("port"), "three summary", "three detail"),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath(), "four summary", "four detail"),
+			},
+			expected: `[` +
+				`{"severity":"error","summary":"one summary","detail":"one detail"},` +
+				`{"severity":"warning","summary":"two summary"},` +
+				`{"severity":"error","summary":"three summary","detail":"three detail","path":"rule[2].port"},` +
+				`{"severity":"warning","summary":"four summary","detail":"four detail","path":""}` +
+				`]`,
+		},
+		"set": {
+			diags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("zones").WithElementKeyValue(tftypes.NewValue(tftypes.String, "a")), "one summary", "one detail"),
+			},
+			expected: `[{"severity":"error","summary":"one summary","detail":"one detail","path":"zones[value(\"string\", \"a\")]"}]`,
+		},
+		"rich": {
+			diags: diag.Diagnostics{
+				diag.WithMetadata(diag.WithCause(diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"), "one summary", "one detail"), errors.New("API error")), map[string]string{"request_id": "abc123"}),
+			},
+			expected: `[{"severity":"error","summary":"one summary","detail":"one detail","path":"tags[\"env\"]","cause":"API error","metadata":{"request_id":"abc123"}}]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tc.diags)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if string(got) != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+
+			var roundTripped diag.Diagnostics
+
+			if err := json.Unmarshal(got, &roundTripped); err != nil {
+				t.Fatalf("Unexpected error unmarshaling: %s", err)
+			}
+
+			if len(roundTripped) != len(tc.diags) {
+				t.Fatalf("Expected %d diagnostics, got %d: %v", len(tc.diags), len(roundTripped), roundTripped)
+			}
+
+			for i := range roundTripped {
+				if !roundTripped[i].Equal(tc.diags[i]) {
+					t.Errorf("Expected diagnostic %d to be %v, got %v", i, tc.diags[i], roundTripped[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnosticsUnmarshalJSON_invalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"severity": `[{"severity":"fatal","summary":"one summary"}]`,
+		"path":     `[{"severity":"error","summary":"one summary","path":"rule[{ port = 80 }]"}]`,
+		"not-list": `{"severity":"error","summary":"one summary"}`,
+	}
+
+	for name, input := range testCases {
+		name, input := name, input
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+
+			if err := json.Unmarshal([]byte(input), &diags); err == nil {
+				t.Errorf("Expected error, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestDiagnosticJSON(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("name")
+
+	var errorDiag diag.ErrorDiagnostic
+	roundTripDiagnosticJSON(t, diag.NewErrorDiagnostic("summary", "detail"), &errorDiag)
+
+	var warningDiag diag.WarningDiagnostic
+	roundTripDiagnosticJSON(t, diag.NewWarningDiagnostic("summary", "detail"), &warningDiag)
+
+	var attributeErrorDiag diag.AttributeErrorDiagnostic
+	roundTripDiagnosticJSON(t, diag.NewAttributeErrorDiagnostic(path, "summary", "detail"), &attributeErrorDiag)
+
+	var attributeWarningDiag diag.AttributeWarningDiagnostic
+	roundTripDiagnosticJSON(t, diag.NewAttributeWarningDiagnostic(path, "summary", "detail"), &attributeWarningDiag)
+
+	data, err := json.Marshal(diag.NewAttributeErrorDiagnostic(path, "summary", "detail"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if err := json.Unmarshal(data, &errorDiag); err == nil {
+		t.Errorf("Expected error unmarshaling attribute diagnostic into %T", errorDiag)
+	}
+
+	if err := json.Unmarshal(data, &attributeWarningDiag); err == nil {
+		t.Errorf("Expected error unmarshaling error diagnostic into %T", attributeWarningDiag)
+	}
+}
+
+func roundTripDiagnosticJSON(t *testing.T, d diag.Diagnostic, target json.Unmarshaler) {
+	t.Helper()
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling %T: %s", d, err)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		t.Fatalf("Unexpected error unmarshaling %T: %s", d, err)
+	}
+
+	got := target.(interface{ Equal(diag.Diagnostic) bool })
+
+	if !got.Equal(d) {
+		t.Errorf("Expected %v to round trip, got %v", d, target)
+	}
+}
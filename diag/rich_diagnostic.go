@@ -0,0 +1,226 @@
+package diag
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// DiagnosticWithCause is a diagnostic caused by a Go error, such as an error
+// returned by a remote API. The error can be retrieved using the
+// Diagnostics.As method, or errors.As on the result of Diagnostics.ToError.
+type DiagnosticWithCause interface {
+	Diagnostic
+
+	// Cause returns the error that caused the diagnostic.
+	Cause() error
+}
+
+// DiagnosticWithMetadata is a diagnostic with structured key/value metadata,
+// such as request IDs. The metadata is appended to the detail when the
+// diagnostic is returned to Terraform.
+type DiagnosticWithMetadata interface {
+	Diagnostic
+
+	// Metadata returns the key/value metadata of the diagnostic.
+	Metadata() map[string]string
+}
+
+var (
+	_ DiagnosticWithCause    = richDiagnostic{}
+	_ DiagnosticWithMetadata = richDiagnostic{}
+	_ DiagnosticWithPath     = richAttributeDiagnostic{}
+)
+
+// richDiagnostic wraps a diagnostic with a cause and metadata.
+type richDiagnostic struct {
+	Diagnostic
+
+	cause    error
+	metadata map[string]string
+}
+
+// Cause returns the error that caused the diagnostic.
+func (d richDiagnostic) Cause() error {
+	return d.cause
+}
+
+// Metadata returns a copy of the key/value metadata of the diagnostic.
+func (d richDiagnostic) Metadata() map[string]string {
+	if d.metadata == nil {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(d.metadata))
+
+	for k, v := range d.metadata {
+		metadata[k] = v
+	}
+
+	return metadata
+}
+
+// Equal returns true if the other diagnostic is wholly equivalent. Causes
+// are considered equivalent if their error messages are the same.
+func (d richDiagnostic) Equal(other Diagnostic) bool {
+	rd, ok := other.(richDiagnostic)
+
+	if !ok {
+		return false
+	}
+
+	return d.equal(rd)
+}
+
+func (d richDiagnostic) equal(other richDiagnostic) bool {
+	if !d.Diagnostic.Equal(other.Diagnostic) {
+		return false
+	}
+
+	if (d.cause == nil) != (other.cause == nil) {
+		return false
+	}
+
+	if d.cause != nil && d.cause.Error() != other.cause.Error() {
+		return false
+	}
+
+	if len(d.metadata) != len(other.metadata) {
+		return false
+	}
+
+	for k, v := range d.metadata {
+		if otherV, ok := other.metadata[k]; !ok || otherV != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// richAttributeDiagnostic is a richDiagnostic wrapping a DiagnosticWithPath.
+type richAttributeDiagnostic struct {
+	richDiagnostic
+}
+
+// Equal returns true if the other diagnostic is wholly equivalent. Causes
+// are considered equivalent if their error messages are the same.
+func (d richAttributeDiagnostic) Equal(other Diagnostic) bool {
+	rad, ok := other.(richAttributeDiagnostic)
+
+	if !ok {
+		return false
+	}
+
+	return d.equal(rad.richDiagnostic)
+}
+
+// Path returns the diagnostic path.
+func (d richAttributeDiagnostic) Path() *tftypes.AttributePath {
+	return d.Diagnostic.(DiagnosticWithPath).Path()
+}
+
+// WithCause returns a copy of `d` that was caused by `cause`. If `d` has a
+// path or metadata, the result keeps them.
+func WithCause(d Diagnostic, cause error) DiagnosticWithCause {
+	rd := toRich(d)
+	rd.cause = cause
+
+	return fromRich(rd).(DiagnosticWithCause)
+}
+
+// WithMetadata returns a copy of `d` with `metadata` added to any metadata it
+// already has. If `d` has a path or cause, the result keeps them.
+func WithMetadata(d Diagnostic, metadata map[string]string) DiagnosticWithMetadata {
+	rd := toRich(d)
+	merged := rd.Metadata()
+
+	if merged == nil {
+		merged = make(map[string]string, len(metadata))
+	}
+
+	for k, v := range metadata {
+		merged[k] = v
+	}
+
+	rd.metadata = merged
+
+	return fromRich(rd).(DiagnosticWithMetadata)
+}
+
+// toRich returns `d` as a richDiagnostic, unwrapping it if it already is one.
+func toRich(d Diagnostic) richDiagnostic {
+	switch d := d.(type) {
+	case richDiagnostic:
+		return d
+	case richAttributeDiagnostic:
+		return d.richDiagnostic
+	default:
+		return richDiagnostic{Diagnostic: d}
+	}
+}
+
+// fromRich returns `rd`, as a richAttributeDiagnostic if the diagnostic it
+// wraps has a path.
+func fromRich(rd richDiagnostic) Diagnostic {
+	if _, ok := rd.Diagnostic.(DiagnosticWithPath); ok {
+		return richAttributeDiagnostic{rd}
+	}
+
+	return rd
+}
+
+// withRichness returns `to` wrapped with the cause and metadata of `from`, if
+// `from` has any. It's used when copying diagnostics with modifications.
+func withRichness(from Diagnostic, to Diagnostic) Diagnostic {
+	switch from.(type) {
+	case richDiagnostic, richAttributeDiagnostic:
+	default:
+		return to
+	}
+
+	rd := toRich(from)
+	rd.Diagnostic = to
+
+	return fromRich(rd)
+}
+
+// As finds the first diagnostic in the collection with a cause that matches
+// `target`, as defined by errors.As, and if found, sets `target` to that
+// error value and returns true. Otherwise, it returns false.
+func (diags Diagnostics) As(target interface{}) bool {
+	for _, diag := range diags {
+		diagWithCause, ok := diag.(DiagnosticWithCause)
+
+		if !ok || diagWithCause.Cause() == nil {
+			continue
+		}
+
+		if errors.As(diagWithCause.Cause(), target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// metadataString renders `metadata` as sorted "key: value" lines.
+func metadataString(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		lines = append(lines, k+": "+metadata[k])
+	}
+
+	return strings.Join(lines, "\n")
+}
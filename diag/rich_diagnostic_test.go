@@ -0,0 +1,199 @@
+package diag_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type testAPIError struct {
+	StatusCode int
+}
+
+func (e testAPIError) Error() string {
+	return fmt.Sprintf("API returned status %d", e.StatusCode)
+}
+
+func TestWithCause(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("test")
+	cause := fmt.Errorf("creating widget: %w", testAPIError{StatusCode: 429})
+
+	d := diag.WithCause(diag.NewAttributeErrorDiagnostic(path, "Error Creating Widget", "Too many requests."), cause)
+
+	if d.Cause() != cause {
+		t.Errorf("Expected cause to be %v, got %v", cause, d.Cause())
+	}
+
+	diagWithPath, ok := d.(diag.DiagnosticWithPath)
+
+	if !ok {
+		t.Fatalf("Expected diagnostic to keep its path")
+	}
+
+	if !diagWithPath.Path().Equal(path) {
+		t.Errorf("Expected path to be %s, got %s", path, diagWithPath.Path())
+	}
+
+	if d.Severity() != diag.SeverityError || d.Summary() != "Error Creating Widget" || d.Detail() != "Too many requests." {
+		t.Errorf("Unexpected diagnostic: %v", d)
+	}
+
+	diags := diag.Diagnostics{
+		diag.NewErrorDiagnostic("Unrelated", ""),
+		d,
+	}
+
+	var apiErr testAPIError
+
+	if !diags.As(&apiErr) {
+		t.Fatalf("Expected Diagnostics.As to find cause")
+	}
+
+	if apiErr.StatusCode != 429 {
+		t.Errorf("Expected status code 429, got %d", apiErr.StatusCode)
+	}
+
+	apiErr = testAPIError{}
+
+	if !errors.As(fmt.Errorf("wrapped: %w", diags.ToError()), &apiErr) {
+		t.Fatalf("Expected errors.As to find cause through ToError")
+	}
+
+	if apiErr.StatusCode != 429 {
+		t.Errorf("Expected status code 429, got %d", apiErr.StatusCode)
+	}
+
+	if (diag.Diagnostics{diag.NewErrorDiagnostic("Unrelated", "")}).As(&apiErr) {
+		t.Errorf("Expected Diagnostics.As to not find cause")
+	}
+}
+
+func TestWithMetadata(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("boom")
+
+	d := diag.WithMetadata(diag.WithCause(diag.NewWarningDiagnostic("Slow Request", "The API was slow."), cause), map[string]string{
+		"request_id": "abc123",
+	})
+	d = diag.WithMetadata(d, map[string]string{
+		"region": "us-east-1",
+	})
+
+	if diff := cmp.Diff(d.Metadata(), map[string]string{"request_id": "abc123", "region": "us-east-1"}); diff != "" {
+		t.Errorf("Unexpected metadata (+wanted, -got): %s", diff)
+	}
+
+	diagWithCause, ok := d.(diag.DiagnosticWithCause)
+
+	if !ok || diagWithCause.Cause() != cause {
+		t.Errorf("Expected diagnostic to keep its cause")
+	}
+
+	if d.Detail() != "The API was slow." {
+		t.Errorf("Expected detail to be unchanged, got %q", d.Detail())
+	}
+
+	expected := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Summary:  "Slow Request",
+			Detail:   "The API was slow.\n\nregion: us-east-1\nrequest_id: abc123",
+		},
+	}
+
+	if diff := cmp.Diff(diag.Diagnostics{d}.ToTfprotov6Diagnostics(), expected); diff != "" {
+		t.Errorf("Unexpected response (+wanted, -got): %s", diff)
+	}
+}
+
+func TestRichDiagnosticEqual(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("test")
+
+	testCases := map[string]struct {
+		diag     diag.Diagnostic
+		other    diag.Diagnostic
+		expected bool
+	}{
+		"same-cause-message": {
+			diag:     diag.WithCause(diag.NewErrorDiagnostic("summary", "detail"), errors.New("cause")),
+			other:    diag.WithCause(diag.NewErrorDiagnostic("summary", "detail"), errors.New("cause")),
+			expected: true,
+		},
+		"different-cause": {
+			diag:     diag.WithCause(diag.NewErrorDiagnostic("summary", "detail"), errors.New("cause")),
+			other:    diag.WithCause(diag.NewErrorDiagnostic("summary", "detail"), errors.New("other cause")),
+			expected: false,
+		},
+		"missing-cause": {
+			diag:     diag.WithCause(diag.NewErrorDiagnostic("summary", "detail"), errors.New("cause")),
+			other:    diag.NewErrorDiagnostic("summary", "detail"),
+			expected: false,
+		},
+		"same-metadata": {
+			diag:     diag.WithMetadata(diag.NewAttributeErrorDiagnostic(path, "summary", "detail"), map[string]string{"a": "b"}),
+			other:    diag.WithMetadata(diag.NewAttributeErrorDiagnostic(path, "summary", "detail"), map[string]string{"a": "b"}),
+			expected: true,
+		},
+		"different-metadata": {
+			diag:     diag.WithMetadata(diag.NewAttributeErrorDiagnostic(path, "summary", "detail"), map[string]string{"a": "b"}),
+			other:    diag.WithMetadata(diag.NewAttributeErrorDiagnostic(path, "summary", "detail"), map[string]string{"a": "c"}),
+			expected: false,
+		},
+		"different-path": {
+			diag:     diag.WithMetadata(diag.NewAttributeErrorDiagnostic(path, "summary", "detail"), map[string]string{"a": "b"}),
+			other:    diag.WithMetadata(diag.NewAttributeErrorDiagnostic(path.WithAttributeName("other"), "summary", "detail"), map[string]string{"a": "b"}),
+			expected: false,
+		},
+		"path-and-no-path": {
+			diag:     diag.WithMetadata(diag.NewAttributeErrorDiagnostic(path, "summary", "detail"), map[string]string{"a": "b"}),
+			other:    diag.WithMetadata(diag.NewErrorDiagnostic("summary", "detail"), map[string]string{"a": "b"}),
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.diag.Equal(tc.other); got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+
+			if got := tc.other.Equal(tc.diag); got != tc.expected {
+				t.Errorf("Expected reversed comparison to be %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsWithPathKeepsCause(t *testing.T) {
+	t.Parallel()
+
+	cause := testAPIError{StatusCode: 404}
+	diags := diag.Diagnostics{
+		diag.WithCause(diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("id"), "Not Found", ""), cause),
+	}.WithPath(tftypes.NewAttributePath().WithAttributeName("parent"))
+
+	diagWithCause, ok := diags[0].(diag.DiagnosticWithCause)
+
+	if !ok || diagWithCause.Cause() != cause {
+		t.Fatalf("Expected diagnostic to keep its cause, got %v", diags[0])
+	}
+
+	expectedPath := tftypes.NewAttributePath().WithAttributeName("parent").WithAttributeName("id")
+
+	if path := diags[0].(diag.DiagnosticWithPath).Path(); !path.Equal(expectedPath) {
+		t.Errorf("Expected path to be %s, got %s", expectedPath, path)
+	}
+}
@@ -0,0 +1,218 @@
+package diag
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Sorted returns a copy of the collection in a stable order: errors before
+// warnings, then diagnostics without a path before those with one, then by
+// path, summary, and detail.
+func (diags Diagnostics) Sorted() Diagnostics {
+	if diags == nil {
+		return nil
+	}
+
+	results := make(Diagnostics, len(diags))
+	copy(results, diags)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return compareDiagnostics(results[i], results[j]) < 0
+	})
+
+	return results
+}
+
+// Collapsed returns a copy of the collection where diagnostics with the same
+// severity, summary, and detail, and with paths that only differ by their
+// element keys, such as the same attribute of every element in a list, are
+// replaced by the first of them. Its detail is annotated with the number of
+// diagnostics it replaces.
+func (diags Diagnostics) Collapsed() Diagnostics {
+	if diags == nil {
+		return nil
+	}
+
+	var keys []string
+	groups := map[string]Diagnostics{}
+
+	for _, diag := range diags {
+		key := collapseKey(diag)
+
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], diag)
+	}
+
+	results := make(Diagnostics, 0, len(keys))
+
+	for _, key := range keys {
+		group := groups[key]
+		first := group[0]
+
+		if len(group) == 1 {
+			results = append(results, first)
+			continue
+		}
+
+		detail := fmt.Sprintf("%d similar diagnostics were collapsed into this one.", len(group))
+
+		if first.Detail() != "" {
+			detail = first.Detail() + "\n\n" + detail
+		}
+
+		results = append(results, withDetail(first, detail))
+	}
+
+	return results
+}
+
+// withDetail returns a copy of `diag` with its detail replaced, keeping any
+// cause and metadata.
+func withDetail(diag Diagnostic, detail string) Diagnostic {
+	if diagWithPath, ok := diag.(DiagnosticWithPath); ok {
+		if diag.Severity() == SeverityWarning {
+			return withRichness(diag, NewAttributeWarningDiagnostic(diagWithPath.Path(), diag.Summary(), detail))
+		}
+
+		return withRichness(diag, NewAttributeErrorDiagnostic(diagWithPath.Path(), diag.Summary(), detail))
+	}
+
+	if diag.Severity() == SeverityWarning {
+		return withRichness(diag, NewWarningDiagnostic(diag.Summary(), detail))
+	}
+
+	return withRichness(diag, NewErrorDiagnostic(diag.Summary(), detail))
+}
+
+// collapseKey returns a string identifying the diagnostics that `diag` can be
+// collapsed with.
+func collapseKey(diag Diagnostic) string {
+	shape := "-"
+
+	if diagWithPath, ok := diag.(DiagnosticWithPath); ok {
+		var b strings.Builder
+
+		for _, step := range diagWithPath.Path().Steps() {
+			if name, ok := step.(tftypes.AttributeName); ok {
+				b.WriteString("." + strconv.Quote(string(name)))
+			} else {
+				b.WriteString("[*]")
+			}
+		}
+
+		shape = b.String()
+	}
+
+	return strings.Join([]string{
+		diag.Severity().String(),
+		strconv.Quote(diag.Summary()),
+		strconv.Quote(diag.Detail()),
+		shape,
+	}, " ")
+}
+
+// compareDiagnostics returns a negative number if `a` sorts before `b`, a
+// positive number if it sorts after, and zero if they sort equally.
+func compareDiagnostics(a, b Diagnostic) int {
+	if c := severityRank(a.Severity()) - severityRank(b.Severity()); c != 0 {
+		return c
+	}
+
+	aWithPath, aHasPath := a.(DiagnosticWithPath)
+	bWithPath, bHasPath := b.(DiagnosticWithPath)
+
+	switch {
+	case !aHasPath && bHasPath:
+		return -1
+	case aHasPath && !bHasPath:
+		return 1
+	case aHasPath && bHasPath:
+		if c := comparePaths(aWithPath.Path(), bWithPath.Path()); c != 0 {
+			return c
+		}
+	}
+
+	if c := strings.Compare(a.Summary(), b.Summary()); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.Detail(), b.Detail())
+}
+
+// severityRank orders errors before warnings, and warnings before any
+// other severity.
+func severityRank(severity Severity) int {
+	switch severity {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// comparePaths orders paths step by step. Attribute names sort before element
+// keys, shorter paths sort before longer paths they're a prefix of, and
+// integer element keys sort numerically.
+func comparePaths(a, b *tftypes.AttributePath) int {
+	aSteps, bSteps := a.Steps(), b.Steps()
+
+	for i := 0; i < len(aSteps) && i < len(bSteps); i++ {
+		if c := compareSteps(aSteps[i], bSteps[i]); c != 0 {
+			return c
+		}
+	}
+
+	return len(aSteps) - len(bSteps)
+}
+
+func compareSteps(a, b tftypes.AttributePathStep) int {
+	if c := stepRank(a) - stepRank(b); c != 0 {
+		return c
+	}
+
+	switch a := a.(type) {
+	case tftypes.AttributeName:
+		return strings.Compare(string(a), string(b.(tftypes.AttributeName)))
+	case tftypes.ElementKeyString:
+		return strings.Compare(string(a), string(b.(tftypes.ElementKeyString)))
+	case tftypes.ElementKeyInt:
+		bInt := b.(tftypes.ElementKeyInt)
+
+		switch {
+		case a < bInt:
+			return -1
+		case a > bInt:
+			return 1
+		default:
+			return 0
+		}
+	case tftypes.ElementKeyValue:
+		return strings.Compare(tftypes.Value(a).String(), tftypes.Value(b.(tftypes.ElementKeyValue)).String())
+	default:
+		return 0
+	}
+}
+
+func stepRank(step tftypes.AttributePathStep) int {
+	switch step.(type) {
+	case tftypes.AttributeName:
+		return 0
+	case tftypes.ElementKeyString:
+		return 1
+	case tftypes.ElementKeyInt:
+		return 2
+	case tftypes.ElementKeyValue:
+		return 3
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,120 @@
+package diag_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDiagnosticsSorted(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diags    diag.Diagnostics
+		expected diag.Diagnostics
+	}{
+		"nil": {},
+		"severity-then-path": {
+			diags: diag.Diagnostics{
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("a"), "warning", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(10), "error", ""),
+				diag.NewWarningDiagnostic("warning", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("port"), "error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule"), "error", ""),
+				diag.NewErrorDiagnostic("b error", ""),
+				diag.NewErrorDiagnostic("a error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("name"), "error", ""),
+			},
+			expected: diag.Diagnostics{
+				diag.NewErrorDiagnostic("a error", ""),
+				diag.NewErrorDiagnostic("b error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("name"), "error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule"), "error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("port"), "error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(10), "error", ""),
+				diag.NewWarningDiagnostic("warning", ""),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("a"), "warning", ""),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var original diag.Diagnostics
+			original = append(original, tc.diags...)
+
+			got := tc.diags.Sorted()
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected response (+wanted, -got): %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.diags, original); diff != "" {
+				t.Errorf("Unexpected modification of original (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsCollapsed(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diags    diag.Diagnostics
+		expected diag.Diagnostics
+	}{
+		"nil": {},
+		"elements": {
+			diags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("name"), "Invalid Port", "Port must be positive."),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(3).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(4).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+				diag.NewWarningDiagnostic("Deprecated", ""),
+			},
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"), "Invalid Port", "Port must be positive.\n\n3 similar diagnostics were collapsed into this one."),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("name"), "Invalid Port", "Port must be positive."),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(3).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+				diag.NewWarningDiagnostic("Deprecated", ""),
+			},
+		},
+		"no-path": {
+			diags: diag.Diagnostics{
+				diag.NewErrorDiagnostic("Error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath(), "Error", ""),
+				diag.NewErrorDiagnostic("Error", "Detail"),
+			},
+			expected: diag.Diagnostics{
+				diag.NewErrorDiagnostic("Error", ""),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath(), "Error", ""),
+				diag.NewErrorDiagnostic("Error", "Detail"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.diags.Collapsed()
+
+			if len(got) != len(tc.expected) {
+				t.Fatalf("Expected %d diagnostics, got %d: %v", len(tc.expected), len(got), got)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tc.expected[i]) {
+					t.Errorf("Expected diagnostic %d to be %v, got %v", i, tc.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -1,10 +1,11 @@
 module github.com/hashicorp/terraform-plugin-framework
 
-go 1.17
+go 1.18
 
 require (
 	github.com/google/go-cmp v0.5.6
 	github.com/hashicorp/terraform-plugin-go v0.3.1
+	sigs.k8s.io/yaml v1.3.0
 )
 
 require (
@@ -23,4 +24,5 @@ require (
 	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55 // indirect
 	google.golang.org/grpc v1.32.0 // indirect
 	google.golang.org/protobuf v1.23.0 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
@@ -0,0 +1,40 @@
+// Package attrvalue renders attr.Values as short, HCL-like strings. It's
+// separate from the valuestring package so that packages attr depends on,
+// like diag, can use valuestring.
+package attrvalue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// String renders `val` in the same form as valuestring.Tftypes. Values that
+// implement fmt.Stringer are rendered using their String method, all others
+// are rendered from their Terraform value.
+func String(ctx context.Context, val attr.Value) string {
+	if val == nil {
+		return valuestring.Null
+	}
+
+	if s, ok := val.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	raw, err := val.ToTerraformValue(ctx)
+	if err != nil {
+		return fmt.Sprintf("<invalid %T: %s>", val, err)
+	}
+
+	typ := val.Type(ctx).TerraformType(ctx)
+
+	err = tftypes.ValidateValue(typ, raw)
+	if err != nil {
+		return fmt.Sprintf("<invalid %T: %s>", val, err)
+	}
+
+	return valuestring.Tftypes(tftypes.NewValue(typ, raw), nil, nil)
+}
@@ -0,0 +1,175 @@
+// Package consistency compares Terraform values the way Terraform does when
+// it checks that a provider's plans and applied states are consistent with
+// what came before them.
+package consistency
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Inconsistency is a value in the actual value that doesn't match the
+// expected value.
+type Inconsistency struct {
+	// Path is the location of the value.
+	Path *tftypes.AttributePath
+
+	// Expected is the expected value at Path.
+	Expected tftypes.Value
+
+	// Actual is the actual value at Path.
+	Actual tftypes.Value
+}
+
+// KnownValues returns the inconsistencies between `expected` and `actual`,
+// where every known value in `expected` must be present in `actual`, and
+// unknown values in `expected` may be replaced by any value. This is the
+// check Terraform makes between a planned state and the state returned by
+// applying it.
+//
+// Elements of lists, maps, and objects are compared individually, so
+// inconsistencies point to the values that differ. Sets are compared as a
+// whole, and only if `expected` has no unknown values within it, as their
+// elements can't be matched up otherwise.
+func KnownValues(expected, actual tftypes.Value) []Inconsistency {
+	return compare(tftypes.NewAttributePath(), expected, actual, nil)
+}
+
+// ConfiguredValues is like KnownValues, but null values in `expected` may
+// also be replaced by any value where `computed` returns true for their
+// path. This is the check Terraform makes between a configuration and the
+// planned state for it, where computed attributes that aren't configured
+// can have any value, and other attributes that aren't configured must stay
+// null.
+func ConfiguredValues(expected, actual tftypes.Value, computed func(*tftypes.AttributePath) bool) []Inconsistency {
+	return compare(tftypes.NewAttributePath(), expected, actual, computed)
+}
+
+// PlannedValues returns the inconsistencies between `config`, a resource's
+// configuration, and `planned`, the planned state for it, that Terraform
+// rejects. It's like ConfiguredValues, but a value that differs from the
+// configured one is also allowed when it's the value in `prior`, the prior
+// state, and both the prior and configured values are non-null, which lets
+// providers keep values that are equivalent to the configured ones. A
+// value that's removed from the configuration of an attribute that isn't
+// computed must be planned as null, even if it had a value before.
+func PlannedValues(config, prior, planned tftypes.Value, computed func(*tftypes.AttributePath) bool) []Inconsistency {
+	var results []Inconsistency
+
+	for _, inconsistency := range ConfiguredValues(config, planned, computed) {
+		if priorValue, ok := ValueAtPath(prior, inconsistency.Path); ok && !priorValue.IsNull() && !inconsistency.Expected.IsNull() && priorValue.Equal(inconsistency.Actual) {
+			continue
+		}
+
+		results = append(results, inconsistency)
+	}
+
+	return results
+}
+
+// ValueAtPath returns the value at `path` within `val`, if there is one.
+func ValueAtPath(val tftypes.Value, path *tftypes.AttributePath) (tftypes.Value, bool) {
+	result, _, err := tftypes.WalkAttributePath(val, path)
+	if err != nil {
+		return tftypes.Value{}, false
+	}
+
+	resultValue, ok := result.(tftypes.Value)
+
+	return resultValue, ok
+}
+
+func compare(path *tftypes.AttributePath, expected, actual tftypes.Value, computed func(*tftypes.AttributePath) bool) []Inconsistency {
+	if !expected.IsKnown() {
+		return nil
+	}
+
+	if expected.IsNull() {
+		if actual.IsNull() || (computed != nil && computed(path)) {
+			return nil
+		}
+
+		return inconsistent(path, expected, actual)
+	}
+
+	if !actual.IsKnown() || actual.IsNull() || !actual.Type().Is(expected.Type()) {
+		return inconsistent(path, expected, actual)
+	}
+
+	typ := expected.Type()
+
+	switch {
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Tuple{}):
+		var expectedElems, actualElems []tftypes.Value
+
+		if expected.As(&expectedElems) != nil || actual.As(&actualElems) != nil || len(expectedElems) != len(actualElems) {
+			return inconsistent(path, expected, actual)
+		}
+
+		var results []Inconsistency
+
+		for pos := range expectedElems {
+			results = append(results, compare(path.WithElementKeyInt(int64(pos)), expectedElems[pos], actualElems[pos], computed)...)
+		}
+
+		return results
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var expectedElems, actualElems map[string]tftypes.Value
+
+		if expected.As(&expectedElems) != nil || actual.As(&actualElems) != nil || len(expectedElems) != len(actualElems) {
+			return inconsistent(path, expected, actual)
+		}
+
+		keys := make([]string, 0, len(expectedElems))
+
+		for key := range expectedElems {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		var results []Inconsistency
+
+		for _, key := range keys {
+			expectedElem := expectedElems[key]
+			actualElem, ok := actualElems[key]
+
+			if !ok {
+				return inconsistent(path, expected, actual)
+			}
+
+			elemPath := path.WithElementKeyString(key)
+
+			if typ.Is(tftypes.Object{}) {
+				elemPath = path.WithAttributeName(key)
+			}
+
+			results = append(results, compare(elemPath, expectedElem, actualElem, computed)...)
+		}
+
+		return results
+	case typ.Is(tftypes.Set{}):
+		if !expected.IsFullyKnown() || expected.Equal(actual) {
+			return nil
+		}
+
+		return inconsistent(path, expected, actual)
+	default:
+		if expected.Equal(actual) {
+			return nil
+		}
+
+		return inconsistent(path, expected, actual)
+	}
+}
+
+func inconsistent(path *tftypes.AttributePath, expected, actual tftypes.Value) []Inconsistency {
+	return []Inconsistency{
+		{
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+		},
+	}
+}
@@ -0,0 +1,224 @@
+package consistency
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestKnownValues(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":    tftypes.String,
+		"name":  tftypes.String,
+		"ports": tftypes.List{ElementType: tftypes.Number},
+		"tags":  tftypes.Set{ElementType: tftypes.String},
+	}}
+	obj := func(id, name interface{}, ports []tftypes.Value, tags interface{}) tftypes.Value {
+		var portsVal interface{} = ports
+		if ports == nil {
+			portsVal = nil
+		}
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"id":    tftypes.NewValue(tftypes.String, id),
+			"name":  tftypes.NewValue(tftypes.String, name),
+			"ports": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, portsVal),
+			"tags":  tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tags),
+		})
+	}
+	num := func(n int) tftypes.Value {
+		return tftypes.NewValue(tftypes.Number, n)
+	}
+	str := func(s string) tftypes.Value {
+		return tftypes.NewValue(tftypes.String, s)
+	}
+	computed := func(path *tftypes.AttributePath) bool {
+		return path.Equal(tftypes.NewAttributePath().WithAttributeName("id"))
+	}
+
+	tests := map[string]struct {
+		expected            tftypes.Value
+		actual              tftypes.Value
+		configured          bool
+		expectedPaths       []*tftypes.AttributePath
+		expectedConfigPaths []*tftypes.AttributePath
+	}{
+		"equal": {
+			expected: obj("a", "b", []tftypes.Value{num(80)}, []tftypes.Value{str("x")}),
+			actual:   obj("a", "b", []tftypes.Value{num(80)}, []tftypes.Value{str("x")}),
+		},
+		"unknown-replaced": {
+			expected: obj(tftypes.UnknownValue, "b", []tftypes.Value{tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)}, tftypes.UnknownValue),
+			actual:   obj("a", "b", []tftypes.Value{num(80)}, []tftypes.Value{str("x")}),
+		},
+		"known-changed": {
+			expected: obj("a", "b", []tftypes.Value{num(80), num(443)}, nil),
+			actual:   obj("a", "c", []tftypes.Value{num(80), num(8443)}, nil),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+				tftypes.NewAttributePath().WithAttributeName("ports").WithElementKeyInt(1),
+			},
+			expectedConfigPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+				tftypes.NewAttributePath().WithAttributeName("ports").WithElementKeyInt(1),
+			},
+		},
+		"null-replaced": {
+			expected: obj(nil, "b", nil, nil),
+			actual:   obj("a", "b", []tftypes.Value{num(80)}, nil),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("id"),
+				tftypes.NewAttributePath().WithAttributeName("ports"),
+			},
+			expectedConfigPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("ports"),
+			},
+		},
+		"null-replaced-optional": {
+			expected: obj("a", nil, nil, nil),
+			actual:   obj("a", "b", nil, nil),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+			expectedConfigPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+		},
+		"list-length": {
+			expected: obj("a", "b", []tftypes.Value{num(80)}, nil),
+			actual:   obj("a", "b", []tftypes.Value{num(80), num(443)}, nil),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("ports"),
+			},
+			expectedConfigPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("ports"),
+			},
+		},
+		"known-to-unknown": {
+			expected: obj("a", "b", nil, nil),
+			actual:   obj(tftypes.UnknownValue, "b", nil, nil),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("id"),
+			},
+			expectedConfigPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("id"),
+			},
+		},
+		"set-changed": {
+			expected: obj("a", "b", nil, []tftypes.Value{str("x")}),
+			actual:   obj("a", "b", nil, []tftypes.Value{str("y")}),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("tags"),
+			},
+			expectedConfigPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("tags"),
+			},
+		},
+		"set-with-unknown": {
+			expected: obj("a", "b", nil, []tftypes.Value{tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+			actual:   obj("a", "b", nil, []tftypes.Value{str("y"), str("z")}),
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			checkPaths(t, "KnownValues", KnownValues(test.expected, test.actual), test.expectedPaths)
+			checkPaths(t, "ConfiguredValues", ConfiguredValues(test.expected, test.actual, computed), test.expectedConfigPaths)
+		})
+	}
+}
+
+func TestPlannedValues(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":   tftypes.String,
+		"name": tftypes.String,
+	}}
+	obj := func(id, name interface{}) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, id),
+			"name": tftypes.NewValue(tftypes.String, name),
+		})
+	}
+	computed := func(path *tftypes.AttributePath) bool {
+		return path.Equal(tftypes.NewAttributePath().WithAttributeName("id"))
+	}
+
+	tests := map[string]struct {
+		config        tftypes.Value
+		prior         tftypes.Value
+		planned       tftypes.Value
+		expectedPaths []*tftypes.AttributePath
+	}{
+		"create": {
+			config:  obj(nil, "a"),
+			prior:   tftypes.NewValue(objType, nil),
+			planned: obj(tftypes.UnknownValue, "a"),
+		},
+		"create-changed": {
+			config:  obj(nil, "a"),
+			prior:   tftypes.NewValue(objType, nil),
+			planned: obj(tftypes.UnknownValue, "b"),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+		},
+		"prior-kept": {
+			config:  obj(nil, "A"),
+			prior:   obj("x", "a"),
+			planned: obj("x", "a"),
+		},
+		"prior-changed": {
+			config:  obj(nil, "A"),
+			prior:   obj("x", "a"),
+			planned: obj("x", "b"),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+		},
+		"removed-prior-kept": {
+			config:  obj(nil, nil),
+			prior:   obj("x", "a"),
+			planned: obj("x", "a"),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+		},
+		"added-prior-kept": {
+			config:  obj(nil, "a"),
+			prior:   obj("x", nil),
+			planned: obj("x", nil),
+			expectedPaths: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			checkPaths(t, "PlannedValues", PlannedValues(test.config, test.prior, test.planned, computed), test.expectedPaths)
+		})
+	}
+}
+
+func checkPaths(t *testing.T, name string, got []Inconsistency, expected []*tftypes.AttributePath) {
+	t.Helper()
+
+	if len(got) != len(expected) {
+		t.Fatalf("%s: expected %d inconsistencies, got %d: %v", name, len(expected), len(got), got)
+	}
+
+	for i := range got {
+		if !got[i].Path.Equal(expected[i]) {
+			t.Errorf("%s: expected inconsistency %d at %s, got %s", name, i, expected[i], got[i].Path)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package consistency
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// attributeAtPath returns the attribute of `block` at `path`, or nil if
+// `path` doesn't point to an attribute.
+func attributeAtPath(block *tfprotov6.SchemaBlock, path *tftypes.AttributePath) *tfprotov6.SchemaAttribute {
+	if block == nil || path == nil {
+		return nil
+	}
+
+	attrs, blocks := block.Attributes, block.BlockTypes
+
+	var current *tfprotov6.SchemaAttribute
+
+	for _, step := range path.Steps() {
+		name, ok := step.(tftypes.AttributeName)
+		if !ok {
+			// element keys select an element of a nested attribute
+			// or block, whose attributes are those of its siblings
+			current = nil
+			continue
+		}
+
+		current = nil
+		nextAttrs, nextBlocks := []*tfprotov6.SchemaAttribute(nil), []*tfprotov6.SchemaNestedBlock(nil)
+
+		for _, attr := range attrs {
+			if attr.Name == string(name) {
+				current = attr
+				if attr.NestedType != nil {
+					nextAttrs = attr.NestedType.Attributes
+				}
+			}
+		}
+
+		for _, nested := range blocks {
+			if nested.TypeName == string(name) && nested.Block != nil {
+				nextAttrs, nextBlocks = nested.Block.Attributes, nested.Block.BlockTypes
+			}
+		}
+
+		attrs, blocks = nextAttrs, nextBlocks
+	}
+
+	return current
+}
+
+// ComputedAtPath returns a function that reports whether the attribute of
+// `block` at a path is computed, meaning the provider may set it when it's
+// not configured, for use with ConfiguredValues and PlannedValues.
+func ComputedAtPath(block *tfprotov6.SchemaBlock) func(*tftypes.AttributePath) bool {
+	return func(path *tftypes.AttributePath) bool {
+		attr := attributeAtPath(block, path)
+
+		return attr != nil && attr.Computed
+	}
+}
+
+// SensitiveAtPath returns a function that reports whether the attribute of
+// `block` at a path, or any attribute containing it, is sensitive, for use
+// with valuestring.Tftypes.
+func SensitiveAtPath(block *tfprotov6.SchemaBlock) func(*tftypes.AttributePath) bool {
+	return func(path *tftypes.AttributePath) bool {
+		if path == nil {
+			return false
+		}
+
+		for len(path.Steps()) > 0 {
+			attr := attributeAtPath(block, path)
+			if attr != nil && attr.Sensitive {
+				return true
+			}
+
+			path = path.WithoutLastStep()
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,149 @@
+package consistency
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestComputedAtPath(t *testing.T) {
+	t.Parallel()
+
+	block := &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{Name: "id", Type: tftypes.String, Computed: true},
+			{Name: "name", Type: tftypes.String, Optional: true},
+			{
+				Name:     "disks",
+				Optional: true,
+				NestedType: &tfprotov6.SchemaObject{
+					Nesting: tfprotov6.SchemaObjectNestingModeList,
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{Name: "id", Type: tftypes.String, Computed: true},
+						{Name: "size", Type: tftypes.Number, Optional: true},
+					},
+				},
+			},
+		},
+		BlockTypes: []*tfprotov6.SchemaNestedBlock{
+			{
+				TypeName: "rule",
+				Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{Name: "port", Type: tftypes.Number, Optional: true, Computed: true},
+					},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		path     *tftypes.AttributePath
+		expected bool
+	}{
+		"root": {
+			path: tftypes.NewAttributePath(),
+		},
+		"computed": {
+			path:     tftypes.NewAttributePath().WithAttributeName("id"),
+			expected: true,
+		},
+		"optional": {
+			path: tftypes.NewAttributePath().WithAttributeName("name"),
+		},
+		"nested-computed": {
+			path:     tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0).WithAttributeName("id"),
+			expected: true,
+		},
+		"nested-optional": {
+			path: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0).WithAttributeName("size"),
+		},
+		"nested-element": {
+			path: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
+		},
+		"block-computed": {
+			path:     tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyValue(tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number}}, nil)).WithAttributeName("port"),
+			expected: true,
+		},
+		"block": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule"),
+		},
+		"missing": {
+			path: tftypes.NewAttributePath().WithAttributeName("other"),
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ComputedAtPath(block)(test.path); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSensitiveAtPath(t *testing.T) {
+	t.Parallel()
+
+	block := &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{Name: "name", Type: tftypes.String, Optional: true},
+			{Name: "password", Type: tftypes.String, Optional: true, Sensitive: true},
+			{
+				Name:      "credentials",
+				Optional:  true,
+				Sensitive: true,
+				NestedType: &tfprotov6.SchemaObject{
+					Nesting: tfprotov6.SchemaObjectNestingModeList,
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{Name: "user", Type: tftypes.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		path     *tftypes.AttributePath
+		expected bool
+	}{
+		"root": {
+			path: tftypes.NewAttributePath(),
+		},
+		"nil": {},
+		"not-sensitive": {
+			path: tftypes.NewAttributePath().WithAttributeName("name"),
+		},
+		"sensitive": {
+			path:     tftypes.NewAttributePath().WithAttributeName("password"),
+			expected: true,
+		},
+		"nested-element": {
+			path:     tftypes.NewAttributePath().WithAttributeName("credentials").WithElementKeyInt(0),
+			expected: true,
+		},
+		"nested-attribute": {
+			path:     tftypes.NewAttributePath().WithAttributeName("credentials").WithElementKeyInt(0).WithAttributeName("user"),
+			expected: true,
+		},
+		"missing": {
+			path: tftypes.NewAttributePath().WithAttributeName("other"),
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := SensitiveAtPath(block)(test.path); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
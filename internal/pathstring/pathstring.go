@@ -0,0 +1,273 @@
+// Package pathstring renders tftypes.AttributePaths in a canonical,
+// HCL-like string form, such as `rule[2].port`, and parses them back.
+package pathstring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// setKeyPrefix starts the rendering of a set element key, which holds the
+// element's type and value, as Terraform renders them in JSON.
+const setKeyPrefix = "[value("
+
+// String renders `path`. Attribute names are separated by dots, integer
+// element keys are rendered as `[2]`, string element keys as `["key"]`, and
+// set element keys as the element's JSON type and value, like
+// `[value(["object",{"port":"number"}], {"port":80})]`. Unknown values, which
+// JSON can't hold, are rendered as `unknown`. The root path is rendered as an
+// empty string.
+func String(path *tftypes.AttributePath) string {
+	var b strings.Builder
+
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(string(step))
+		case tftypes.ElementKeyString:
+			b.WriteString("[" + strconv.Quote(string(step)) + "]")
+		case tftypes.ElementKeyInt:
+			b.WriteString("[" + strconv.FormatInt(int64(step), 10) + "]")
+		case tftypes.ElementKeyValue:
+			b.WriteString(setKeyString(tftypes.Value(step)))
+		default:
+			b.WriteString(fmt.Sprintf("[<unsupported step %T>]", step))
+		}
+	}
+
+	return b.String()
+}
+
+// Parse returns the path rendered as `s` by String. Set element keys holding
+// unknown values can't be parsed, and return an error.
+func Parse(s string) (*tftypes.AttributePath, error) {
+	path := tftypes.NewAttributePath()
+	rest := s
+
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end, step, err := parseElementKey(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", s, err)
+			}
+			switch step := step.(type) {
+			case tftypes.ElementKeyString:
+				path = path.WithElementKeyString(string(step))
+			case tftypes.ElementKeyInt:
+				path = path.WithElementKeyInt(int64(step))
+			case tftypes.ElementKeyValue:
+				path = path.WithElementKeyValue(tftypes.Value(step))
+			}
+			rest = rest[end:]
+		case rest[0] == '.' && len(path.Steps()) > 0:
+			rest = rest[1:]
+			fallthrough
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty attribute name", s)
+			}
+			path = path.WithAttributeName(rest[:end])
+			rest = rest[end:]
+		}
+	}
+
+	return path, nil
+}
+
+// parseElementKey parses the element key at the start of `s`, returning the
+// length of its rendering and the step it represents.
+func parseElementKey(s string) (int, tftypes.AttributePathStep, error) {
+	if strings.HasPrefix(s, setKeyPrefix) {
+		return parseSetKey(s)
+	}
+
+	if strings.HasPrefix(s, `["`) {
+		quoted, err := strconv.QuotedPrefix(s[1:])
+		if err != nil || !strings.HasPrefix(s[1+len(quoted):], "]") {
+			return 0, nil, fmt.Errorf("invalid string element key in %q", s)
+		}
+		key, err := strconv.Unquote(quoted)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid string element key in %q: %w", s, err)
+		}
+		return len(quoted) + 2, tftypes.ElementKeyString(key), nil
+	}
+
+	end := strings.Index(s, "]")
+	if end < 0 {
+		return 0, nil, fmt.Errorf("unterminated element key in %q", s)
+	}
+
+	key, err := strconv.ParseInt(s[1:end], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unsupported element key %q, only string, integer, and set element keys can be parsed", s[:end+1])
+	}
+
+	return end + 1, tftypes.ElementKeyInt(key), nil
+}
+
+// setKeyString renders the set element key holding `val`.
+func setKeyString(val tftypes.Value) string {
+	var b bytes.Buffer
+
+	b.WriteString(setKeyPrefix)
+
+	//nolint:staticcheck
+	typ, err := val.Type().MarshalJSON()
+	if err != nil {
+		typ = []byte(fmt.Sprintf("<invalid type %s>", val.Type()))
+	}
+	b.Write(typ)
+	b.WriteString(", ")
+	writeJSONValue(&b, val)
+	b.WriteString(")]")
+
+	return b.String()
+}
+
+// writeJSONValue writes `val` to `b` as JSON, with object attributes and map
+// keys in sorted order so that the rendering is canonical.
+func writeJSONValue(b *bytes.Buffer, val tftypes.Value) {
+	if !val.IsKnown() {
+		b.WriteString("unknown")
+		return
+	}
+
+	if val.IsNull() {
+		b.WriteString("null")
+		return
+	}
+
+	typ := val.Type()
+
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := val.As(&s); err != nil {
+			b.WriteString("<invalid>")
+			return
+		}
+		// json.Marshal doesn't fail on strings
+		encoded, _ := json.Marshal(s)
+		b.Write(encoded)
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := val.As(&n); err != nil || n.IsInf() {
+			b.WriteString("<invalid>")
+			return
+		}
+		b.WriteString(exactDecimal(n))
+	case typ.Is(tftypes.Bool):
+		var v bool
+		if err := val.As(&v); err != nil {
+			b.WriteString("<invalid>")
+			return
+		}
+		b.WriteString(strconv.FormatBool(v))
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := val.As(&elems); err != nil {
+			b.WriteString("<invalid>")
+			return
+		}
+		b.WriteString("[")
+		for pos, elem := range elems {
+			if pos > 0 {
+				b.WriteString(",")
+			}
+			writeJSONValue(b, elem)
+		}
+		b.WriteString("]")
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		elems := map[string]tftypes.Value{}
+		if err := val.As(&elems); err != nil {
+			b.WriteString("<invalid>")
+			return
+		}
+		keys := make([]string, 0, len(elems))
+		for key := range elems {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteString("{")
+		for pos, key := range keys {
+			if pos > 0 {
+				b.WriteString(",")
+			}
+			encoded, _ := json.Marshal(key)
+			b.Write(encoded)
+			b.WriteString(":")
+			writeJSONValue(b, elems[key])
+		}
+		b.WriteString("}")
+	default:
+		b.WriteString("<invalid>")
+	}
+}
+
+// exactDecimal renders `n` in decimal without rounding it, as a number with
+// a binary fraction always has a finite decimal expansion.
+func exactDecimal(n *big.Float) string {
+	digits := 0
+	if !n.IsInt() {
+		digits = int(n.MinPrec()) - n.MantExp(nil)
+	}
+	return n.Text('f', digits)
+}
+
+// parseSetKey parses the set element key at the start of `s`, returning the
+// length of its rendering and the step it represents.
+func parseSetKey(s string) (int, tftypes.AttributePathStep, error) {
+	rest := s[len(setKeyPrefix):]
+
+	var rawType, rawValue json.RawMessage
+
+	dec := json.NewDecoder(strings.NewReader(rest))
+	if err := dec.Decode(&rawType); err != nil {
+		return 0, nil, fmt.Errorf("invalid set element key type in %q: %w", s, err)
+	}
+	typeEnd := int(dec.InputOffset())
+
+	if !strings.HasPrefix(rest[typeEnd:], ", ") {
+		return 0, nil, fmt.Errorf("invalid set element key in %q", s)
+	}
+	valueStart := typeEnd + len(", ")
+
+	dec = json.NewDecoder(strings.NewReader(rest[valueStart:]))
+	if err := dec.Decode(&rawValue); err != nil {
+		return 0, nil, fmt.Errorf("invalid set element key value in %q, set element keys holding unknown values can't be parsed: %w", s, err)
+	}
+	valueEnd := valueStart + int(dec.InputOffset())
+
+	if !strings.HasPrefix(rest[valueEnd:], ")]") {
+		return 0, nil, fmt.Errorf("unterminated set element key in %q", s)
+	}
+
+	//nolint:staticcheck
+	typ, err := tftypes.ParseJSONType(rawType)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid set element key type in %q: %w", s, err)
+	}
+
+	val, err := tftypes.ValueFromJSON(rawValue, typ)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid set element key value in %q: %w", s, err)
+	}
+
+	return len(setKeyPrefix) + valueEnd + len(")]"), tftypes.ElementKeyValue(val), nil
+}
@@ -0,0 +1,219 @@
+package pathstring
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path     *tftypes.AttributePath
+		expected string
+	}{
+		"nil": {
+			expected: "",
+		},
+		"root": {
+			path:     tftypes.NewAttributePath(),
+			expected: "",
+		},
+		"attribute": {
+			path:     tftypes.NewAttributePath().WithAttributeName("name"),
+			expected: "name",
+		},
+		"list": {
+			path:     tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("port"),
+			expected: "rule[2].port",
+		},
+		"map": {
+			path:     tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString(`a "quoted" key`),
+			expected: `tags["a \"quoted\" key"]`,
+		},
+		"set": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyValue(tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number},
+			}, map[string]tftypes.Value{
+				"port": tftypes.NewValue(tftypes.Number, 80),
+			})).WithAttributeName("port"),
+			expected: `rule[value(["object",{"port":"number"}], {"port":80})].port`,
+		},
+		"nested-elements": {
+			path:     tftypes.NewAttributePath().WithAttributeName("matrix").WithElementKeyInt(0).WithElementKeyInt(1),
+			expected: "matrix[0][1]",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := String(test.path); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input       string
+		expected    *tftypes.AttributePath
+		expectedErr bool
+	}{
+		"root": {
+			input:    "",
+			expected: tftypes.NewAttributePath(),
+		},
+		"attribute": {
+			input:    "name",
+			expected: tftypes.NewAttributePath().WithAttributeName("name"),
+		},
+		"list": {
+			input:    "rule[2].port",
+			expected: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("port"),
+		},
+		"map": {
+			input:    `tags["a \"quoted\" ] key"].value`,
+			expected: tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString(`a "quoted" ] key`).WithAttributeName("value"),
+		},
+		"nested-elements": {
+			input:    "matrix[0][1]",
+			expected: tftypes.NewAttributePath().WithAttributeName("matrix").WithElementKeyInt(0).WithElementKeyInt(1),
+		},
+		"set": {
+			input: `rule[value(["object",{"port":"number"}], {"port":80})].port`,
+			expected: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyValue(tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number},
+			}, map[string]tftypes.Value{
+				"port": tftypes.NewValue(tftypes.Number, 80),
+			})).WithAttributeName("port"),
+		},
+		"set-unknown": {
+			input:       `rule[value("string", unknown)]`,
+			expectedErr: true,
+		},
+		"set-unterminated": {
+			input:       `rule[value("string", "a"]`,
+			expectedErr: true,
+		},
+		"set-invalid-type": {
+			input:       `rule[value("text", "a")]`,
+			expectedErr: true,
+		},
+		"unterminated": {
+			input:       "rule[2",
+			expectedErr: true,
+		},
+		"empty-attribute": {
+			input:       "rule..port",
+			expectedErr: true,
+		},
+		"leading-dot": {
+			input:       ".rule",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(test.input)
+			if test.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+			if String(got) != test.input {
+				t.Errorf("expected %q to round trip, got %q", test.input, String(got))
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"ports": tftypes.List{ElementType: tftypes.Number},
+			"tags":  tftypes.Map{AttributeType: tftypes.String},
+		},
+	}
+	precise, _, err := big.ParseFloat("123456789012345678901234567890.125", 10, 512, big.ToNearestEven)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]tftypes.AttributePathStep{
+		"int":          tftypes.ElementKeyInt(2),
+		"string":       tftypes.ElementKeyString(`a "quoted" ] key`),
+		"set-string":   tftypes.ElementKeyValue(tftypes.NewValue(tftypes.String, "a")),
+		"set-number":   tftypes.ElementKeyValue(tftypes.NewValue(tftypes.Number, 2)),
+		"set-fraction": tftypes.ElementKeyValue(tftypes.NewValue(tftypes.Number, 0.1)),
+		"set-precise":  tftypes.ElementKeyValue(tftypes.NewValue(tftypes.Number, precise)),
+		"set-bool":     tftypes.ElementKeyValue(tftypes.NewValue(tftypes.Bool, true)),
+		"set-null":     tftypes.ElementKeyValue(tftypes.NewValue(tftypes.String, nil)),
+		"set-list": tftypes.ElementKeyValue(tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "a"),
+			tftypes.NewValue(tftypes.String, ")]"),
+		})),
+		"set-object": tftypes.ElementKeyValue(tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "example"),
+			"ports": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, 80),
+				tftypes.NewValue(tftypes.Number, 443),
+			}),
+			"tags": tftypes.NewValue(tftypes.Map{AttributeType: tftypes.String}, map[string]tftypes.Value{
+				"b": tftypes.NewValue(tftypes.String, "2"),
+				"a": tftypes.NewValue(tftypes.String, "1"),
+			}),
+		})),
+	}
+
+	for name, step := range tests {
+		name, step := name, step
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := tftypes.NewAttributePath().WithAttributeName("rule")
+			switch step := step.(type) {
+			case tftypes.ElementKeyInt:
+				path = path.WithElementKeyInt(int64(step))
+			case tftypes.ElementKeyString:
+				path = path.WithElementKeyString(string(step))
+			case tftypes.ElementKeyValue:
+				path = path.WithElementKeyValue(tftypes.Value(step))
+			}
+			path = path.WithAttributeName("port")
+
+			s := String(path)
+			got, err := Parse(s)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %s", s, err)
+			}
+			if !got.Equal(path) {
+				t.Errorf("expected %s, got %s", path, got)
+			}
+			if String(got) != s {
+				t.Errorf("expected %q to be rendered again, got %q", s, String(got))
+			}
+		})
+	}
+}
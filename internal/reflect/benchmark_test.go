@@ -0,0 +1,111 @@
+package reflect_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type benchmarkDisk struct {
+	ID                 string       `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	SizeGB             int64        `tfsdk:"size_gb"`
+	DeleteWithInstance bool         `tfsdk:"delete_with_instance"`
+	Labels             []string     `tfsdk:"labels"`
+}
+
+type benchmarkModel struct {
+	ID    string          `tfsdk:"id"`
+	Disks []benchmarkDisk `tfsdk:"disks"`
+}
+
+var benchmarkDiskType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                   types.StringType,
+		"name":                 types.StringType,
+		"size_gb":              types.NumberType,
+		"delete_with_instance": types.BoolType,
+		"labels":               types.ListType{ElemType: types.StringType},
+	},
+}
+
+var benchmarkModelType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":    types.StringType,
+		"disks": types.ListType{ElemType: benchmarkDiskType},
+	},
+}
+
+// benchmarkValue returns a model with `n` disks, and its Terraform value.
+func benchmarkValue(b *testing.B, n int) (benchmarkModel, tftypes.Value) {
+	ctx := context.Background()
+	model := benchmarkModel{
+		ID:    "instance",
+		Disks: make([]benchmarkDisk, 0, n),
+	}
+	for i := 0; i < n; i++ {
+		model.Disks = append(model.Disks, benchmarkDisk{
+			ID:                 fmt.Sprintf("disk-%d", i),
+			Name:               types.String{Value: fmt.Sprintf("Disk %d", i)},
+			SizeGB:             int64(i),
+			DeleteWithInstance: i%2 == 0,
+			Labels:             []string{"a", "b"},
+		})
+	}
+
+	val, diags := refl.FromValue(ctx, benchmarkModelType, model, tftypes.NewAttributePath())
+	if diags.HasError() {
+		b.Fatalf("unexpected error: %v", diags)
+	}
+	raw, err := val.ToTerraformValue(ctx)
+	if err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+	return model, tftypes.NewValue(benchmarkModelType.TerraformType(ctx), raw)
+}
+
+func BenchmarkInto(b *testing.B) {
+	for _, n := range []int{10, 1000} {
+		n := n
+		b.Run(fmt.Sprintf("disks=%d", n), func(b *testing.B) {
+			ctx := context.Background()
+			_, val := benchmarkValue(b, n)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var target benchmarkModel
+				diags := refl.Into(ctx, benchmarkModelType, val, &target, refl.Options{})
+				if diags.HasError() {
+					b.Fatalf("unexpected error: %v", diags)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFromValue(b *testing.B) {
+	for _, n := range []int{10, 1000} {
+		n := n
+		b.Run(fmt.Sprintf("disks=%d", n), func(b *testing.B) {
+			ctx := context.Background()
+			model, _ := benchmarkValue(b, n)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, diags := refl.FromValue(ctx, benchmarkModelType, model, tftypes.NewAttributePath())
+				if diags.HasError() {
+					b.Fatalf("unexpected error: %v", diags)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package reflect
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	attributeValueSetterType = reflect.TypeOf((*AttributeValueSetter)(nil)).Elem()
+	attrValueType            = reflect.TypeOf((*attr.Value)(nil)).Elem()
+	valueConverterType       = reflect.TypeOf((*tftypes.ValueConverter)(nil)).Elem()
+	unknownableType          = reflect.TypeOf((*Unknownable)(nil)).Elem()
+	nullableType             = reflect.TypeOf((*Nullable)(nil)).Elem()
+)
+
+// typeInfo describes how BuildValue builds values of a reflect.Type. It's
+// computed once per type by getTypeInfo, as checking which interfaces a type
+// implements is expensive relative to the conversions themselves, and the
+// same types are converted over and over again.
+type typeInfo struct {
+	// attributeValueSetter is true if the type or a pointer to it
+	// implements AttributeValueSetter.
+	attributeValueSetter bool
+
+	// attrValue is true if the type implements attr.Value.
+	attrValue bool
+
+	// valueConverter is true if the type implements
+	// tftypes.ValueConverter.
+	valueConverter bool
+
+	// unknownable is true if the type implements Unknownable.
+	unknownable bool
+
+	// nullable is true if the type implements Nullable.
+	nullable bool
+
+	// text is true if the type is parsed from a string by Text.
+	text bool
+
+	// iface is true if the type is built by Interface.
+	iface bool
+
+	// textMarshaler is true if the type or a pointer to it implements
+	// encoding.TextMarshaler.
+	textMarshaler bool
+}
+
+// typeInfoCache is a map[reflect.Type]*typeInfo.
+var typeInfoCache sync.Map
+
+// getTypeInfo returns the typeInfo for `typ`, computing and caching it if
+// this is the first time `typ` has been seen.
+func getTypeInfo(typ reflect.Type) *typeInfo {
+	if info, ok := typeInfoCache.Load(typ); ok {
+		return info.(*typeInfo)
+	}
+
+	info := &typeInfo{
+		attributeValueSetter: typ.Implements(attributeValueSetterType) || reflect.PtrTo(typ).Implements(attributeValueSetterType),
+		attrValue:            typ.Implements(attrValueType),
+		valueConverter:       typ.Implements(valueConverterType),
+		unknownable:          typ.Implements(unknownableType),
+		nullable:             typ.Implements(nullableType),
+		text:                 isTextUnmarshaler(typ),
+		iface:                isInterfaceTarget(typ),
+		textMarshaler:        typ.Implements(textMarshalerType) || reflect.PtrTo(typ).Implements(textMarshalerType),
+	}
+
+	actual, _ := typeInfoCache.LoadOrStore(typ, info)
+	return actual.(*typeInfo)
+}
+
+// structTagsCache is a map[reflect.Type]map[string]structTag, holding the
+// successful results of getStructTags. The cached maps are shared, and must
+// not be modified.
+var structTagsCache sync.Map
+
+// getCachedStructTags returns the same results as getStructTags, but only
+// parses the tags of each struct type once. Errors aren't cached, as they
+// reference `path` and are expected to be fixed rather than repeated.
+func getCachedStructTags(ctx context.Context, in reflect.Value, path *tftypes.AttributePath) (map[string]structTag, error) {
+	typ := trueReflectValue(in).Type()
+
+	if tags, ok := structTagsCache.Load(typ); ok {
+		return tags.(map[string]structTag), nil
+	}
+
+	tags, err := getStructTags(ctx, in, path)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := structTagsCache.LoadOrStore(typ, tags)
+	return actual.(map[string]structTag), nil
+}
@@ -0,0 +1,84 @@
+package reflect
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestGetCachedStructTags_concurrent(t *testing.T) {
+	t.Parallel()
+
+	type testStruct struct {
+		A string `tfsdk:"a"`
+		B string `tfsdk:"b"`
+	}
+
+	expected := map[string]structTag{
+		"a": {index: []int{0}},
+		"b": {index: []int{1}},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			got, err := getCachedStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+				return
+			}
+			if diff := cmp.Diff(got, expected, cmp.AllowUnexported(structTag{})); diff != "" {
+				t.Errorf("Unexpected result (+got, -expected): %s", diff)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGetCachedStructTags_errorsNotCached(t *testing.T) {
+	t.Parallel()
+
+	type testStruct struct {
+		A string
+	}
+
+	for _, path := range []*tftypes.AttributePath{
+		tftypes.NewAttributePath().WithAttributeName("first"),
+		tftypes.NewAttributePath().WithAttributeName("second"),
+	} {
+		_, err := getCachedStructTags(context.Background(), reflect.ValueOf(testStruct{}), path)
+		if err == nil {
+			t.Fatalf("Expected error, got nil")
+		}
+		expected := path.NewErrorf(`need a struct tag for "tfsdk" on A`).Error()
+		if err.Error() != expected {
+			t.Errorf("Expected error to be %q, got %q", expected, err.Error())
+		}
+	}
+}
+
+func TestGetTypeInfo(t *testing.T) {
+	t.Parallel()
+
+	info := getTypeInfo(reflect.TypeOf(&unknownableValue{}))
+	if !info.unknownable || info.nullable || info.attrValue {
+		t.Errorf("Unexpected type info: %+v", info)
+	}
+	if getTypeInfo(reflect.TypeOf(&unknownableValue{})) != info {
+		t.Errorf("Expected type info to be cached")
+	}
+}
+
+type unknownableValue struct{}
+
+func (u *unknownableValue) SetUnknown(context.Context, bool) error      { return nil }
+func (u *unknownableValue) SetValue(context.Context, interface{}) error { return nil }
+func (u *unknownableValue) GetUnknown(context.Context) bool             { return false }
+func (u *unknownableValue) GetValue(context.Context) interface{}        { return nil }
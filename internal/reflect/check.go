@@ -0,0 +1,196 @@
+package reflect
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	bigFloatType = reflect.TypeOf(big.NewFloat(0))
+	bigIntType   = reflect.TypeOf(big.NewInt(0))
+)
+
+// CheckType returns an error diagnostic for every place where values of
+// `typ` can't be built into, or created from, values of the Go type
+// `target`, such as a struct field with no attribute, an attribute with no
+// struct field, or an int for a list. It follows the same rules as
+// BuildValue, but walks the types instead of converting a value, so it finds
+// problems that would otherwise only surface for some values, like a struct
+// nested in a list that's usually empty.
+//
+// Types that convert values themselves, like AttributeValueSetters and
+// tftypes.ValueConverters, can't be checked without a value, and are assumed
+// to be compatible. Problems in the elements of lists, sets, and maps are
+// reported at the path of the list, set, or map.
+//
+// Null and unknown values aren't considered; see CanHoldNull and
+// CanHoldUnknown.
+func CheckType(ctx context.Context, typ attr.Type, target reflect.Type, path *tftypes.AttributePath) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	info := getTypeInfo(target)
+	if info.attributeValueSetter || info.valueConverter || info.iface {
+		return diags
+	}
+
+	tfType := typ.TerraformType(ctx)
+
+	if info.attrValue {
+		val, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(tfType, nil))
+		if err != nil {
+			return append(diags, valueFromTerraformErrorDiag(err, path))
+		}
+		if reflect.TypeOf(val) != target {
+			diags.Append(DiagNewAttributeValueIntoWrongType{
+				ValType:    reflect.TypeOf(val),
+				TargetType: target,
+				SchemaType: typ,
+				AttrPath:   path,
+			})
+		}
+		return diags
+	}
+
+	if target == bigFloatType || target == bigIntType {
+		return checkTerraformType(tfType, tftypes.Number, target, path)
+	}
+	if info.text {
+		return checkTerraformType(tfType, tftypes.String, target, path)
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		return checkStruct(ctx, typ, target, path)
+	case reflect.Bool:
+		return checkTerraformType(tfType, tftypes.Bool, target, path)
+	case reflect.String:
+		return checkTerraformType(tfType, tftypes.String, target, path)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return checkTerraformType(tfType, tftypes.Number, target, path)
+	case reflect.Slice:
+		elemTyper, ok := typ.(attr.TypeWithElementType)
+		if !ok || !(tfType.Is(tftypes.List{}) || tfType.Is(tftypes.Set{})) {
+			return append(diags, checkTypeErrorDiag(fmt.Errorf("can't use %s for %s, slices can only be used for lists and sets", target, tfType), path))
+		}
+		return CheckType(ctx, elemTyper.ElementType(), target.Elem(), path)
+	case reflect.Map:
+		elemTyper, ok := typ.(attr.TypeWithElementType)
+		if !ok || !tfType.Is(tftypes.Map{}) {
+			return append(diags, checkTypeErrorDiag(fmt.Errorf("can't use %s for %s, maps can only be used for maps", target, tfType), path))
+		}
+		if target.Key().Kind() != reflect.String {
+			return append(diags, checkTypeErrorDiag(fmt.Errorf("can't use %s for %s, map keys must be strings", target, tfType), path))
+		}
+		return CheckType(ctx, elemTyper.ElementType(), target.Elem(), path)
+	case reflect.Ptr:
+		return CheckType(ctx, typ, target.Elem(), path)
+	default:
+		return append(diags, checkTypeErrorDiag(fmt.Errorf("don't know how to reflect %s into %s", tfType, target), path))
+	}
+}
+
+// checkTerraformType returns an error diagnostic if `got` isn't `expected`,
+// the only type that can be used with `target`.
+func checkTerraformType(got, expected tftypes.Type, target reflect.Type, path *tftypes.AttributePath) diag.Diagnostics {
+	if got.Is(expected) {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		checkTypeErrorDiag(fmt.Errorf("can't use %s for %s, it can only be used for %s", target, got, expected), path),
+	}
+}
+
+// checkStruct checks the fields of the struct type `target` against the
+// attributes of the object type `typ`, reporting every attribute without a
+// field and every field without an attribute.
+func checkStruct(ctx context.Context, typ attr.Type, target reflect.Type, path *tftypes.AttributePath) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attrsType, ok := typ.(attr.TypeWithAttributeTypes)
+	if !ok || !typ.TerraformType(ctx).Is(tftypes.Object{}) {
+		return append(diags, checkTypeErrorDiag(fmt.Errorf("can't use %s for %s, structs can only be used for objects", target, typ.TerraformType(ctx)), path))
+	}
+
+	fields, err := StructFields(ctx, target)
+	if err != nil {
+		return append(diags, checkTypeErrorDiag(fmt.Errorf("error retrieving field names from struct tags: %w", err), path))
+	}
+
+	attrTypes := attrsType.AttributeTypes()
+
+	names := make([]string, 0, len(attrTypes)+len(fields))
+	for name := range attrTypes {
+		names = append(names, name)
+	}
+	for name := range fields {
+		if _, ok := attrTypes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attrType, inType := attrTypes[name]
+		field, inStruct := fields[name]
+		attrPath := path.WithAttributeName(name)
+
+		switch {
+		case !inStruct:
+			diags.Append(checkTypeErrorDiag(fmt.Errorf("%s has no field for attribute %q", target, name), attrPath))
+		case !inType:
+			diags.Append(checkTypeErrorDiag(fmt.Errorf("field %s of %s has no attribute %q", field.Name, target, name), attrPath))
+		default:
+			diags.Append(CheckType(ctx, attrType, field.Type, attrPath)...)
+		}
+	}
+
+	return diags
+}
+
+// CanHoldNull returns true if BuildValue can build null values into values
+// of the Go type `target`, without the UnhandledNullAsEmpty option. Types
+// that convert values themselves are assumed to handle null values.
+func CanHoldNull(target reflect.Type) bool {
+	info := getTypeInfo(target)
+
+	switch {
+	case info.attributeValueSetter, info.attrValue, info.valueConverter, info.nullable, info.iface:
+		return true
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanHoldUnknown returns true if BuildValue can build unknown values into
+// values of the Go type `target`, without the UnhandledUnknownAsEmpty
+// option. Types that convert values themselves are assumed to handle unknown
+// values.
+func CanHoldUnknown(target reflect.Type) bool {
+	info := getTypeInfo(target)
+
+	switch {
+	case info.attributeValueSetter, info.attrValue, info.valueConverter, info.unknownable:
+		return true
+	}
+
+	if target.Kind() == reflect.Ptr {
+		return CanHoldUnknown(target.Elem())
+	}
+
+	return false
+}
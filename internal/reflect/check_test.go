@@ -0,0 +1,279 @@
+package reflect_test
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func checkTypeError(path *tftypes.AttributePath, err string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path,
+		"Incompatible Model Type",
+		"The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err,
+	)
+}
+
+func TestCheckType(t *testing.T) {
+	t.Parallel()
+
+	type rule struct {
+		Port     int64  `tfsdk:"port"`
+		Protocol string `tfsdk:"protocol"`
+	}
+
+	type badRule struct {
+		Port  string `tfsdk:"port"`
+		Extra string `tfsdk:"extra"`
+	}
+
+	type common struct {
+		ID types.String `tfsdk:"id"`
+	}
+
+	ruleType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"port":     types.NumberType,
+			"protocol": types.StringType,
+		},
+	}
+
+	type testCase struct {
+		typ      attr.Type
+		target   reflect.Type
+		expected diag.Diagnostics
+	}
+
+	tests := map[string]testCase{
+		"string": {
+			typ:    types.StringType,
+			target: reflect.TypeOf(""),
+		},
+		"string-pointer": {
+			typ:    types.StringType,
+			target: reflect.TypeOf(new(string)),
+		},
+		"number-int": {
+			typ:    types.NumberType,
+			target: reflect.TypeOf(0),
+		},
+		"number-big-float": {
+			typ:    types.NumberType,
+			target: reflect.TypeOf(big.NewFloat(0)),
+		},
+		"int64-uint8": {
+			typ:    types.Int64Type,
+			target: reflect.TypeOf(uint8(0)),
+		},
+		"bool": {
+			typ:    types.BoolType,
+			target: reflect.TypeOf(false),
+		},
+		"duration": {
+			typ:    types.StringType,
+			target: reflect.TypeOf(time.Duration(0)),
+		},
+		"duration-number": {
+			typ:    types.NumberType,
+			target: reflect.TypeOf(time.Duration(0)),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), "can't use time.Duration for tftypes.Number, it can only be used for tftypes.String"),
+			},
+		},
+		"attr-value": {
+			typ:    types.ListType{ElemType: types.StringType},
+			target: reflect.TypeOf(types.List{}),
+		},
+		"attr-value-wrong": {
+			typ:    types.ListType{ElemType: types.StringType},
+			target: reflect.TypeOf(types.Set{}),
+			expected: diag.Diagnostics{
+				refl.DiagNewAttributeValueIntoWrongType{
+					ValType:    reflect.TypeOf(types.List{}),
+					TargetType: reflect.TypeOf(types.Set{}),
+					SchemaType: types.ListType{ElemType: types.StringType},
+					AttrPath:   tftypes.NewAttributePath(),
+				},
+			},
+		},
+		"interface": {
+			typ:    types.ListType{ElemType: types.StringType},
+			target: reflect.TypeOf(map[string]interface{}{}),
+		},
+		"list-slice": {
+			typ:    types.ListType{ElemType: types.StringType},
+			target: reflect.TypeOf([]string{}),
+		},
+		"set-slice": {
+			typ:    types.SetType{ElemType: types.NumberType},
+			target: reflect.TypeOf([]float64{}),
+		},
+		"list-int": {
+			typ:    types.ListType{ElemType: types.StringType},
+			target: reflect.TypeOf(0),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), "can't use int for tftypes.List[tftypes.String], it can only be used for tftypes.Number"),
+			},
+		},
+		"list-wrong-element": {
+			typ:    types.ListType{ElemType: types.StringType},
+			target: reflect.TypeOf([]bool{}),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), "can't use bool for tftypes.String, it can only be used for tftypes.Bool"),
+			},
+		},
+		"map-slice": {
+			typ:    types.MapType{ElemType: types.StringType},
+			target: reflect.TypeOf([]string{}),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), "can't use []string for tftypes.Map[tftypes.String], slices can only be used for lists and sets"),
+			},
+		},
+		"map-int-keys": {
+			typ:    types.MapType{ElemType: types.StringType},
+			target: reflect.TypeOf(map[int]string{}),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), "can't use map[int]string for tftypes.Map[tftypes.String], map keys must be strings"),
+			},
+		},
+		"object-struct": {
+			typ:    ruleType,
+			target: reflect.TypeOf(rule{}),
+		},
+		"object-embedded-struct": {
+			typ: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"id":    types.StringType,
+					"rules": types.ListType{ElemType: ruleType},
+				},
+			},
+			target: reflect.TypeOf(&struct {
+				common
+				Rules []*rule `tfsdk:"rules"`
+			}{}),
+		},
+		"object-struct-mismatch": {
+			typ: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"rules": types.ListType{ElemType: ruleType},
+				},
+			},
+			target: reflect.TypeOf(struct {
+				Rules []badRule `tfsdk:"rules"`
+			}{}),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath().WithAttributeName("rules").WithAttributeName("extra"), `field Extra of reflect_test.badRule has no attribute "extra"`),
+				checkTypeError(tftypes.NewAttributePath().WithAttributeName("rules").WithAttributeName("port"), "can't use string for tftypes.Number, it can only be used for tftypes.String"),
+				checkTypeError(tftypes.NewAttributePath().WithAttributeName("rules").WithAttributeName("protocol"), `reflect_test.badRule has no field for attribute "protocol"`),
+			},
+		},
+		"object-map": {
+			typ:    ruleType,
+			target: reflect.TypeOf(map[string]string{}),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), `can't use map[string]string for tftypes.Object["port":tftypes.Number, "protocol":tftypes.String], maps can only be used for maps`),
+			},
+		},
+		"string-struct": {
+			typ:    types.StringType,
+			target: reflect.TypeOf(rule{}),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), "can't use reflect_test.rule for tftypes.String, structs can only be used for objects"),
+			},
+		},
+		"struct-tags": {
+			typ: ruleType,
+			target: reflect.TypeOf(struct {
+				Port int64
+			}{}),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), `error retrieving field names from struct tags: need a struct tag for "tfsdk" on Port`),
+			},
+		},
+		"channel": {
+			typ:    types.StringType,
+			target: reflect.TypeOf(make(chan string)),
+			expected: diag.Diagnostics{
+				checkTypeError(tftypes.NewAttributePath(), "don't know how to reflect tftypes.String into chan string"),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := refl.CheckType(context.Background(), tc.typ, tc.target, tftypes.NewAttributePath())
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCanHoldNullAndUnknown(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		target          reflect.Type
+		expectedNull    bool
+		expectedUnknown bool
+	}
+
+	tests := map[string]testCase{
+		"string": {
+			target: reflect.TypeOf(""),
+		},
+		"string-pointer": {
+			target:       reflect.TypeOf(new(string)),
+			expectedNull: true,
+		},
+		"slice": {
+			target:       reflect.TypeOf([]string{}),
+			expectedNull: true,
+		},
+		"struct": {
+			target: reflect.TypeOf(struct{}{}),
+		},
+		"attr-value": {
+			target:          reflect.TypeOf(types.String{}),
+			expectedNull:    true,
+			expectedUnknown: true,
+		},
+		"interface": {
+			target:       reflect.TypeOf(map[string]interface{}{}),
+			expectedNull: true,
+		},
+		"unknownable": {
+			target:          reflect.TypeOf(&unknownableString{}),
+			expectedNull:    true,
+			expectedUnknown: true,
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := refl.CanHoldNull(tc.target); got != tc.expectedNull {
+				t.Errorf("expected CanHoldNull to be %t, got %t", tc.expectedNull, got)
+			}
+
+			if got := refl.CanHoldUnknown(tc.target); got != tc.expectedUnknown {
+				t.Errorf("expected CanHoldUnknown to be %t, got %t", tc.expectedUnknown, got)
+			}
+		})
+	}
+}
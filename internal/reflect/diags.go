@@ -41,6 +41,14 @@ func valueFromTerraformErrorDiag(err error, path *tftypes.AttributePath) diag.At
 	)
 }
 
+func checkTypeErrorDiag(err error, path *tftypes.AttributePath) diag.AttributeErrorDiagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path,
+		"Incompatible Model Type",
+		"The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+	)
+}
+
 type DiagIntoIncompatibleType struct {
 	Val        tftypes.Value
 	TargetType reflect.Type
@@ -84,6 +92,13 @@ func (d DiagIntoIncompatibleType) Path() *tftypes.AttributePath {
 	return d.AttrPath
 }
 
+// MarshalJSON returns the JSON representation of the diagnostic, in the same
+// format as the diagnostics in the diag package. It can't be unmarshaled back
+// into a DiagIntoIncompatibleType, only into a diag.Diagnostics.
+func (d DiagIntoIncompatibleType) MarshalJSON() ([]byte, error) {
+	return diag.MarshalDiagnosticJSON(d)
+}
+
 type DiagNewAttributeValueIntoWrongType struct {
 	ValType    reflect.Type
 	TargetType reflect.Type
@@ -126,3 +141,60 @@ func (d DiagNewAttributeValueIntoWrongType) Equal(o diag.Diagnostic) bool {
 func (d DiagNewAttributeValueIntoWrongType) Path() *tftypes.AttributePath {
 	return d.AttrPath
 }
+
+// MarshalJSON returns the JSON representation of the diagnostic, in the same
+// format as the diagnostics in the diag package. It can't be unmarshaled back
+// into a DiagNewAttributeValueIntoWrongType, only into a diag.Diagnostics.
+func (d DiagNewAttributeValueIntoWrongType) MarshalJSON() ([]byte, error) {
+	return diag.MarshalDiagnosticJSON(d)
+}
+
+type DiagIntoParseError struct {
+	Val        tftypes.Value
+	TargetType reflect.Type
+	AttrPath   *tftypes.AttributePath
+	Err        error
+}
+
+func (d DiagIntoParseError) Severity() diag.Severity {
+	return diag.SeverityError
+}
+
+func (d DiagIntoParseError) Summary() string {
+	return "Value Conversion Error"
+}
+
+func (d DiagIntoParseError) Detail() string {
+	return fmt.Sprintf("The value could not be parsed as %s:\n\n%s", d.TargetType, d.Err.Error())
+}
+
+func (d DiagIntoParseError) Equal(o diag.Diagnostic) bool {
+	od, ok := o.(DiagIntoParseError)
+	if !ok {
+		return false
+	}
+	if !d.Val.Equal(od.Val) {
+		return false
+	}
+	if d.TargetType != od.TargetType {
+		return false
+	}
+	if !d.AttrPath.Equal(od.AttrPath) {
+		return false
+	}
+	if d.Err.Error() != od.Err.Error() {
+		return false
+	}
+	return true
+}
+
+func (d DiagIntoParseError) Path() *tftypes.AttributePath {
+	return d.AttrPath
+}
+
+// MarshalJSON returns the JSON representation of the diagnostic, in the same
+// format as the diagnostics in the diag package. It can't be unmarshaled back
+// into a DiagIntoParseError, only into a diag.Diagnostics.
+func (d DiagIntoParseError) MarshalJSON() ([]byte, error) {
+	return diag.MarshalDiagnosticJSON(d)
+}
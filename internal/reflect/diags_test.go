@@ -0,0 +1,77 @@
+package reflect_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDiagnosticsJSON(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(2).WithAttributeName("port")
+
+	diags := diag.Diagnostics{
+		refl.DiagIntoIncompatibleType{
+			Val:        tftypes.NewValue(tftypes.String, "hello"),
+			TargetType: reflect.TypeOf(0),
+			AttrPath:   path,
+			Err:        errors.New("cannot reflect"),
+		},
+		refl.DiagNewAttributeValueIntoWrongType{
+			ValType:    reflect.TypeOf(types.String{}),
+			TargetType: reflect.TypeOf(types.Bool{}),
+			SchemaType: types.StringType,
+			AttrPath:   path,
+		},
+		refl.DiagIntoParseError{
+			Val:        tftypes.NewValue(tftypes.String, "soon"),
+			TargetType: reflect.TypeOf(0),
+			AttrPath:   path,
+			Err:        errors.New("invalid duration"),
+		},
+	}
+
+	for _, d := range diags {
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("Unexpected error marshaling %T: %s", d, err)
+		}
+
+		var got map[string]interface{}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unexpected error unmarshaling %T: %s", d, err)
+		}
+
+		if got["path"] != "rule[2].port" {
+			t.Errorf("Expected %T to have path %q, got %v", d, "rule[2].port", got["path"])
+		}
+
+		if got["severity"] != "error" || got["summary"] != d.Summary() || got["detail"] != d.Detail() {
+			t.Errorf("Unexpected JSON for %T: %s", d, data)
+		}
+	}
+
+	data, err := json.Marshal(diags)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling diagnostics: %s", err)
+	}
+
+	var roundTripped diag.Diagnostics
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("Unexpected error unmarshaling diagnostics: %s", err)
+	}
+
+	for i, d := range roundTripped {
+		expected := diag.NewAttributeErrorDiagnostic(path, diags[i].Summary(), diags[i].Detail())
+		if !d.Equal(expected) {
+			t.Errorf("Expected diagnostic %d to be %v, got %v", i, expected, d)
+		}
+	}
+}
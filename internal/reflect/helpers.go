@@ -3,6 +3,7 @@ package reflect
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
@@ -42,45 +43,244 @@ func commaSeparatedString(in []string) string {
 	}
 }
 
-// getStructTags returns a map of Terraform field names to their position in
-// the tags of the struct `in`. `in` must be a struct.
-func getStructTags(ctx context.Context, in reflect.Value, path *tftypes.AttributePath) (map[string]int, error) {
-	tags := map[string]int{}
+// structTag describes a struct field that maps to a Terraform attribute, as
+// parsed from its "tfsdk" tag.
+type structTag struct {
+	// index is the index sequence of the field, suitable for use with
+	// reflect.Value.FieldByIndex.
+	index []int
+
+	// nullIfEmpty is set by the "nullifempty" option. Empty values are
+	// converted to null, and null values are converted to empty values.
+	nullIfEmpty bool
+
+	// unknownAsEmpty is set by the "unknownasempty" option. Unknown values
+	// are converted to empty values, rather than returning an error.
+	unknownAsEmpty bool
+}
+
+// getStructTags returns a map of Terraform field names to the struct fields
+// they map to in the struct `in`. The fields of embedded structs, and
+// embedded pointers to structs, that don't have a "tfsdk" tag are flattened
+// into the map as if they were declared on `in`. `in` must be a struct.
+func getStructTags(ctx context.Context, in reflect.Value, path *tftypes.AttributePath) (map[string]structTag, error) {
+	tags := map[string]structTag{}
 	typ := trueReflectValue(in).Type()
 	if typ.Kind() != reflect.Struct {
 		return nil, path.NewErrorf("can't get struct tags of %s, is not a struct", in.Type())
 	}
+	err := addStructTags(ctx, typ, typ, nil, tags, path)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// StructField is a struct field that's mapped to a Terraform attribute.
+type StructField struct {
+	// Name is the name of the field, including the names of any embedded
+	// structs it's promoted from, like "CommonFields.ID".
+	Name string
+
+	// Type is the type of the field.
+	Type reflect.Type
+
+	// NullIfEmpty and UnknownAsEmpty are set by the "nullifempty" and
+	// "unknownasempty" tag options.
+	NullIfEmpty    bool
+	UnknownAsEmpty bool
+}
+
+// StructFields returns the fields of the struct type `typ` that Struct and
+// FromStruct map to Terraform attributes, keyed by attribute name.
+func StructFields(ctx context.Context, typ reflect.Type) (map[string]StructField, error) {
+	root := typ
+	for root.Kind() == reflect.Ptr {
+		root = root.Elem()
+	}
+
+	tags, err := getStructTags(ctx, reflect.Zero(root), tftypes.NewAttributePath())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]StructField, len(tags))
+	for name, tag := range tags {
+		fields[name] = StructField{
+			Name:           structFieldName(root, tag.index),
+			Type:           root.FieldByIndex(tag.index).Type,
+			NullIfEmpty:    tag.nullIfEmpty,
+			UnknownAsEmpty: tag.unknownAsEmpty,
+		}
+	}
+
+	return fields, nil
+}
+
+// addStructTags adds the Terraform field names of the struct type `typ`,
+// which is found at `index` in the struct type `root`, to `tags`, recursing
+// into embedded structs.
+func addStructTags(ctx context.Context, root, typ reflect.Type, index []int, tags map[string]structTag, path *tftypes.AttributePath) error {
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
-		if field.PkgPath != "" {
-			// skip unexported fields
-			continue
-		}
+		fieldIndex := make([]int, len(index), len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex = append(fieldIndex, i)
 		tag := field.Tag.Get(`tfsdk`)
 		if tag == "-" {
 			// skip explicitly excluded fields
 			continue
 		}
-		if tag == "" {
-			return nil, path.NewErrorf(`need a struct tag for "tfsdk" on %s`, field.Name)
+		if field.Anonymous && tag == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if field.PkgPath != "" && field.Type.Kind() == reflect.Ptr {
+					return path.NewErrorf("can't flatten embedded pointer to unexported struct %s, embed the struct instead or tag it", structFieldName(root, fieldIndex))
+				}
+				if embedsType(root, index, embedded) {
+					return path.NewErrorf("can't flatten embedded struct %s, it embeds itself", structFieldName(root, fieldIndex))
+				}
+				err := addStructTags(ctx, root, embedded, fieldIndex, tags, path)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// skip unexported fields
+			continue
+		}
+		name, parsed, err := parseStructTag(tag)
+		if err != nil {
+			return path.NewErrorf(`invalid struct tag for "tfsdk" on %s: %w`, structFieldName(root, fieldIndex), err)
 		}
-		path := path.WithAttributeName(tag)
-		if !isValidFieldName(tag) {
-			return nil, path.NewError(errors.New("invalid field name, must only use lowercase letters, underscores, and numbers, and must start with a letter"))
+		if name == "" {
+			return path.NewErrorf(`need a struct tag for "tfsdk" on %s`, structFieldName(root, fieldIndex))
 		}
-		if other, ok := tags[tag]; ok {
-			return nil, path.NewErrorf("can't use field name for both %s and %s", typ.Field(other).Name, field.Name)
+		path := path.WithAttributeName(name)
+		if !isValidFieldName(name) {
+			return path.NewError(errors.New("invalid field name, must only use lowercase letters, underscores, and numbers, and must start with a letter"))
 		}
-		tags[tag] = i
+		if other, ok := tags[name]; ok {
+			return path.NewErrorf("can't use field name for both %s and %s", structFieldName(root, other.index), structFieldName(root, fieldIndex))
+		}
+		parsed.index = fieldIndex
+		tags[name] = parsed
 	}
-	return tags, nil
+	return nil
 }
 
+// parseStructTag splits a "tfsdk" tag like "name,nullifempty" into the
+// field name and its options.
+func parseStructTag(tag string) (string, structTag, error) {
+	var parsed structTag
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "nullifempty":
+			parsed.nullIfEmpty = true
+		case "unknownasempty":
+			parsed.unknownAsEmpty = true
+		default:
+			return "", parsed, fmt.Errorf("unknown option %q", opt)
+		}
+	}
+	return parts[0], parsed, nil
+}
+
+// structFieldName returns the name of the field at `index` in the struct
+// type `root`, including the names of any embedded structs it's promoted
+// from, like "CommonFields.ID".
+func structFieldName(root reflect.Type, index []int) string {
+	names := make([]string, 0, len(index))
+	typ := root
+	for _, i := range index {
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		field := typ.Field(i)
+		names = append(names, field.Name)
+		typ = field.Type
+	}
+	return strings.Join(names, ".")
+}
+
+// embedsType returns true if `typ` is `root` or one of the structs embedded
+// along `index` in `root`.
+func embedsType(root reflect.Type, index []int, typ reflect.Type) bool {
+	current := root
+	if current == typ {
+		return true
+	}
+	for _, i := range index {
+		current = current.Field(i).Type
+		if current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+		if current == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// structField returns the field at `index` in the settable struct `in`,
+// allocating any nil embedded struct pointers along the way.
+func structField(in reflect.Value, index []int) reflect.Value {
+	for pos, i := range index {
+		if pos > 0 && in.Kind() == reflect.Ptr {
+			if in.IsNil() {
+				in.Set(reflect.New(in.Type().Elem()))
+			}
+			in = in.Elem()
+		}
+		in = in.Field(i)
+	}
+	return in
+}
+
+// structFieldOrZero returns the field at `index` in the struct `in`. If any
+// embedded struct pointer along the way is nil, it returns the zero value of
+// the field's type instead.
+func structFieldOrZero(in reflect.Value, index []int) reflect.Value {
+	for pos, i := range index {
+		if pos > 0 && in.Kind() == reflect.Ptr {
+			if in.IsNil() {
+				return reflect.Zero(in.Type().Elem().FieldByIndex(index[pos:]).Type)
+			}
+			in = in.Elem()
+		}
+		in = in.Field(i)
+	}
+	return in
+}
+
+var validFieldName = regexp.MustCompile("^[a-z][a-z0-9_]*$")
+
 // isValidFieldName returns true if `name` can be used as a field name in a
 // Terraform resource or data source.
 func isValidFieldName(name string) bool {
-	re := regexp.MustCompile("^[a-z][a-z0-9_]*$")
-	return re.MatchString(name)
+	return validFieldName.MatchString(name)
+}
+
+// isEmptyValue returns true if `val` is the zero value of its type, or is a
+// slice or map with no elements. attr.Values are never empty, as their zero
+// value is a known value, not null.
+func isEmptyValue(val reflect.Value) bool {
+	if getTypeInfo(val.Type()).attrValue {
+		return false
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Map:
+		return val.Len() == 0
+	default:
+		return val.IsZero()
+	}
 }
 
 // canBeNil returns true if `target`'s type can hold a nil value
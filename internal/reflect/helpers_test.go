@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -110,11 +111,187 @@ func TestGetStructTags_success(t *testing.T) {
 	if len(res) != 1 {
 		t.Errorf("Unexpected result: %v", res)
 	}
-	if res["exported_and_tagged"] != 0 {
+	if diff := cmp.Diff(res["exported_and_tagged"], structTag{index: []int{0}}, cmp.AllowUnexported(structTag{})); diff != "" {
 		t.Errorf("Unexpected result: %v", res)
 	}
 }
 
+func TestGetStructTags_embedded(t *testing.T) {
+	t.Parallel()
+
+	type Timeouts struct {
+		Create string `tfsdk:"create_timeout"`
+	}
+	type commonFields struct {
+		ID string `tfsdk:"id"`
+	}
+	type CommonFields struct {
+		commonFields
+		*Timeouts
+		Tags    map[string]string `tfsdk:"tags"`
+		Ignored string            `tfsdk:"-"`
+	}
+	type testStruct struct {
+		CommonFields
+		Name     string   `tfsdk:"name"`
+		Excluded Timeouts `tfsdk:"-"`
+		Tagged   Timeouts `tfsdk:"tagged"`
+	}
+
+	res, err := getStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[string]structTag{
+		"id":             {index: []int{0, 0, 0}},
+		"create_timeout": {index: []int{0, 1, 0}},
+		"tags":           {index: []int{0, 2}},
+		"name":           {index: []int{1}},
+		"tagged":         {index: []int{3}},
+	}
+	if diff := cmp.Diff(res, expected, cmp.AllowUnexported(structTag{})); diff != "" {
+		t.Errorf("Unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	t.Parallel()
+
+	type Timeouts struct {
+		Create string `tfsdk:"create_timeout"`
+	}
+	type testStruct struct {
+		*Timeouts
+		Name    string            `tfsdk:"name,nullifempty"`
+		Tags    map[string]string `tfsdk:"tags"`
+		Ignored string            `tfsdk:"-"`
+	}
+
+	res, err := StructFields(context.Background(), reflect.TypeOf(&testStruct{}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[string]StructField{
+		"create_timeout": {Name: "Timeouts.Create", Type: reflect.TypeOf("")},
+		"name":           {Name: "Name", Type: reflect.TypeOf(""), NullIfEmpty: true},
+		"tags":           {Name: "Tags", Type: reflect.TypeOf(map[string]string{})},
+	}
+	if diff := cmp.Diff(res, expected, cmp.Comparer(func(a, b reflect.Type) bool { return a == b })); diff != "" {
+		t.Errorf("Unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestGetStructTags_options(t *testing.T) {
+	t.Parallel()
+
+	type testStruct struct {
+		Plain    string   `tfsdk:"plain"`
+		Null     string   `tfsdk:"null,nullifempty"`
+		Unknown  string   `tfsdk:"unknown,unknownasempty"`
+		Both     []string `tfsdk:"both,nullifempty,unknownasempty"`
+		Excluded string   `tfsdk:"-"`
+	}
+
+	res, err := getStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[string]structTag{
+		"plain":   {index: []int{0}},
+		"null":    {index: []int{1}, nullIfEmpty: true},
+		"unknown": {index: []int{2}, unknownAsEmpty: true},
+		"both":    {index: []int{3}, nullIfEmpty: true, unknownAsEmpty: true},
+	}
+	if diff := cmp.Diff(res, expected, cmp.AllowUnexported(structTag{})); diff != "" {
+		t.Errorf("Unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestGetStructTags_unknownOption(t *testing.T) {
+	t.Parallel()
+	type testStruct struct {
+		Field string `tfsdk:"field,omitempty"`
+	}
+	_, err := getStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	expected := `invalid struct tag for "tfsdk" on Field: unknown option "omitempty"`
+	if err.Error() != expected {
+		t.Errorf("Expected error to be %q, got %q", expected, err.Error())
+	}
+}
+
+func TestGetStructTags_optionsWithoutName(t *testing.T) {
+	t.Parallel()
+	type testStruct struct {
+		Field string `tfsdk:",nullifempty"`
+	}
+	_, err := getStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	expected := `need a struct tag for "tfsdk" on Field`
+	if err.Error() != expected {
+		t.Errorf("Expected error to be %q, got %q", expected, err.Error())
+	}
+}
+
+func TestGetStructTags_embeddedDuplicateTag(t *testing.T) {
+	t.Parallel()
+	type CommonFields struct {
+		ID string `tfsdk:"id"`
+	}
+	type testStruct struct {
+		CommonFields
+		ID string `tfsdk:"id"`
+	}
+	_, err := getStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	expected := `AttributeName("id"): can't use field name for both CommonFields.ID and ID`
+	if err.Error() != expected {
+		t.Errorf("Expected error to be %q, got %q", expected, err.Error())
+	}
+}
+
+func TestGetStructTags_embeddedUntagged(t *testing.T) {
+	t.Parallel()
+	type CommonFields struct {
+		ID string
+	}
+	type testStruct struct {
+		*CommonFields
+	}
+	_, err := getStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	expected := `need a struct tag for "tfsdk" on CommonFields.ID`
+	if err.Error() != expected {
+		t.Errorf("Expected error to be %q, got %q", expected, err.Error())
+	}
+}
+
+func TestGetStructTags_embeddedUnexportedPointer(t *testing.T) {
+	t.Parallel()
+	type commonFields struct {
+		ID string `tfsdk:"id"`
+	}
+	type testStruct struct {
+		*commonFields
+	}
+	_, err := getStructTags(context.Background(), reflect.ValueOf(testStruct{}), tftypes.NewAttributePath())
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	expected := `can't flatten embedded pointer to unexported struct commonFields, embed the struct instead or tag it`
+	if err.Error() != expected {
+		t.Errorf("Expected error to be %q, got %q", expected, err.Error())
+	}
+}
+
 func TestGetStructTags_untagged(t *testing.T) {
 	t.Parallel()
 	type testStruct struct {
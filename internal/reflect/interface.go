@@ -0,0 +1,198 @@
+package reflect
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	interfaceMapType   = reflect.TypeOf(map[string]interface{}{})
+)
+
+// isInterfaceTarget returns true if `typ` is interface{} or
+// map[string]interface{}, which Interface can build.
+func isInterfaceTarget(typ reflect.Type) bool {
+	return typ == emptyInterfaceType || typ == interfaceMapType
+}
+
+// Interface builds an interface{} or a map[string]interface{}, depending on
+// the type of `target`, holding plain Go data equivalent to `val`. Strings
+// and bools become string and bool, numbers become int64 if they're integers
+// that fit, and *big.Float otherwise, lists, sets, and tuples become
+// []interface{}, and maps and objects become map[string]interface{}.
+//
+// Null values become opts.NullInterfaceValue, which defaults to nil, unless
+// that can't be assigned to `target`, in which case they become nil. Unknown
+// values become opts.UnknownInterfaceValue; if that's nil, unknown values are
+// an error unless opts.UnhandledUnknownAsEmpty is set, in which case they
+// become nil.
+//
+// It is meant to be called through Into, not directly.
+func Interface(ctx context.Context, typ attr.Type, val tftypes.Value, target reflect.Value, opts Options, path *tftypes.AttributePath) (reflect.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	result, resultDiags := interfaceValue(val, opts, path)
+	diags.Append(resultDiags...)
+
+	if diags.HasError() {
+		return target, diags
+	}
+
+	if result == nil {
+		return reflect.Zero(target.Type()), diags
+	}
+
+	resultValue := reflect.ValueOf(result)
+	if !resultValue.Type().AssignableTo(target.Type()) {
+		// opts.NullInterfaceValue is meant for interface{} targets; a
+		// null value can't be anything but a nil map[string]interface{}
+		if val.IsKnown() && val.IsNull() {
+			return reflect.Zero(target.Type()), diags
+		}
+
+		diags.Append(DiagIntoIncompatibleType{
+			Val:        val,
+			TargetType: target.Type(),
+			AttrPath:   path,
+			Err:        fmt.Errorf("cannot reflect %s into %s", val.Type(), target.Type()),
+		})
+		return target, diags
+	}
+
+	return resultValue, diags
+}
+
+// InterfaceValue returns the plain Go data equivalent to `val`, as described
+// by Interface.
+func InterfaceValue(val tftypes.Value, opts Options) (interface{}, diag.Diagnostics) {
+	return interfaceValue(val, opts, tftypes.NewAttributePath())
+}
+
+// interfaceValue returns the plain Go data equivalent to `val`, which is
+// found at `path`, as described by Interface.
+func interfaceValue(val tftypes.Value, opts Options, path *tftypes.AttributePath) (interface{}, diag.Diagnostics) {
+	if !val.IsKnown() {
+		if opts.UnknownInterfaceValue != nil {
+			return opts.UnknownInterfaceValue, nil
+		}
+		if opts.UnhandledUnknownAsEmpty {
+			return nil, nil
+		}
+		return nil, interfaceValueErrorDiags(path, fmt.Errorf("unhandled unknown value"))
+	}
+
+	if val.IsNull() {
+		return opts.NullInterfaceValue, nil
+	}
+
+	typ := val.Type()
+
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := val.As(&s); err != nil {
+			return nil, interfaceValueErrorDiags(path, err)
+		}
+		return s, nil
+	case typ.Is(tftypes.Bool):
+		var b bool
+		if err := val.As(&b); err != nil {
+			return nil, interfaceValueErrorDiags(path, err)
+		}
+		return b, nil
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := val.As(&n); err != nil {
+			return nil, interfaceValueErrorDiags(path, err)
+		}
+		if n.IsInt() {
+			if i, accuracy := n.Int64(); accuracy == big.Exact {
+				return i, nil
+			}
+		}
+		return n, nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := val.As(&elems); err != nil {
+			return nil, interfaceValueErrorDiags(path, err)
+		}
+		result := make([]interface{}, 0, len(elems))
+		for pos, elem := range elems {
+			elemPath := path.WithElementKeyInt(int64(pos))
+			if typ.Is(tftypes.Set{}) {
+				elemPath = path.WithElementKeyValue(elem)
+			}
+			converted, diags := interfaceValue(elem, opts, elemPath)
+			if diags.HasError() {
+				return nil, diags
+			}
+			result = append(result, converted)
+		}
+		return result, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		elems := map[string]tftypes.Value{}
+		if err := val.As(&elems); err != nil {
+			return nil, interfaceValueErrorDiags(path, err)
+		}
+		result := make(map[string]interface{}, len(elems))
+		for key, elem := range elems {
+			elemPath := path.WithElementKeyString(key)
+			if typ.Is(tftypes.Object{}) {
+				elemPath = path.WithAttributeName(key)
+			}
+			converted, diags := interfaceValue(elem, opts, elemPath)
+			if diags.HasError() {
+				return nil, diags
+			}
+			result[key] = converted
+		}
+		return result, nil
+	default:
+		return nil, interfaceValueErrorDiags(path, fmt.Errorf("don't know how to reflect %s into interface{}", typ))
+	}
+}
+
+func interfaceValueErrorDiags(path *tftypes.AttributePath, err error) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.NewAttributeErrorDiagnostic(
+			path,
+			"Value Conversion Error",
+			"An unexpected error was encountered trying to build a value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		),
+	}
+}
+
+// fromInterfaceScalar returns the attr.Value produced by `typ` for values
+// that FromValue can't reflect on: nil, which is converted to null, and
+// tftypes.UnknownValue, which is converted to unknown. ok is false for all
+// other values.
+func fromInterfaceScalar(ctx context.Context, typ attr.Type, val interface{}, path *tftypes.AttributePath) (attr.Value, diag.Diagnostics, bool) {
+	if val != nil && val != tftypes.UnknownValue {
+		return nil, nil, false
+	}
+
+	var diags diag.Diagnostics
+	tfVal := tftypes.NewValue(typ.TerraformType(ctx), val)
+
+	if typeWithValidate, ok := typ.(attr.TypeWithValidate); ok {
+		diags.Append(typeWithValidate.Validate(ctx, tfVal, path)...)
+
+		if diags.HasError() {
+			return nil, diags, true
+		}
+	}
+
+	attrVal, err := typ.ValueFromTerraform(ctx, tfVal)
+	if err != nil {
+		return nil, append(diags, valueFromTerraformErrorDiag(err, path)), true
+	}
+
+	return attrVal, diags, true
+}
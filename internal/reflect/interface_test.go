@@ -0,0 +1,272 @@
+package reflect_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestInto_interface(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"count": tftypes.Number,
+			"ratio": tftypes.Number,
+			"tags":  tftypes.List{ElementType: tftypes.String},
+			"meta":  tftypes.Map{AttributeType: tftypes.Bool},
+			"note":  tftypes.String,
+		},
+	}
+	val := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "example"),
+		"count": tftypes.NewValue(tftypes.Number, 3),
+		"ratio": tftypes.NewValue(tftypes.Number, 1.5),
+		"tags": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "a"),
+			tftypes.NewValue(tftypes.String, "b"),
+		}),
+		"meta": tftypes.NewValue(tftypes.Map{AttributeType: tftypes.Bool}, map[string]tftypes.Value{
+			"enabled": tftypes.NewValue(tftypes.Bool, true),
+		}),
+		"note": tftypes.NewValue(tftypes.String, nil),
+	})
+	expected := map[string]interface{}{
+		"name":  "example",
+		"count": int64(3),
+		"ratio": big.NewFloat(1.5),
+		"tags":  []interface{}{"a", "b"},
+		"meta":  map[string]interface{}{"enabled": true},
+		"note":  nil,
+	}
+	typ := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":  types.StringType,
+			"count": types.NumberType,
+			"ratio": types.NumberType,
+			"tags":  types.ListType{ElemType: types.StringType},
+			"meta":  types.MapType{ElemType: types.BoolType},
+			"note":  types.StringType,
+		},
+	}
+
+	var m map[string]interface{}
+	diags := refl.Into(context.Background(), typ, val, &m, refl.Options{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diff := cmp.Diff(m, expected, cmp.Comparer(bigFloatEqual)); diff != "" {
+		t.Errorf("unexpected map (+got, -expected): %s", diff)
+	}
+
+	var i interface{}
+	diags = refl.Into(context.Background(), typ, val, &i, refl.Options{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diff := cmp.Diff(i, interface{}(expected), cmp.Comparer(bigFloatEqual)); diff != "" {
+		t.Errorf("unexpected interface (+got, -expected): %s", diff)
+	}
+}
+
+func TestInto_interfaceStructField(t *testing.T) {
+	t.Parallel()
+
+	type target struct {
+		Name  string                 `tfsdk:"name"`
+		Extra map[string]interface{} `tfsdk:"extra"`
+	}
+
+	extraType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number}}
+	val := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"extra": extraType,
+		},
+	}, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "example"),
+		"extra": tftypes.NewValue(extraType, map[string]tftypes.Value{
+			"port": tftypes.NewValue(tftypes.Number, 443),
+		}),
+	})
+
+	var got target
+	diags := refl.Into(context.Background(), types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":  types.StringType,
+			"extra": types.ObjectType{AttrTypes: map[string]attr.Type{"port": types.NumberType}},
+		},
+	}, val, &got, refl.Options{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := target{Name: "example", Extra: map[string]interface{}{"port": int64(443)}}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestInto_interfaceNullUnknown(t *testing.T) {
+	t.Parallel()
+
+	listType := tftypes.List{ElementType: tftypes.String}
+	typ := types.ListType{ElemType: types.StringType}
+	val := tftypes.NewValue(listType, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "a"),
+		tftypes.NewValue(tftypes.String, nil),
+		tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	tests := map[string]struct {
+		opts        refl.Options
+		expected    interface{}
+		expectedErr bool
+	}{
+		"default": {
+			expectedErr: true,
+		},
+		"unknown-as-empty": {
+			opts:     refl.Options{UnhandledUnknownAsEmpty: true},
+			expected: []interface{}{"a", nil, nil},
+		},
+		"custom": {
+			opts: refl.Options{
+				NullInterfaceValue:    "NULL",
+				UnknownInterfaceValue: tftypes.UnknownValue,
+			},
+			expected: []interface{}{"a", "NULL", tftypes.UnknownValue},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got interface{}
+			diags := refl.Into(context.Background(), typ, val, &got, test.opts)
+			if test.expectedErr {
+				if !diags.HasError() {
+					t.Fatalf("expected error, got none")
+				}
+				if len(diags) != 1 {
+					t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+				}
+				expectedPath := tftypes.NewAttributePath().WithElementKeyInt(2)
+				if path := diags[0].(diag.DiagnosticWithPath).Path(); !path.Equal(expectedPath) {
+					t.Errorf("expected error at %s, got %s", expectedPath, path)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("unexpected result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestInto_interfaceNullMap(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number}}
+	typ := types.ObjectType{AttrTypes: map[string]attr.Type{"port": types.NumberType}}
+
+	got := map[string]interface{}{"port": int64(443)}
+	diags := refl.Into(context.Background(), typ, tftypes.NewValue(objType, nil), &got, refl.Options{
+		NullInterfaceValue: "NULL",
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got != nil {
+		t.Errorf("expected nil map, got %v", got)
+	}
+
+	var i interface{}
+	diags = refl.Into(context.Background(), typ, tftypes.NewValue(objType, nil), &i, refl.Options{
+		NullInterfaceValue: "NULL",
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diff := cmp.Diff(i, interface{}("NULL")); diff != "" {
+		t.Errorf("unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestFromValue_interface(t *testing.T) {
+	t.Parallel()
+
+	typ := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":  types.StringType,
+			"count": types.NumberType,
+			"tags":  types.ListType{ElemType: types.StringType},
+			"note":  types.StringType,
+			"id":    types.StringType,
+		},
+	}
+
+	got, diags := refl.FromValue(context.Background(), typ, map[string]interface{}{
+		"name":  "example",
+		"count": int64(3),
+		"tags":  []interface{}{"a", nil},
+		"id":    tftypes.UnknownValue,
+	}, tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := types.Object{
+		AttrTypes: typ.AttrTypes,
+		Attrs: map[string]attr.Value{
+			"name":  types.String{Value: "example"},
+			"count": types.Number{Value: big.NewFloat(3)},
+			"tags": types.List{
+				ElemType: types.StringType,
+				Elems: []attr.Value{
+					types.String{Value: "a"},
+					types.String{Null: true},
+				},
+			},
+			"note": types.String{Null: true},
+			"id":   types.String{Unknown: true},
+		},
+	}
+	if diff := cmp.Diff(got, expected, cmp.Comparer(bigFloatEqual)); diff != "" {
+		t.Errorf("unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestFromValue_interfaceExtraKey(t *testing.T) {
+	t.Parallel()
+
+	typ := types.ObjectType{AttrTypes: map[string]attr.Type{"name": types.StringType}}
+
+	_, diags := refl.FromValue(context.Background(), typ, map[string]interface{}{
+		"name":  "example",
+		"other": "value",
+	}, tftypes.NewAttributePath())
+	if !diags.HasError() {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func bigFloatEqual(a, b *big.Float) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
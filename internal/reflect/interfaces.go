@@ -328,3 +328,68 @@ func FromAttributeValue(ctx context.Context, typ attr.Type, val attr.Value, path
 
 	return val, diags
 }
+
+// AttributeValueSetter is an interface for types that can populate themselves
+// from the attr.Value created by an attr.Type, such as typed wrappers around
+// attr.Values, which are not created by any attr.Type themselves.
+type AttributeValueSetter interface {
+	SetAttributeValue(context.Context, attr.Value) error
+}
+
+// NewAttributeValueSetter creates a new value of `target` (or the concrete
+// type it's referencing, if it's a pointer) and calls its SetAttributeValue
+// method with the attr.Value created by calling the ValueFromTerraform method
+// on `typ`.
+//
+// It is meant to be called through Into, not directly.
+func NewAttributeValueSetter(ctx context.Context, typ attr.Type, val tftypes.Value, target reflect.Value, opts Options, path *tftypes.AttributePath) (reflect.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if typeWithValidate, ok := typ.(attr.TypeWithValidate); ok {
+		diags.Append(typeWithValidate.Validate(ctx, val, path)...)
+
+		if diags.HasError() {
+			return target, diags
+		}
+	}
+
+	res, err := typ.ValueFromTerraform(ctx, val)
+	if err != nil {
+		return target, append(diags, valueFromTerraformErrorDiag(err, path))
+	}
+
+	var receiver reflect.Value
+	if target.Kind() == reflect.Ptr {
+		receiver = reflect.New(target.Type().Elem())
+	} else {
+		receiver = reflect.New(target.Type())
+	}
+
+	setter, ok := receiver.Interface().(AttributeValueSetter)
+	if !ok {
+		err := fmt.Errorf("cannot find SetAttributeValue method on type %s", receiver.Type().String())
+		diags.AddAttributeError(
+			path,
+			"Value Conversion Error",
+			"An unexpected error was encountered trying to convert value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return target, diags
+	}
+
+	err = setter.SetAttributeValue(ctx, res)
+	if err != nil {
+		err = fmt.Errorf("reflection error: %w", err)
+		diags.AddAttributeError(
+			path,
+			"Value Conversion Error",
+			"An unexpected error was encountered trying to convert into a value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return target, diags
+	}
+
+	if target.Kind() == reflect.Ptr {
+		return receiver, diags
+	}
+
+	return receiver.Elem(), diags
+}
@@ -219,6 +219,21 @@ func (v *valueCreator) Equal(o *valueCreator) bool {
 
 var _ tftypes.ValueCreator = &valueCreator{}
 
+type attributeValueSetterString struct {
+	Value types.String
+}
+
+func (s *attributeValueSetterString) SetAttributeValue(_ context.Context, v attr.Value) error {
+	str, ok := v.(types.String)
+	if !ok {
+		return fmt.Errorf("can't set type %T", v)
+	}
+	s.Value = str
+	return nil
+}
+
+var _ refl.AttributeValueSetter = &attributeValueSetterString{}
+
 func TestNewUnknownable(t *testing.T) {
 	t.Parallel()
 
@@ -607,3 +622,61 @@ func TestFromValueCreator(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAttributeValueSetter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		typ           attr.Type
+		val           tftypes.Value
+		target        reflect.Value
+		expected      interface{}
+		expectedDiags diag.Diagnostics
+	}{
+		"value": {
+			typ:      types.StringType,
+			val:      tftypes.NewValue(tftypes.String, "hello"),
+			target:   reflect.ValueOf(attributeValueSetterString{}),
+			expected: attributeValueSetterString{Value: types.String{Value: "hello"}},
+		},
+		"pointer": {
+			typ:      types.StringType,
+			val:      tftypes.NewValue(tftypes.String, nil),
+			target:   reflect.ValueOf(new(attributeValueSetterString)),
+			expected: &attributeValueSetterString{Value: types.String{Null: true}},
+		},
+		"error": {
+			typ:    types.BoolType,
+			val:    tftypes.NewValue(tftypes.Bool, true),
+			target: reflect.ValueOf(attributeValueSetterString{}),
+			expectedDiags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath(),
+					"Value Conversion Error",
+					"An unexpected error was encountered trying to convert into a value. This is always an error in the provider. Please report the following to the provider developer:\n\nreflection error: can't set type types.Bool",
+				),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, diags := refl.NewAttributeValueSetter(context.Background(), tc.typ, tc.val, tc.target, refl.Options{}, tftypes.NewAttributePath())
+
+			if diff := cmp.Diff(diags, tc.expectedDiags); diff != "" {
+				t.Errorf("unexpected diagnostics (+wanted, -got): %s", diff)
+			}
+
+			if diags.HasError() {
+				return
+			}
+
+			if diff := cmp.Diff(res.Interface(), tc.expected); diff != "" {
+				t.Errorf("unexpected result (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
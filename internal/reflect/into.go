@@ -20,7 +20,9 @@ import (
 // "tfsdk" tag with the name of the field in the tftypes.Value, and all fields
 // in the tftypes.Value must have a corresponding property in the struct. Into
 // will be called for each struct field. Slices will have Into called for each
-// element.
+// element. time.Duration and encoding.TextUnmarshaler values, like time.Time,
+// are parsed from strings. interface{} and map[string]interface{} targets are
+// populated with plain Go data, as described by Interface.
 func Into(ctx context.Context, typ attr.Type, val tftypes.Value, target interface{}, opts Options) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -60,18 +62,25 @@ func BuildValue(ctx context.Context, typ attr.Type, val tftypes.Value, target re
 		)
 		return target, diags
 	}
+	info := getTypeInfo(target.Type())
+	// if this can populate itself from an attr.Value, build the attr.Value
+	// and let it do that. This needs to come before the attr.Value check,
+	// as these types are usually attr.Values themselves.
+	if info.attributeValueSetter {
+		return NewAttributeValueSetter(ctx, typ, val, target, opts, path)
+	}
 	// if this is an attr.Value, build the type from that
-	if target.Type().Implements(reflect.TypeOf((*attr.Value)(nil)).Elem()) {
+	if info.attrValue {
 		return NewAttributeValue(ctx, typ, val, target, opts, path)
 	}
 	// if this tells tftypes how to build an instance of it out of a
 	// tftypes.Value, well, that's what we want, so do that instead of our
 	// default logic.
-	if target.Type().Implements(reflect.TypeOf((*tftypes.ValueConverter)(nil)).Elem()) {
+	if info.valueConverter {
 		return NewValueConverter(ctx, typ, val, target, opts, path)
 	}
 	// if this can explicitly be set to unknown, do that
-	if target.Type().Implements(reflect.TypeOf((*Unknownable)(nil)).Elem()) {
+	if info.unknownable {
 		res, unknownableDiags := NewUnknownable(ctx, typ, val, target, opts, path)
 		diags.Append(unknownableDiags...)
 		if diags.HasError() {
@@ -86,7 +95,7 @@ func BuildValue(ctx context.Context, typ attr.Type, val tftypes.Value, target re
 		}
 	}
 	// if this can explicitly be set to null, do that
-	if target.Type().Implements(reflect.TypeOf((*Nullable)(nil)).Elem()) {
+	if info.nullable {
 		res, nullableDiags := NewNullable(ctx, typ, val, target, opts, path)
 		diags.Append(nullableDiags...)
 		if diags.HasError() {
@@ -100,6 +109,11 @@ func BuildValue(ctx context.Context, typ attr.Type, val tftypes.Value, target re
 			return target, nil
 		}
 	}
+	// interface{} and map[string]interface{} get plain Go data, with
+	// their own handling of nulls and unknowns
+	if info.iface {
+		return Interface(ctx, typ, val, target, opts, path)
+	}
 	if !val.IsKnown() {
 		// we already handled unknown the only ways we can
 		// we checked that target doesn't have a SetUnknown method we
@@ -144,6 +158,13 @@ func BuildValue(ctx context.Context, typ attr.Type, val tftypes.Value, target re
 	if target.Type() == reflect.TypeOf(big.NewFloat(0)) || target.Type() == reflect.TypeOf(big.NewInt(0)) {
 		return Number(ctx, typ, val, target, opts, path)
 	}
+	// time.Duration and encoding.TextUnmarshalers, including time.Time,
+	// are parsed from strings, even though they're numbers and structs,
+	// but only when the attribute is a string; a time.Duration can still
+	// hold a number
+	if info.text && typ.TerraformType(ctx).Is(tftypes.String) {
+		return Text(ctx, typ, val, target, path)
+	}
 	switch target.Kind() {
 	case reflect.Struct:
 		val, valDiags := Struct(ctx, typ, val, target, opts, path)
@@ -164,6 +185,11 @@ func BuildValue(ctx context.Context, typ attr.Type, val tftypes.Value, target re
 		// let people use the types they want
 		val, valDiags := Number(ctx, typ, val, target, opts, path)
 		diags.Append(valDiags...)
+		// Number builds values of the underlying kind, so named
+		// types like time.Duration need converting back
+		if !diags.HasError() && val.Type() != target.Type() {
+			val = val.Convert(target.Type())
+		}
 		return val, diags
 	case reflect.Slice:
 		val, valDiags := reflectSlice(ctx, typ, val, target, opts, path)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -195,3 +196,100 @@ func FromMap(ctx context.Context, typ attr.TypeWithElementType, val reflect.Valu
 
 	return attrVal, diags
 }
+
+// FromObjectMap returns an attr.Value as produced by `typ` from the data in
+// `val`, which must be a map type with keys that are a string type. Each key
+// in the map must be an attribute of `typ`, and FromObjectMap will recurse
+// into FromValue for each of them. Attributes with no key in the map are null.
+//
+// It is meant to be called through FromValue, not directly.
+func FromObjectMap(ctx context.Context, typ attr.TypeWithAttributeTypes, val reflect.Value, path *tftypes.AttributePath) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tfType := typ.TerraformType(ctx)
+
+	if val.IsNil() {
+		attrVal, nullDiags, _ := fromInterfaceScalar(ctx, typ, nil, path)
+		return attrVal, append(diags, nullDiags...)
+	}
+
+	if val.Type().Key().Kind() != reflect.String {
+		err := fmt.Errorf("map keys must be strings, got %s", val.Type().Key())
+		diags.AddAttributeError(
+			path,
+			"Value Conversion Error",
+			"An unexpected error was encountered trying to convert into a Terraform value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return nil, diags
+	}
+
+	attrTypes := typ.AttributeTypes()
+
+	var unknownKeys []string
+	for _, key := range val.MapKeys() {
+		if _, ok := attrTypes[key.String()]; !ok {
+			unknownKeys = append(unknownKeys, key.String())
+		}
+	}
+	if len(unknownKeys) > 0 {
+		sort.Strings(unknownKeys)
+		err := fmt.Errorf("map defines keys not found in object: %s", commaSeparatedString(unknownKeys))
+		diags.AddAttributeError(
+			path,
+			"Value Conversion Error",
+			"An unexpected error was encountered trying to convert into a Terraform value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return nil, diags
+	}
+
+	tfAttrs := map[string]tftypes.Value{}
+	for name, attrType := range attrTypes {
+		path := path.WithAttributeName(name)
+
+		var attrData interface{}
+		if elem := val.MapIndex(reflect.ValueOf(name).Convert(val.Type().Key())); elem.IsValid() {
+			attrData = elem.Interface()
+		}
+
+		attrVal, attrValDiags := FromValue(ctx, attrType, attrData, path)
+		diags.Append(attrValDiags...)
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		tfVal, err := attrVal.ToTerraformValue(ctx)
+		if err != nil {
+			return nil, append(diags, toTerraformValueErrorDiag(err, path))
+		}
+
+		tfAttrType := attrType.TerraformType(ctx)
+		err = tftypes.ValidateValue(tfAttrType, tfVal)
+		if err != nil {
+			return nil, append(diags, validateValueErrorDiag(err, path))
+		}
+
+		tfAttrs[name] = tftypes.NewValue(tfAttrType, tfVal)
+	}
+
+	err := tftypes.ValidateValue(tfType, tfAttrs)
+	if err != nil {
+		return nil, append(diags, validateValueErrorDiag(err, path))
+	}
+
+	tfVal := tftypes.NewValue(tfType, tfAttrs)
+
+	if typeWithValidate, ok := typ.(attr.TypeWithValidate); ok {
+		diags.Append(typeWithValidate.Validate(ctx, tfVal, path)...)
+
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	attrVal, err := typ.ValueFromTerraform(ctx, tfVal)
+	if err != nil {
+		return nil, append(diags, valueFromTerraformErrorDiag(err, path))
+	}
+
+	return attrVal, diags
+}
@@ -26,7 +26,7 @@ import (
 // It is meant to be called through Into, not directly.
 func Number(ctx context.Context, typ attr.Type, val tftypes.Value, target reflect.Value, opts Options, path *tftypes.AttributePath) (reflect.Value, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	result := big.NewFloat(0)
+	result := new(big.Float)
 	err := val.As(&result)
 	if err != nil {
 		diags.Append(DiagIntoIncompatibleType{
@@ -57,8 +57,8 @@ func Number(ctx context.Context, typ attr.Type, val tftypes.Value, target reflec
 	switch target.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
 		reflect.Int64:
-		intResult, acc := result.Int64()
-		if acc != big.Exact && !opts.AllowRoundingNumbers {
+		intResult, err := BigFloatToInt64(result, opts)
+		if err != nil {
 			return target, append(diags, roundingErrorDiag)
 		}
 		switch target.Kind() {
@@ -182,40 +182,8 @@ func Number(ctx context.Context, typ attr.Type, val tftypes.Value, target reflec
 		}
 		return reflect.ValueOf(floatResult), diags
 	case reflect.Float64:
-		floatResult, acc := result.Float64()
-		if acc != big.Exact && !opts.AllowRoundingNumbers {
-			return target, append(diags, roundingErrorDiag)
-		}
-		if acc == big.Above {
-			if floatResult == math.Inf(1) || floatResult == math.MaxFloat64 {
-				floatResult = math.MaxFloat64
-			} else if floatResult == 0.0 || floatResult == math.SmallestNonzeroFloat64 {
-				floatResult = -math.SmallestNonzeroFloat64
-			} else {
-				err := fmt.Errorf("not sure how to round %s and %f", acc, floatResult)
-				diags.AddAttributeError(
-					path,
-					"Value Conversion Error",
-					"An unexpected error was encountered trying to convert to number. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
-				)
-				return target, diags
-			}
-		} else if acc == big.Below {
-			if floatResult == math.Inf(-1) || floatResult == -math.MaxFloat64 {
-				floatResult = -math.MaxFloat64
-			} else if floatResult == -0.0 || floatResult == -math.SmallestNonzeroFloat64 { //nolint:staticcheck
-				floatResult = math.SmallestNonzeroFloat64
-			} else {
-				err := fmt.Errorf("not sure how to round %s and %f", acc, floatResult)
-				diags.AddAttributeError(
-					path,
-					"Value Conversion Error",
-					"An unexpected error was encountered trying to convert to number. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
-				)
-				return target, diags
-			}
-		} else if acc != big.Exact {
-			err := fmt.Errorf("not sure how to round %s and %f", acc, floatResult)
+		floatResult, err := BigFloatToFloat64(result, opts)
+		if err != nil {
 			diags.AddAttributeError(
 				path,
 				"Value Conversion Error",
@@ -234,6 +202,47 @@ func Number(ctx context.Context, typ attr.Type, val tftypes.Value, target reflec
 	return target, diags
 }
 
+// BigFloatToInt64 returns `f` as an int64. It returns an error if `f` cannot
+// be losslessly represented as an int64, unless opts.AllowRoundingNumbers is
+// set to true, in which case `f` is rounded towards 0, and values outside the
+// range of an int64 become math.MinInt64 or math.MaxInt64.
+func BigFloatToInt64(f *big.Float, opts Options) (int64, error) {
+	intResult, acc := f.Int64()
+	if acc != big.Exact && !opts.AllowRoundingNumbers {
+		return 0, fmt.Errorf("cannot store %s in int64", f.String())
+	}
+	return intResult, nil
+}
+
+// BigFloatToFloat64 returns `f` as a float64. It returns an error if `f`
+// cannot be losslessly represented as a float64, unless
+// opts.AllowRoundingNumbers is set to true, in which case `f` is rounded to
+// the nearest float64, and values outside the range of a float64 become the
+// largest or smallest finite float64.
+func BigFloatToFloat64(f *big.Float, opts Options) (float64, error) {
+	floatResult, acc := f.Float64()
+	if acc != big.Exact && !opts.AllowRoundingNumbers {
+		return 0, fmt.Errorf("cannot store %s in float64", f.String())
+	}
+	// values rounded to a finite, non-zero float64 are usable as-is, but
+	// overflows and underflows need to be clamped to the closest finite,
+	// non-zero float64
+	if acc == big.Above {
+		if floatResult == math.Inf(1) {
+			floatResult = math.MaxFloat64
+		} else if floatResult == 0.0 {
+			floatResult = -math.SmallestNonzeroFloat64
+		}
+	} else if acc == big.Below {
+		if floatResult == math.Inf(-1) {
+			floatResult = -math.MaxFloat64
+		} else if floatResult == 0.0 {
+			floatResult = math.SmallestNonzeroFloat64
+		}
+	}
+	return floatResult, nil
+}
+
 // FromInt creates an attr.Value using `typ` from an int64.
 //
 // It is meant to be called through FromValue, not directly.
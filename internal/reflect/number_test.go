@@ -120,6 +120,21 @@ func TestNumber_int(t *testing.T) {
 	}
 }
 
+func TestNumber_int64Precise(t *testing.T) {
+	t.Parallel()
+
+	var n int64
+
+	result, diags := refl.Number(context.Background(), types.NumberType, tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(math.MaxInt64)), reflect.ValueOf(n), refl.Options{}, tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Errorf("Unexpected error: %v", diags)
+	}
+	reflect.ValueOf(&n).Elem().Set(result)
+	if n != math.MaxInt64 {
+		t.Errorf("Expected %v, got %v", int64(math.MaxInt64), n)
+	}
+}
+
 func TestNumber_intOverflow(t *testing.T) {
 	t.Parallel()
 
@@ -17,4 +17,22 @@ type Options struct {
 	// perfectly in the types they're being stored in, rather than
 	// returning errors. Numbers will always be rounded towards 0.
 	AllowRoundingNumbers bool
+
+	// IgnoreExtraAttributes ignores object attributes that have no
+	// corresponding struct field when building a struct, rather than
+	// returning an error. Struct fields with no corresponding attribute
+	// are always an error.
+	IgnoreExtraAttributes bool
+
+	// NullInterfaceValue is used in place of null values when building
+	// interface{} and map[string]interface{} values, including their
+	// elements. It defaults to nil.
+	NullInterfaceValue interface{}
+
+	// UnknownInterfaceValue is used in place of unknown values when
+	// building interface{} and map[string]interface{} values, including
+	// their elements. If it's nil, unknown values are an error, unless
+	// UnhandledUnknownAsEmpty is set, in which case nil is used. Setting
+	// it to tftypes.UnknownValue lets FromValue convert the data back.
+	UnknownInterfaceValue interface{}
 }
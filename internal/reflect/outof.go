@@ -15,9 +15,16 @@ import (
 // into an attr.Value using the attr.Type supplied. `val` will first be
 // transformed into a tftypes.Value, then passed to `typ`'s ValueFromTerraform
 // method.
+//
+// Plain Go data, like that produced by Interface, can be converted as well: a
+// nil `val` becomes null, tftypes.UnknownValue becomes unknown, and maps with
+// string keys can hold the attributes of objects.
 func FromValue(ctx context.Context, typ attr.Type, val interface{}, path *tftypes.AttributePath) (attr.Value, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	if v, vDiags, ok := fromInterfaceScalar(ctx, typ, val, path); ok {
+		return v, vDiags
+	}
 	if v, ok := val.(attr.Value); ok {
 		return FromAttributeValue(ctx, typ, v, path)
 	}
@@ -37,6 +44,11 @@ func FromValue(ctx context.Context, typ attr.Type, val interface{}, path *tftype
 		return FromBigInt(ctx, typ, bi, path)
 	}
 	value := reflect.ValueOf(val)
+	if typ.TerraformType(ctx).Is(tftypes.String) {
+		if tm, ok := textMarshaler(value); ok {
+			return FromText(ctx, typ, tm, path)
+		}
+	}
 	kind := value.Kind()
 	switch kind {
 	case reflect.Struct:
@@ -66,6 +78,9 @@ func FromValue(ctx context.Context, typ attr.Type, val interface{}, path *tftype
 	case reflect.Slice:
 		return FromSlice(ctx, typ, value, path)
 	case reflect.Map:
+		if t, ok := typ.(attr.TypeWithAttributeTypes); ok {
+			return FromObjectMap(ctx, t, value, path)
+		}
 		t, ok := typ.(attr.TypeWithElementType)
 		if !ok {
 			err := fmt.Errorf("cannot use type %T as schema type %T; %T must be an attr.TypeWithElementType to hold %T", val, typ, typ, val)
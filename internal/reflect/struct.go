@@ -18,11 +18,26 @@ import (
 // The properties on `target` must be tagged with a "tfsdk" label containing
 // the field name to map to that property. Every property must be tagged, and
 // every property must be present in the type of `object`, and all the
-// attributes in the type of `object` must have a corresponding property.
+// attributes in the type of `object` must have a corresponding property,
+// unless opts.IgnoreExtraAttributes is set.
 // Properties that don't map to object attributes must have a `tfsdk:"-"` tag,
 // explicitly defining them as not part of the object. This is to catch typos
 // and other mistakes early.
 //
+// The properties of embedded structs, and embedded pointers to structs, that
+// don't have a "tfsdk" tag are treated as properties of `target`, and nil
+// embedded pointers are allocated as needed. A "tfsdk" tag on an embedded
+// struct maps it to a single attribute instead. The same field name can't be
+// used by more than one property, including promoted ones.
+//
+// The "tfsdk" tag can be followed by comma-separated options, like
+// `tfsdk:"name,nullifempty"`. The "nullifempty" option sets the property to
+// its empty value when the attribute is null, and "unknownasempty" sets it to
+// its empty value when the attribute is unknown, as if
+// opts.UnhandledNullAsEmpty or opts.UnhandledUnknownAsEmpty were set for that
+// property alone. Types that can represent null and unknown values
+// themselves, like attr.Values, still do so.
+//
 // Struct is meant to be called from Into, not directly.
 func Struct(ctx context.Context, typ attr.Type, object tftypes.Value, target reflect.Value, opts Options, path *tftypes.AttributePath) (reflect.Value, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -73,7 +88,7 @@ func Struct(ctx context.Context, typ attr.Type, object tftypes.Value, target ref
 
 	// collect a map of fields that are defined in the tags of the struct
 	// passed in
-	targetFields, err := getStructTags(ctx, target, path)
+	targetFields, err := getCachedStructTags(ctx, target, path)
 	if err != nil {
 		diags.Append(DiagIntoIncompatibleType{
 			Val:        object,
@@ -93,9 +108,11 @@ func Struct(ctx context.Context, typ attr.Type, object tftypes.Value, target ref
 			objectMissing = append(objectMissing, field)
 		}
 	}
-	for field := range objectFields {
-		if _, ok := targetFields[field]; !ok {
-			targetMissing = append(targetMissing, field)
+	if !opts.IgnoreExtraAttributes {
+		for field := range objectFields {
+			if _, ok := targetFields[field]; !ok {
+				targetMissing = append(targetMissing, field)
+			}
 		}
 	}
 	if len(objectMissing) > 0 || len(targetMissing) > 0 {
@@ -120,7 +137,7 @@ func Struct(ctx context.Context, typ attr.Type, object tftypes.Value, target ref
 	// now that we know they match perfectly, fill the struct with the
 	// values in the object
 	result := reflect.New(target.Type()).Elem()
-	for field, structFieldPos := range targetFields {
+	for field, tag := range targetFields {
 		attrType, ok := attrTypes[field]
 		if !ok {
 			diags.Append(DiagIntoIncompatibleType{
@@ -131,14 +148,22 @@ func Struct(ctx context.Context, typ attr.Type, object tftypes.Value, target ref
 			})
 			return target, diags
 		}
-		structField := result.Field(structFieldPos)
-		fieldVal, fieldValDiags := BuildValue(ctx, attrType, objectFields[field], structField, opts, path.WithAttributeName(field))
+		fieldObject := objectFields[field]
+		fieldOpts := opts
+		if tag.nullIfEmpty && fieldObject.IsNull() {
+			fieldOpts.UnhandledNullAsEmpty = true
+		}
+		if tag.unknownAsEmpty && !fieldObject.IsKnown() {
+			fieldOpts.UnhandledUnknownAsEmpty = true
+		}
+		resultField := structField(result, tag.index)
+		fieldVal, fieldValDiags := BuildValue(ctx, attrType, fieldObject, resultField, fieldOpts, path.WithAttributeName(field))
 		diags.Append(fieldValDiags...)
 
 		if diags.HasError() {
 			return target, diags
 		}
-		structField.Set(fieldVal)
+		resultField.Set(fieldVal)
 	}
 	return result, diags
 }
@@ -149,6 +174,17 @@ func Struct(ctx context.Context, typ attr.Type, object tftypes.Value, target ref
 // into FromValue for each attribute, using the type of the attribute as
 // reported by `typ`.
 //
+// Embedded structs are flattened in the same way as they are by Struct.
+// Properties promoted from a nil embedded pointer are converted from the
+// zero value of their type.
+//
+// Properties with the "nullifempty" tag option are converted to null when
+// they hold the empty value of their type, or are slices or maps with no
+// elements, so that values read by Struct are written back unchanged.
+// attr.Values are converted as they are, as they represent null themselves.
+// The "unknownasempty" option has no effect, as Go values without their own
+// notion of unknown can't be converted to unknown values.
+//
 // It is meant to be called through FromValue, not directly.
 func FromStruct(ctx context.Context, typ attr.TypeWithAttributeTypes, val reflect.Value, path *tftypes.AttributePath) (attr.Value, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -157,7 +193,7 @@ func FromStruct(ctx context.Context, typ attr.TypeWithAttributeTypes, val reflec
 
 	// collect a map of fields that are defined in the tags of the struct
 	// passed in
-	targetFields, err := getStructTags(ctx, val, path)
+	targetFields, err := getCachedStructTags(ctx, val, path)
 	if err != nil {
 		err = fmt.Errorf("error retrieving field names from struct tags: %w", err)
 		diags.AddAttributeError(
@@ -169,11 +205,16 @@ func FromStruct(ctx context.Context, typ attr.TypeWithAttributeTypes, val reflec
 	}
 
 	attrTypes := typ.AttributeTypes()
-	for name, fieldNo := range targetFields {
+	for name, tag := range targetFields {
 		path := path.WithAttributeName(name)
-		fieldValue := val.Field(fieldNo)
+		fieldValue := structFieldOrZero(val, tag.index)
+
+		fieldData := fieldValue.Interface()
+		if tag.nullIfEmpty && isEmptyValue(fieldValue) {
+			fieldData = nil
+		}
 
-		attrVal, attrValDiags := FromValue(ctx, attrTypes[name], fieldValue.Interface(), path)
+		attrVal, attrValDiags := FromValue(ctx, attrTypes[name], fieldData, path)
 		diags.Append(attrValDiags...)
 
 		if diags.HasError() {
@@ -201,6 +201,125 @@ func TestNewStruct_primitives(t *testing.T) {
 	}
 }
 
+func TestNewStruct_embedded(t *testing.T) {
+	t.Parallel()
+
+	type Timeouts struct {
+		Create string `tfsdk:"create"`
+	}
+	type CommonFields struct {
+		ID string `tfsdk:"id"`
+		*Timeouts
+	}
+	var s struct {
+		CommonFields
+		Name string `tfsdk:"name"`
+	}
+	result, diags := refl.Struct(context.Background(), types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":     types.StringType,
+			"create": types.StringType,
+			"name":   types.StringType,
+		},
+	}, tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":     tftypes.String,
+			"create": tftypes.String,
+			"name":   tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "abc123"),
+		"create": tftypes.NewValue(tftypes.String, "10m"),
+		"name":   tftypes.NewValue(tftypes.String, "hello"),
+	}), reflect.ValueOf(s), refl.Options{}, tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	reflect.ValueOf(&s).Elem().Set(result)
+	if s.ID != "abc123" {
+		t.Errorf("Expected s.ID to be %q, was %q", "abc123", s.ID)
+	}
+	if s.Timeouts == nil {
+		t.Fatalf("Expected s.Timeouts to be allocated, was nil")
+	}
+	if s.Create != "10m" {
+		t.Errorf("Expected s.Create to be %q, was %q", "10m", s.Create)
+	}
+	if s.Name != "hello" {
+		t.Errorf("Expected s.Name to be %q, was %q", "hello", s.Name)
+	}
+}
+
+func TestNewStruct_tagOptions(t *testing.T) {
+	t.Parallel()
+
+	type myStruct struct {
+		Name    string       `tfsdk:"name,nullifempty"`
+		Tags    []string     `tfsdk:"tags,nullifempty"`
+		ID      string       `tfsdk:"id,unknownasempty"`
+		Wrapped types.String `tfsdk:"wrapped,nullifempty,unknownasempty"`
+	}
+	var s myStruct
+	result, diags := refl.Struct(context.Background(), types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":    types.StringType,
+			"tags":    types.ListType{ElemType: types.StringType},
+			"id":      types.StringType,
+			"wrapped": types.StringType,
+		},
+	}, tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":    tftypes.String,
+			"tags":    tftypes.List{ElementType: tftypes.String},
+			"id":      tftypes.String,
+			"wrapped": tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"name":    tftypes.NewValue(tftypes.String, nil),
+		"tags":    tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"id":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"wrapped": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}), reflect.ValueOf(s), refl.Options{}, tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	reflect.ValueOf(&s).Elem().Set(result)
+
+	expected := myStruct{
+		Wrapped: types.String{Unknown: true},
+	}
+	if diff := cmp.Diff(s, expected); diff != "" {
+		t.Errorf("Unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestNewStruct_tagOptionsScoped(t *testing.T) {
+	t.Parallel()
+
+	type myStruct struct {
+		Name string `tfsdk:"name,nullifempty"`
+		ID   string `tfsdk:"id"`
+	}
+	var s myStruct
+	_, diags := refl.Struct(context.Background(), types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name": types.StringType,
+			"id":   types.StringType,
+		},
+	}, tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name": tftypes.String,
+			"id":   tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"id":   tftypes.NewValue(tftypes.String, "abc123"),
+	}), reflect.ValueOf(s), refl.Options{}, tftypes.NewAttributePath())
+	if !diags.HasError() {
+		t.Fatal("Expected error, nullifempty should not allow unknown values")
+	}
+}
+
 func TestNewStruct_complex(t *testing.T) {
 	t.Parallel()
 
@@ -856,3 +975,191 @@ func TestFromStruct_complex(t *testing.T) {
 		t.Errorf("Didn't get expected value. Diff (+ is expected, - is result): %s", diff)
 	}
 }
+
+func TestFromStruct_tagOptions(t *testing.T) {
+	t.Parallel()
+
+	type myStruct struct {
+		Name    string            `tfsdk:"name,nullifempty"`
+		Count   int64             `tfsdk:"count,nullifempty"`
+		Tags    []string          `tfsdk:"tags,nullifempty"`
+		Meta    map[string]string `tfsdk:"meta,nullifempty"`
+		ID      string            `tfsdk:"id,unknownasempty"`
+		Wrapped types.String      `tfsdk:"wrapped,nullifempty"`
+	}
+
+	objType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":    types.StringType,
+			"count":   types.NumberType,
+			"tags":    types.ListType{ElemType: types.StringType},
+			"meta":    types.MapType{ElemType: types.StringType},
+			"id":      types.StringType,
+			"wrapped": types.StringType,
+		},
+	}
+
+	type testCase struct {
+		val      myStruct
+		expected attr.Value
+	}
+	tests := map[string]testCase{
+		"empty": {
+			val: myStruct{
+				Tags: []string{},
+				Meta: map[string]string{},
+			},
+			expected: types.Object{
+				Attrs: map[string]attr.Value{
+					"name":    types.String{Null: true},
+					"count":   types.Number{Null: true},
+					"tags":    types.List{ElemType: types.StringType, Null: true},
+					"meta":    types.Map{ElemType: types.StringType, Null: true},
+					"id":      types.String{Value: ""},
+					"wrapped": types.String{Value: ""},
+				},
+				AttrTypes: objType.AttrTypes,
+			},
+		},
+		"set": {
+			val: myStruct{
+				Name:    "hello",
+				Count:   2,
+				Tags:    []string{"a"},
+				Meta:    map[string]string{"k": "v"},
+				ID:      "abc123",
+				Wrapped: types.String{Value: "def456"},
+			},
+			expected: types.Object{
+				Attrs: map[string]attr.Value{
+					"name":  types.String{Value: "hello"},
+					"count": types.Number{Value: big.NewFloat(2)},
+					"tags": types.List{
+						ElemType: types.StringType,
+						Elems:    []attr.Value{types.String{Value: "a"}},
+					},
+					"meta": types.Map{
+						ElemType: types.StringType,
+						Elems:    map[string]attr.Value{"k": types.String{Value: "v"}},
+					},
+					"id":      types.String{Value: "abc123"},
+					"wrapped": types.String{Value: "def456"},
+				},
+				AttrTypes: objType.AttrTypes,
+			},
+		},
+		"null-attr-value": {
+			val: myStruct{
+				Wrapped: types.String{Null: true},
+			},
+			expected: types.Object{
+				Attrs: map[string]attr.Value{
+					"name":    types.String{Null: true},
+					"count":   types.Number{Null: true},
+					"tags":    types.List{ElemType: types.StringType, Null: true},
+					"meta":    types.Map{ElemType: types.StringType, Null: true},
+					"id":      types.String{Value: ""},
+					"wrapped": types.String{Null: true},
+				},
+				AttrTypes: objType.AttrTypes,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actualVal, diags := refl.FromStruct(context.Background(), objType, reflect.ValueOf(tc.val), tftypes.NewAttributePath())
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if diff := cmp.Diff(tc.expected, actualVal, cmp.Comparer(bigFloatEqual)); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestFromStruct_embedded(t *testing.T) {
+	t.Parallel()
+
+	type Timeouts struct {
+		Create *string `tfsdk:"create"`
+	}
+	type CommonFields struct {
+		ID string `tfsdk:"id"`
+		*Timeouts
+	}
+	type myStruct struct {
+		CommonFields
+		Name string `tfsdk:"name"`
+	}
+
+	objType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":     types.StringType,
+			"create": types.StringType,
+			"name":   types.StringType,
+		},
+	}
+
+	create := "10m"
+	type testCase struct {
+		val      myStruct
+		expected attr.Value
+	}
+	tests := map[string]testCase{
+		"allocated": {
+			val: myStruct{
+				CommonFields: CommonFields{
+					ID:       "abc123",
+					Timeouts: &Timeouts{Create: &create},
+				},
+				Name: "hello",
+			},
+			expected: types.Object{
+				Attrs: map[string]attr.Value{
+					"id":     types.String{Value: "abc123"},
+					"create": types.String{Value: "10m"},
+					"name":   types.String{Value: "hello"},
+				},
+				AttrTypes: objType.AttrTypes,
+			},
+		},
+		"nil-embedded-pointer": {
+			val: myStruct{
+				CommonFields: CommonFields{
+					ID: "abc123",
+				},
+				Name: "hello",
+			},
+			expected: types.Object{
+				Attrs: map[string]attr.Value{
+					"id":     types.String{Value: "abc123"},
+					"create": types.String{Null: true},
+					"name":   types.String{Value: "hello"},
+				},
+				AttrTypes: objType.AttrTypes,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actualVal, diags := refl.FromStruct(context.Background(), objType, reflect.ValueOf(tc.val), tftypes.NewAttributePath())
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if diff := cmp.Diff(tc.expected, actualVal); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
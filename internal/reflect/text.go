@@ -0,0 +1,150 @@
+package reflect
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isTextUnmarshaler returns true if `typ` is not a pointer and is either a
+// time.Duration or has an UnmarshalText method on its pointer, meaning Text
+// can build it from a string.
+func isTextUnmarshaler(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		return false
+	}
+	return typ == durationType || reflect.PtrTo(typ).Implements(textUnmarshalerType)
+}
+
+// Text builds a time.Duration or an encoding.TextUnmarshaler, depending on
+// the type of `target`, and populates it by parsing the string in `val`.
+// time.Time values are parsed as RFC 3339 timestamps, and time.Duration
+// values are parsed using time.ParseDuration.
+//
+// It is meant to be called through Into, not directly.
+func Text(ctx context.Context, typ attr.Type, val tftypes.Value, target reflect.Value, path *tftypes.AttributePath) (reflect.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !val.Type().Is(tftypes.String) {
+		diags.Append(DiagIntoIncompatibleType{
+			Val:        val,
+			TargetType: target.Type(),
+			AttrPath:   path,
+			Err:        fmt.Errorf("cannot reflect %s into %s, must be a string", val.Type(), target.Type()),
+		})
+		return target, diags
+	}
+
+	var s string
+	err := val.As(&s)
+	if err != nil {
+		diags.Append(DiagIntoIncompatibleType{
+			Val:        val,
+			TargetType: target.Type(),
+			AttrPath:   path,
+			Err:        err,
+		})
+		return target, diags
+	}
+
+	if target.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			diags.Append(DiagIntoParseError{
+				Val:        val,
+				TargetType: target.Type(),
+				AttrPath:   path,
+				Err:        err,
+			})
+			return target, diags
+		}
+		return reflect.ValueOf(d), diags
+	}
+
+	result := reflect.New(target.Type())
+	unmarshaler, ok := result.Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		diags.Append(DiagIntoIncompatibleType{
+			Val:        val,
+			TargetType: target.Type(),
+			AttrPath:   path,
+			Err:        fmt.Errorf("%s does not implement encoding.TextUnmarshaler", result.Type()),
+		})
+		return target, diags
+	}
+
+	err = unmarshaler.UnmarshalText([]byte(s))
+	if err != nil {
+		diags.Append(DiagIntoParseError{
+			Val:        val,
+			TargetType: target.Type(),
+			AttrPath:   path,
+			Err:        err,
+		})
+		return target, diags
+	}
+
+	return result.Elem(), diags
+}
+
+// textMarshaler returns `value` as an encoding.TextMarshaler if it's a
+// time.Duration, or if it or a pointer to it has a MarshalText method.
+// Pointers are not returned, so nil pointers can be handled by FromPointer.
+func textMarshaler(value reflect.Value) (encoding.TextMarshaler, bool) {
+	if !value.IsValid() || value.Kind() == reflect.Ptr {
+		return nil, false
+	}
+	if value.Type() == durationType {
+		return durationMarshaler(value.Interface().(time.Duration)), true
+	}
+	if !getTypeInfo(value.Type()).textMarshaler {
+		return nil, false
+	}
+	if m, ok := value.Interface().(encoding.TextMarshaler); ok {
+		return m, true
+	}
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	return ptr.Interface().(encoding.TextMarshaler), true
+}
+
+// durationMarshaler marshals a time.Duration using its String method, which
+// time.ParseDuration can parse.
+type durationMarshaler time.Duration
+
+func (d durationMarshaler) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// FromText returns an attr.Value as produced by `typ` from the text returned
+// by the MarshalText method of `val`.
+//
+// It is meant to be called through FromValue, not directly.
+func FromText(ctx context.Context, typ attr.Type, val encoding.TextMarshaler, path *tftypes.AttributePath) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	text, err := val.MarshalText()
+	if err != nil {
+		err = fmt.Errorf("error marshaling %T as text: %w", val, err)
+		diags.AddAttributeError(
+			path,
+			"Value Conversion Error",
+			"An unexpected error was encountered trying to convert from value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return nil, diags
+	}
+
+	return FromString(ctx, typ, string(text), path)
+}
@@ -0,0 +1,277 @@
+package reflect_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestText_time(t *testing.T) {
+	t.Parallel()
+
+	var target time.Time
+
+	result, diags := refl.Text(context.Background(), types.StringType, tftypes.NewValue(tftypes.String, "2021-08-31T12:30:00Z"), reflect.ValueOf(target), tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	reflect.ValueOf(&target).Elem().Set(result)
+
+	expected := time.Date(2021, 8, 31, 12, 30, 0, 0, time.UTC)
+	if !target.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, target)
+	}
+}
+
+func TestText_duration(t *testing.T) {
+	t.Parallel()
+
+	var target time.Duration
+
+	result, diags := refl.Text(context.Background(), types.StringType, tftypes.NewValue(tftypes.String, "1h30m"), reflect.ValueOf(target), tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	reflect.ValueOf(&target).Elem().Set(result)
+
+	if target != 90*time.Minute {
+		t.Errorf("Expected %s, got %s", 90*time.Minute, target)
+	}
+}
+
+func TestText_textUnmarshaler(t *testing.T) {
+	t.Parallel()
+
+	var target net.IP
+
+	result, diags := refl.Text(context.Background(), types.StringType, tftypes.NewValue(tftypes.String, "10.0.0.1"), reflect.ValueOf(target), tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	reflect.ValueOf(&target).Elem().Set(result)
+
+	if !target.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("Expected %s, got %s", net.IPv4(10, 0, 0, 1), target)
+	}
+}
+
+func TestText_parseError(t *testing.T) {
+	t.Parallel()
+
+	var target struct {
+		Timeout time.Duration `tfsdk:"timeout"`
+	}
+
+	val := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"timeout": tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"timeout": tftypes.NewValue(tftypes.String, "soon"),
+	})
+
+	expectedDiags := diag.Diagnostics{
+		refl.DiagIntoParseError{
+			Val:        tftypes.NewValue(tftypes.String, "soon"),
+			TargetType: reflect.TypeOf(time.Duration(0)),
+			AttrPath:   tftypes.NewAttributePath().WithAttributeName("timeout"),
+			Err:        errors.New(`time: invalid duration "soon"`),
+		},
+	}
+
+	diags := refl.Into(context.Background(), types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"timeout": types.StringType,
+		},
+	}, val, &target, refl.Options{})
+
+	if diff := cmp.Diff(diags, expectedDiags); diff != "" {
+		t.Errorf("unexpected diagnostics (+got, -expected): %s", diff)
+	}
+}
+
+func TestText_notString(t *testing.T) {
+	t.Parallel()
+
+	var target time.Time
+
+	_, diags := refl.Text(context.Background(), types.NumberType, tftypes.NewValue(tftypes.Number, 123), reflect.ValueOf(target), tftypes.NewAttributePath())
+	if !diags.HasError() {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestFromText(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2021, 8, 31, 12, 30, 0, 0, time.UTC)
+
+	type testCase struct {
+		val      interface{}
+		expected attr.Value
+	}
+	tests := map[string]testCase{
+		"time": {
+			val:      timestamp,
+			expected: types.String{Value: "2021-08-31T12:30:00Z"},
+		},
+		"time-pointer": {
+			val:      &timestamp,
+			expected: types.String{Value: "2021-08-31T12:30:00Z"},
+		},
+		"time-nil-pointer": {
+			val:      (*time.Time)(nil),
+			expected: types.String{Null: true},
+		},
+		"duration": {
+			val:      90 * time.Minute,
+			expected: types.String{Value: "1h30m0s"},
+		},
+		"text-marshaler": {
+			val:      net.IPv4(10, 0, 0, 1),
+			expected: types.String{Value: "10.0.0.1"},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, diags := refl.FromValue(context.Background(), types.StringType, tc.val, tftypes.NewAttributePath())
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if diff := cmp.Diff(actual, tc.expected); diff != "" {
+				t.Errorf("unexpected result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTextRoundTrip_struct(t *testing.T) {
+	t.Parallel()
+
+	type model struct {
+		CreatedAt time.Time     `tfsdk:"created_at"`
+		Timeout   time.Duration `tfsdk:"timeout"`
+		Address   *net.IP       `tfsdk:"address"`
+	}
+
+	objType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"created_at": types.StringType,
+			"timeout":    types.StringType,
+			"address":    types.StringType,
+		},
+	}
+
+	in := model{
+		CreatedAt: time.Date(2021, 8, 31, 12, 30, 0, 0, time.UTC),
+		Timeout:   5 * time.Minute,
+	}
+
+	val, diags := refl.FromValue(context.Background(), objType, in, tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	raw, err := val.ToTerraformValue(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var out model
+	diags = refl.Into(context.Background(), objType, tftypes.NewValue(objType.TerraformType(context.Background()), raw), &out, refl.Options{})
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if diff := cmp.Diff(out, in); diff != "" {
+		t.Errorf("unexpected result (+got, -expected): %s", diff)
+	}
+}
+
+func TestInto_durationNumber(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		typ attr.Type
+		val tftypes.Value
+	}
+	tests := map[string]testCase{
+		"int64": {
+			typ: types.Int64Type,
+			val: tftypes.NewValue(tftypes.Number, 5),
+		},
+		"number": {
+			typ: types.NumberType,
+			val: tftypes.NewValue(tftypes.Number, 5),
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var target time.Duration
+
+			diags := refl.Into(context.Background(), tc.typ, tc.val, &target, refl.Options{})
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if target != 5 {
+				t.Errorf("Expected %d, got %d", time.Duration(5), target)
+			}
+		})
+	}
+}
+
+func TestFromValue_durationNumber(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		typ      attr.Type
+		expected attr.Value
+	}
+	tests := map[string]testCase{
+		"int64": {
+			typ:      types.Int64Type,
+			expected: types.Int64{Value: 5},
+		},
+		"number": {
+			typ:      types.NumberType,
+			expected: types.Number{Value: big.NewFloat(5)},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, diags := refl.FromValue(context.Background(), tc.typ, time.Duration(5), tftypes.NewAttributePath())
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if !actual.Equal(tc.expected) {
+				t.Errorf("Expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
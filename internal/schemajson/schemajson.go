@@ -0,0 +1,111 @@
+// Package schemajson defines the JSON format `terraform providers schema
+// -json` outputs, which tfsdk.ProviderSchemaJSON writes and the tfsdkcompat
+// and tfsdklint packages read.
+package schemajson
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FormatVersion is the version of the format that ProviderSchemaJSON
+// outputs.
+const FormatVersion = "1.0"
+
+// Schemas holds the schemas of one or more providers.
+type Schemas struct {
+	FormatVersion   string               `json:"format_version"`
+	ProviderSchemas map[string]*Provider `json:"provider_schemas,omitempty"`
+}
+
+// Provider holds the schemas of a provider. Unlike Terraform's output,
+// tfsdk.ProviderSchemaJSON includes the provider_meta schema, if the
+// provider has one.
+type Provider struct {
+	Provider          *Schema            `json:"provider,omitempty"`
+	ProviderMeta      *Schema            `json:"provider_meta,omitempty"`
+	ResourceSchemas   map[string]*Schema `json:"resource_schemas,omitempty"`
+	DataSourceSchemas map[string]*Schema `json:"data_source_schemas,omitempty"`
+}
+
+// Schema is the schema of a provider, resource, or data source.
+type Schema struct {
+	Version int64  `json:"version"`
+	Block   *Block `json:"block,omitempty"`
+}
+
+// Block is a block of a schema, holding its attributes and nested blocks.
+type Block struct {
+	Attributes      map[string]*Attribute `json:"attributes,omitempty"`
+	BlockTypes      map[string]*BlockType `json:"block_types,omitempty"`
+	Description     string                `json:"description,omitempty"`
+	DescriptionKind string                `json:"description_kind,omitempty"`
+	Deprecated      bool                  `json:"deprecated,omitempty"`
+}
+
+// Attribute is an attribute of a block or of a nested type. Type is the JSON
+// type signature Terraform uses, like ["list","string"], and is only set if
+// NestedType isn't.
+type Attribute struct {
+	Type            json.RawMessage `json:"type,omitempty"`
+	NestedType      *NestedType     `json:"nested_type,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	DescriptionKind string          `json:"description_kind,omitempty"`
+	Deprecated      bool            `json:"deprecated,omitempty"`
+	Required        bool            `json:"required,omitempty"`
+	Optional        bool            `json:"optional,omitempty"`
+	Computed        bool            `json:"computed,omitempty"`
+	Sensitive       bool            `json:"sensitive,omitempty"`
+}
+
+// NestedType holds the attributes nested within an attribute.
+type NestedType struct {
+	Attributes  map[string]*Attribute `json:"attributes,omitempty"`
+	NestingMode string                `json:"nesting_mode,omitempty"`
+	MinItems    int64                 `json:"min_items,omitempty"`
+	MaxItems    int64                 `json:"max_items,omitempty"`
+}
+
+// BlockType is a block nested within another block.
+type BlockType struct {
+	NestingMode string `json:"nesting_mode,omitempty"`
+	Block       *Block `json:"block,omitempty"`
+	MinItems    int64  `json:"min_items,omitempty"`
+	MaxItems    int64  `json:"max_items,omitempty"`
+}
+
+// ProviderFromJSON returns the schemas of the provider at `address`, such as
+// registry.terraform.io/hashicorp/random, in `in`. If `address` is empty,
+// `in` must hold the schemas of exactly one provider. The returned Provider
+// is never nil.
+func ProviderFromJSON(in []byte, address string) (*Provider, error) {
+	var schemas Schemas
+
+	if err := json.Unmarshal(in, &schemas); err != nil {
+		return nil, err
+	}
+
+	var provider *Provider
+
+	switch {
+	case address != "":
+		p, ok := schemas.ProviderSchemas[address]
+		if !ok {
+			return nil, fmt.Errorf("no schema for provider %q", address)
+		}
+
+		provider = p
+	case len(schemas.ProviderSchemas) != 1:
+		return nil, fmt.Errorf("expected schemas for 1 provider, got %d; specify the provider address", len(schemas.ProviderSchemas))
+	default:
+		for _, p := range schemas.ProviderSchemas {
+			provider = p
+		}
+	}
+
+	if provider == nil {
+		return &Provider{}, nil
+	}
+
+	return provider, nil
+}
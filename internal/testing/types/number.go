@@ -51,7 +51,7 @@ func (t NumberType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (a
 			CreatedBy: t,
 		}, nil
 	}
-	n := big.NewFloat(0)
+	n := new(big.Float)
 	err := in.As(&n)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,25 @@
+package valuestring
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type sensitiveContextKey struct{}
+
+// WithSensitive returns a copy of `ctx` holding `sensitive`, a function
+// reporting whether the value at a path is sensitive, for code that renders
+// values but only has a context to learn about the schema from, like
+// attr.TypeWithValidate implementations.
+func WithSensitive(ctx context.Context, sensitive func(*tftypes.AttributePath) bool) context.Context {
+	return context.WithValue(ctx, sensitiveContextKey{}, sensitive)
+}
+
+// SensitiveFromContext returns the function stored in `ctx` by
+// WithSensitive, or nil if there isn't one.
+func SensitiveFromContext(ctx context.Context) func(*tftypes.AttributePath) bool {
+	sensitive, _ := ctx.Value(sensitiveContextKey{}).(func(*tftypes.AttributePath) bool)
+
+	return sensitive
+}
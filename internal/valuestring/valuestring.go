@@ -0,0 +1,162 @@
+// Package valuestring renders tftypes.Values as short, HCL-like strings for
+// use in diagnostics, logs, and test failures. See the attrvalue package for
+// rendering attr.Values.
+package valuestring
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	// Null is the rendering of a null value.
+	Null = "null"
+
+	// Unknown is the rendering of an unknown value.
+	Unknown = "<unknown>"
+
+	// Sensitive is the rendering of a value that has been masked because
+	// it is sensitive.
+	Sensitive = "<sensitive>"
+)
+
+var identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// String renders a string value as a quoted string.
+func String(s string) string {
+	return strconv.Quote(s)
+}
+
+// Bool renders a bool value.
+func Bool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+// Number renders a number value. Integers are rendered without an exponent
+// unless they are very large.
+func Number(n *big.Float) string {
+	if n == nil {
+		return Null
+	}
+
+	if n.IsInt() && n.MantExp(nil) <= 64 {
+		return n.Text('f', 0)
+	}
+
+	return n.Text('g', -1)
+}
+
+// Sequence renders the already rendered elements of a list, set, or tuple.
+func Sequence(elems []string) string {
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// Attributes renders the already rendered elements of a map or the
+// attributes of an object. Keys are sorted, and quoted unless they are
+// valid identifiers.
+func Attributes(elems map[string]string) string {
+	if len(elems) == 0 {
+		return "{}"
+	}
+
+	keys := make([]string, 0, len(elems))
+	for key := range elems {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		name := key
+		if !identifier.MatchString(key) {
+			name = strconv.Quote(key)
+		}
+		pairs = append(pairs, name+" = "+elems[key])
+	}
+
+	return "{ " + strings.Join(pairs, ", ") + " }"
+}
+
+// Tftypes renders `val`, which is located at `path`. If `sensitive` is not
+// nil, it is called with the path of `val` and of every value nested within
+// it, and any value it returns true for is rendered as Sensitive.
+func Tftypes(val tftypes.Value, path *tftypes.AttributePath, sensitive func(*tftypes.AttributePath) bool) string {
+	if path == nil {
+		path = tftypes.NewAttributePath()
+	}
+
+	if sensitive != nil && sensitive(path) {
+		return Sensitive
+	}
+
+	if !val.IsKnown() {
+		return Unknown
+	}
+
+	if val.IsNull() {
+		return Null
+	}
+
+	typ := val.Type()
+
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := val.As(&s); err != nil {
+			return invalid(val, err)
+		}
+		return String(s)
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := val.As(&n); err != nil {
+			return invalid(val, err)
+		}
+		return Number(n)
+	case typ.Is(tftypes.Bool):
+		var b bool
+		if err := val.As(&b); err != nil {
+			return invalid(val, err)
+		}
+		return Bool(b)
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := val.As(&elems); err != nil {
+			return invalid(val, err)
+		}
+		rendered := make([]string, 0, len(elems))
+		for pos, elem := range elems {
+			elemPath := path.WithElementKeyInt(int64(pos))
+			if typ.Is(tftypes.Set{}) {
+				elemPath = path.WithElementKeyValue(elem)
+			}
+			rendered = append(rendered, Tftypes(elem, elemPath, sensitive))
+		}
+		return Sequence(rendered)
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		elems := map[string]tftypes.Value{}
+		if err := val.As(&elems); err != nil {
+			return invalid(val, err)
+		}
+		rendered := make(map[string]string, len(elems))
+		for key, elem := range elems {
+			elemPath := path.WithElementKeyString(key)
+			if typ.Is(tftypes.Object{}) {
+				elemPath = path.WithAttributeName(key)
+			}
+			rendered[key] = Tftypes(elem, elemPath, sensitive)
+		}
+		return Attributes(rendered)
+	default:
+		return fmt.Sprintf("<unsupported type %s>", typ)
+	}
+}
+
+func invalid(val tftypes.Value, err error) string {
+	return fmt.Sprintf("<invalid %s: %s>", val.Type(), err)
+}
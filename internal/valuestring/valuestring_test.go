@@ -0,0 +1,75 @@
+package valuestring
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTftypes(t *testing.T) {
+	t.Parallel()
+
+	largeInt, _, err := big.ParseFloat("1e100", 10, 512, big.ToNearestEven)
+	if err != nil {
+		t.Fatalf("unexpected error parsing number: %s", err)
+	}
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":     tftypes.String,
+		"secret": tftypes.String,
+	}}
+
+	type testCase struct {
+		input     tftypes.Value
+		sensitive func(*tftypes.AttributePath) bool
+		expected  string
+	}
+	tests := map[string]testCase{
+		"number-large": {
+			input:    tftypes.NewValue(tftypes.Number, largeInt),
+			expected: "1e+100",
+		},
+		"number-fraction": {
+			input:    tftypes.NewValue(tftypes.Number, big.NewFloat(-0.125)),
+			expected: "-0.125",
+		},
+		"tuple": {
+			input: tftypes.NewValue(tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String, tftypes.Bool}}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "a"),
+				tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+			}),
+			expected: `["a", <unknown>]`,
+		},
+		"object-sensitive": {
+			input: tftypes.NewValue(objType, map[string]tftypes.Value{
+				"id":     tftypes.NewValue(tftypes.String, "abc"),
+				"secret": tftypes.NewValue(tftypes.String, "xyz"),
+			}),
+			sensitive: func(path *tftypes.AttributePath) bool {
+				return path.Equal(tftypes.NewAttributePath().WithAttributeName("secret"))
+			},
+			expected: `{ id = "abc", secret = <sensitive> }`,
+		},
+		"root-sensitive": {
+			input: tftypes.NewValue(tftypes.String, "xyz"),
+			sensitive: func(path *tftypes.AttributePath) bool {
+				return true
+			},
+			expected: "<sensitive>",
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Tftypes(tc.input, nil, tc.sensitive)
+
+			if got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
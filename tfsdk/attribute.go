@@ -326,7 +326,7 @@ func (a Attribute) validate(ctx context.Context, req ValidateAttributeRequest, r
 				tfValueRaw, err := value.ToTerraformValue(ctx)
 
 				if err != nil {
-					err := fmt.Errorf("error running ToTerraformValue on element value: %v", value)
+					err := fmt.Errorf("error running ToTerraformValue on element value %s: %w", req.Config.Schema.AttributeValueString(ctx, req.AttributePath, value), err)
 					resp.Diagnostics.AddAttributeError(
 						req.AttributePath,
 						"Attribute Validation Error",
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -21,6 +22,12 @@ func (c Config) Get(ctx context.Context, target interface{}) diag.Diagnostics {
 	return reflect.Into(ctx, c.Schema.AttributeType(), c.Raw, target, reflect.Options{})
 }
 
+// GetWithOptions populates the struct passed as `target` with the entire
+// config, using `opts` to control how values are converted.
+func (c Config) GetWithOptions(ctx context.Context, target interface{}, opts GetOptions) diag.Diagnostics {
+	return reflect.Into(ctx, c.Schema.AttributeType(), c.Raw, target, opts.reflectOptions())
+}
+
 // GetAttribute retrieves the attribute found at `path` and returns it as an
 // attr.Value. Consumers should assert the type of the returned value with the
 // desired attr.Type.
@@ -54,7 +61,7 @@ func (c Config) GetAttribute(ctx context.Context, path *tftypes.AttributePath) (
 	}
 
 	if attrTypeWithValidate, ok := attrType.(attr.TypeWithValidate); ok {
-		diags.Append(attrTypeWithValidate.Validate(ctx, tfValue, path)...)
+		diags.Append(attrTypeWithValidate.Validate(valuestring.WithSensitive(ctx, c.Schema.sensitiveAtPath), tfValue, path)...)
 
 		if diags.HasError() {
 			return nil, diags
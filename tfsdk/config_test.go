@@ -152,6 +152,95 @@ func TestConfigGet_testTypes(t *testing.T) {
 	}
 }
 
+func TestConfigGetWithOptions(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{
+		Attributes: map[string]Attribute{
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"count": {
+				Type:     types.NumberType,
+				Optional: true,
+			},
+		},
+	}
+
+	raw := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"count": tftypes.Number,
+		},
+	}, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "namevalue"),
+		"count": tftypes.NewValue(tftypes.Number, 1),
+	})
+
+	type nameOnly struct {
+		Name types.String `tfsdk:"name"`
+	}
+	type nameAndID struct {
+		Name types.String `tfsdk:"name"`
+		ID   types.String `tfsdk:"id"`
+	}
+
+	type testCase struct {
+		target      interface{}
+		opts        GetOptions
+		expected    interface{}
+		expectError bool
+	}
+
+	testCases := map[string]testCase{
+		"strict-extra-attribute": {
+			target:      &nameOnly{},
+			opts:        GetOptions{},
+			expectError: true,
+		},
+		"lenient-extra-attribute": {
+			target:   &nameOnly{},
+			opts:     GetOptions{Mode: GetModeLenient},
+			expected: &nameOnly{Name: types.String{Value: "namevalue"}},
+		},
+		"lenient-missing-attribute": {
+			target:      &nameAndID{},
+			opts:        GetOptions{Mode: GetModeLenient},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := Config{
+				Raw:    raw,
+				Schema: schema,
+			}
+
+			diags := config.GetWithOptions(context.Background(), tc.target, tc.opts)
+
+			if tc.expectError {
+				if !diags.HasError() {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if diff := cmp.Diff(tc.target, tc.expected); diff != "" {
+				t.Errorf("unexpected value (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
 func TestConfigGetAttribute(t *testing.T) {
 	t.Parallel()
 
@@ -17,6 +17,17 @@ type DataSourceType interface {
 	NewDataSource(context.Context, Provider) (DataSource, diag.Diagnostics)
 }
 
+// DataSourceTypeWithModel is a DataSourceType that declares the struct its
+// data sources read and write state with, so it can be checked against its
+// schema with ValidateModel when the provider is served with
+// ServeOpts.ValidateModels.
+type DataSourceTypeWithModel interface {
+	DataSourceType
+
+	// Model returns a pointer to a zero value of the model struct.
+	Model() interface{}
+}
+
 // DataSource represents a data source instance. This is the core interface that
 // all data sources must implement.
 type DataSource interface {
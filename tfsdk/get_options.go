@@ -0,0 +1,71 @@
+package tfsdk
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+)
+
+// GetMode controls how the fields of a struct are matched to the attributes
+// of the object being converted into it.
+type GetMode uint8
+
+const (
+	// GetModeStrict requires every struct field to have a matching
+	// attribute, and every attribute to have a matching struct field. This
+	// catches typos and schema changes early, and is the mode used by Get.
+	GetModeStrict GetMode = iota
+
+	// GetModeLenient ignores attributes that have no matching struct
+	// field, so a struct can describe just the attributes it needs. Struct
+	// fields with no matching attribute are still rejected.
+	GetModeLenient
+)
+
+// GetOptions controls how GetWithOptions and ValueAsWithOptions convert
+// values into Go types. The zero value converts values the same way Get and
+// ValueAs do.
+type GetOptions struct {
+	// UnhandledNullAsEmpty controls what happens when a null value needs
+	// to be stored in a type that has no way to preserve that
+	// distinction. When set to true, the type's empty value will be used.
+	// When set to false, an error will be returned.
+	UnhandledNullAsEmpty bool
+
+	// UnhandledUnknownAsEmpty controls what happens when an unknown value
+	// needs to be stored in a type that has no way to preserve that
+	// distinction. When set to true, the type's empty value will be used.
+	// When set to false, an error will be returned.
+	UnhandledUnknownAsEmpty bool
+
+	// AllowRoundingNumbers silently rounds numbers that don't fit
+	// perfectly in the types they're being stored in, rather than
+	// returning errors. Numbers will always be rounded towards 0.
+	AllowRoundingNumbers bool
+
+	// NullInterfaceValue is used in place of null values when converting
+	// into interface{} or map[string]interface{} targets. It defaults to
+	// nil.
+	NullInterfaceValue interface{}
+
+	// UnknownInterfaceValue is used in place of unknown values when
+	// converting into interface{} or map[string]interface{} targets. If
+	// it's nil, unknown values are an error unless UnhandledUnknownAsEmpty
+	// is set. Setting it to tftypes.UnknownValue allows the data to be
+	// passed back to Set without losing unknown values.
+	UnknownInterfaceValue interface{}
+
+	// Mode controls how struct fields are matched to attributes. It
+	// applies to the target struct and to any structs nested within it.
+	Mode GetMode
+}
+
+// reflectOptions returns the reflect.Options equivalent of `o`.
+func (o GetOptions) reflectOptions() reflect.Options {
+	return reflect.Options{
+		UnhandledNullAsEmpty:    o.UnhandledNullAsEmpty,
+		UnhandledUnknownAsEmpty: o.UnhandledUnknownAsEmpty,
+		AllowRoundingNumbers:    o.AllowRoundingNumbers,
+		IgnoreExtraAttributes:   o.Mode == GetModeLenient,
+		NullInterfaceValue:      o.NullInterfaceValue,
+		UnknownInterfaceValue:   o.UnknownInterfaceValue,
+	}
+}
@@ -0,0 +1,176 @@
+package tfsdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ValidateModel checks that the struct type of `model`, which is usually a
+// pointer to a zero value like &resourceModel{}, can be used with Get and
+// Set for `schema`, without needing a value of the schema to convert. It
+// returns:
+//
+//   - an error for every attribute without a struct field, and every struct
+//     field without an attribute, including in nested attributes and object
+//     types;
+//   - an error for every field whose Go type can't hold the values of its
+//     attribute, like an int for a list;
+//   - a warning for every field whose Go type can't hold null, like a
+//     string, for an attribute that's optional or computed, and so is null
+//     whenever it isn't set;
+//   - a warning for every field whose Go type can't hold unknown values,
+//     like a string or a *string, for a computed attribute, which is
+//     unknown in plans until it's applied.
+//
+// Fields with the "nullifempty" and "unknownasempty" tag options don't get
+// the warnings about null and unknown values respectively. Problems in the
+// elements of lists, sets, and maps are reported at the path of the list,
+// set, or map.
+//
+// Get and Set only report these problems when they convert a value that
+// runs into them, which is often during apply. ValidateModel is meant to be
+// called from unit tests, or when serving the provider with
+// ServeOpts.ValidateModels.
+func ValidateModel(ctx context.Context, schema Schema, model interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model == nil {
+		diags.AddError(
+			"Invalid Model",
+			"The model must be a struct or a pointer to a struct, got nil. This is always an error in the provider. Please report the following to the provider developer:\n\nValidateModel was called with a nil model.",
+		)
+		return diags
+	}
+
+	typ := reflect.TypeOf(model)
+	path := tftypes.NewAttributePath()
+
+	diags.Append(refl.CheckType(ctx, schema.AttributeType(), typ, path)...)
+
+	if diags.HasError() {
+		// the warnings would be about fields of types that are already
+		// wrong, and only repeat the errors
+		return diags
+	}
+
+	diags.Append(validateModelAttributes(ctx, schema.Attributes, typ, path)...)
+
+	return diags
+}
+
+// validateModelAttributes returns warnings for the fields of the struct
+// type `typ` that can't hold the null or unknown values of their
+// `attributes`, recursing into nested attributes. `typ` is assumed to have
+// already been checked by refl.CheckType.
+func validateModelAttributes(ctx context.Context, attributes map[string]Attribute, typ reflect.Type, path *tftypes.AttributePath) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	fields, err := refl.StructFields(ctx, typ)
+	if err != nil {
+		// already reported by refl.CheckType
+		return diags
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attribute := attributes[name]
+		attrPath := path.WithAttributeName(name)
+
+		field, ok := fields[name]
+		if !ok {
+			continue
+		}
+
+		if !attribute.Required && !field.NullIfEmpty && !refl.CanHoldNull(field.Type) {
+			diags.AddAttributeWarning(
+				attrPath,
+				"Model Can't Hold Null Values",
+				fmt.Sprintf("Field %s is a %s, which can't hold null values, but the attribute is optional or computed, so it's null whenever it isn't set. "+
+					"Getting the attribute will return an error when it's null. Use %s or a pointer, or add the \"nullifempty\" option to the field's tfsdk tag to get an empty value instead.",
+					field.Name, field.Type, modelAttrValueType(ctx, attribute)),
+			)
+		}
+
+		if attribute.Computed && !field.UnknownAsEmpty && !refl.CanHoldUnknown(field.Type) {
+			diags.AddAttributeWarning(
+				attrPath,
+				"Model Can't Hold Unknown Values",
+				fmt.Sprintf("Field %s is a %s, which can't hold unknown values, but the attribute is computed, so it's unknown in plans until it's applied. "+
+					"Getting the attribute from a plan will return an error when it's unknown. Use %s, or add the \"unknownasempty\" option to the field's tfsdk tag to get an empty value instead.",
+					field.Name, field.Type, modelAttrValueType(ctx, attribute)),
+			)
+		}
+
+		if attribute.Attributes == nil {
+			continue
+		}
+
+		if nested, ok := nestedModelType(attribute.Attributes.GetNestingMode(), field.Type); ok {
+			diags.Append(validateModelAttributes(ctx, attribute.Attributes.GetAttributes(), nested, attrPath)...)
+		}
+	}
+
+	return diags
+}
+
+// nestedModelType returns the struct type of the objects in a field of type
+// `typ`, for nested attributes with nesting mode `mode`, if the field uses
+// structs for them rather than a type like types.List.
+func nestedModelType(mode NestingMode, typ reflect.Type) (reflect.Type, bool) {
+	if typ.Implements(attrValueType) {
+		return nil, false
+	}
+
+	switch mode {
+	case NestingModeList, NestingModeSet:
+		if typ.Kind() != reflect.Slice {
+			return nil, false
+		}
+		typ = typ.Elem()
+	case NestingModeMap:
+		if typ.Kind() != reflect.Map {
+			return nil, false
+		}
+		typ = typ.Elem()
+	}
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || typ.Implements(attrValueType) {
+		return nil, false
+	}
+
+	return typ, true
+}
+
+var attrValueType = reflect.TypeOf((*attr.Value)(nil)).Elem()
+
+// modelAttrValueType returns the name of the attr.Value type of
+// `attribute`'s values, like types.String, to suggest in diagnostics.
+func modelAttrValueType(ctx context.Context, attribute Attribute) string {
+	typ := attribute.Type
+	if attribute.Attributes != nil {
+		typ = attribute.Attributes.AttributeType()
+	}
+
+	val, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(typ.TerraformType(ctx), nil))
+	if err != nil {
+		return "an attr.Value type"
+	}
+
+	return reflect.TypeOf(val).String()
+}
@@ -0,0 +1,212 @@
+package tfsdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateModel(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{
+		Attributes: map[string]Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"description": {
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"tags": {
+				Type:     types.ListType{ElemType: types.StringType},
+				Optional: true,
+			},
+			"rule": {
+				Optional: true,
+				Attributes: ListNestedAttributes(map[string]Attribute{
+					"port": {
+						Type:     types.Int64Type,
+						Required: true,
+					},
+					"protocol": {
+						Type:     types.StringType,
+						Optional: true,
+					},
+				}, ListNestedAttributesOptions{}),
+			},
+		},
+	}
+
+	type ruleModel struct {
+		Port     int64  `tfsdk:"port"`
+		Protocol string `tfsdk:"protocol,nullifempty"`
+	}
+
+	type mismatchRuleModel struct {
+		Port int64 `tfsdk:"port"`
+	}
+
+	type mismatchModel struct {
+		Name        string              `tfsdk:"name"`
+		Description types.String        `tfsdk:"description"`
+		Size        int64               `tfsdk:"size"`
+		Tags        int                 `tfsdk:"tags"`
+		Rule        []mismatchRuleModel `tfsdk:"rule"`
+	}
+
+	type testCase struct {
+		model    interface{}
+		expected diag.Diagnostics
+	}
+
+	tests := map[string]testCase{
+		"valid": {
+			model: &struct {
+				ID          types.String `tfsdk:"id"`
+				Name        string       `tfsdk:"name"`
+				Description *string      `tfsdk:"description"`
+				Tags        []string     `tfsdk:"tags"`
+				Rule        []ruleModel  `tfsdk:"rule"`
+			}{},
+		},
+		"valid-attr-values": {
+			model: struct {
+				ID          types.String `tfsdk:"id"`
+				Name        types.String `tfsdk:"name"`
+				Description types.String `tfsdk:"description"`
+				Tags        types.List   `tfsdk:"tags"`
+				Rule        types.List   `tfsdk:"rule"`
+			}{},
+		},
+		"mismatch": {
+			model: &mismatchModel{},
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("id"),
+					"Incompatible Model Type",
+					"The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+						`tfsdk.mismatchModel has no field for attribute "id"`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("rule").WithAttributeName("protocol"),
+					"Incompatible Model Type",
+					"The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+						`tfsdk.mismatchRuleModel has no field for attribute "protocol"`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("size"),
+					"Incompatible Model Type",
+					"The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+						`field Size of tfsdk.mismatchModel has no attribute "size"`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("tags"),
+					"Incompatible Model Type",
+					"The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+						"can't use int for tftypes.List[tftypes.String], it can only be used for tftypes.Number",
+				),
+			},
+		},
+		"struct-tags": {
+			model: &struct {
+				ID          string `tfsdk:"id"`
+				Name        string `tfsdk:"name"`
+				Description string `tfsdk:"description"`
+				Tags        []string
+				Rule        []struct {
+					Port     int64  `tfsdk:"port"`
+					Protocol string `tfsdk:"protocol"`
+				} `tfsdk:"rule"`
+			}{},
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath(),
+					"Incompatible Model Type",
+					"The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+						`error retrieving field names from struct tags: need a struct tag for "tfsdk" on Tags`,
+				),
+			},
+		},
+		"hazards": {
+			model: &struct {
+				ID          string                `tfsdk:"id"`
+				Name        string                `tfsdk:"name"`
+				Description string                `tfsdk:"description"`
+				Tags        []string              `tfsdk:"tags"`
+				Rule        []struct{ ruleModel } `tfsdk:"rule"`
+			}{},
+			expected: diag.Diagnostics{
+				diag.NewAttributeWarningDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("description"),
+					"Model Can't Hold Null Values",
+					"Field Description is a string, which can't hold null values, but the attribute is optional or computed, so it's null whenever it isn't set. "+
+						"Getting the attribute will return an error when it's null. Use types.String or a pointer, or add the \"nullifempty\" option to the field's tfsdk tag to get an empty value instead.",
+				),
+				diag.NewAttributeWarningDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("id"),
+					"Model Can't Hold Null Values",
+					"Field ID is a string, which can't hold null values, but the attribute is optional or computed, so it's null whenever it isn't set. "+
+						"Getting the attribute will return an error when it's null. Use types.String or a pointer, or add the \"nullifempty\" option to the field's tfsdk tag to get an empty value instead.",
+				),
+				diag.NewAttributeWarningDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("id"),
+					"Model Can't Hold Unknown Values",
+					"Field ID is a string, which can't hold unknown values, but the attribute is computed, so it's unknown in plans until it's applied. "+
+						"Getting the attribute from a plan will return an error when it's unknown. Use types.String, or add the \"unknownasempty\" option to the field's tfsdk tag to get an empty value instead.",
+				),
+			},
+		},
+		"nested-hazards": {
+			model: &struct {
+				ID          types.String `tfsdk:"id"`
+				Name        string       `tfsdk:"name"`
+				Description *string      `tfsdk:"description"`
+				Tags        []string     `tfsdk:"tags"`
+				Rule        []struct {
+					Port     int64  `tfsdk:"port"`
+					Protocol string `tfsdk:"protocol"`
+				} `tfsdk:"rule"`
+			}{},
+			expected: diag.Diagnostics{
+				diag.NewAttributeWarningDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("rule").WithAttributeName("protocol"),
+					"Model Can't Hold Null Values",
+					"Field Protocol is a string, which can't hold null values, but the attribute is optional or computed, so it's null whenever it isn't set. "+
+						"Getting the attribute will return an error when it's null. Use types.String or a pointer, or add the \"nullifempty\" option to the field's tfsdk tag to get an empty value instead.",
+				),
+			},
+		},
+		"nil": {
+			model: nil,
+			expected: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Invalid Model",
+					"The model must be a struct or a pointer to a struct, got nil. This is always an error in the provider. Please report the following to the provider developer:\n\nValidateModel was called with a nil model.",
+				),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ValidateModel(context.Background(), schema, tc.model)
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -21,6 +22,12 @@ func (p Plan) Get(ctx context.Context, target interface{}) diag.Diagnostics {
 	return reflect.Into(ctx, p.Schema.AttributeType(), p.Raw, target, reflect.Options{})
 }
 
+// GetWithOptions populates the struct passed as `target` with the entire
+// plan, using `opts` to control how values are converted.
+func (p Plan) GetWithOptions(ctx context.Context, target interface{}, opts GetOptions) diag.Diagnostics {
+	return reflect.Into(ctx, p.Schema.AttributeType(), p.Raw, target, opts.reflectOptions())
+}
+
 // GetAttribute retrieves the attribute found at `path` and returns it as an
 // attr.Value. Consumers should assert the type of the returned value with the
 // desired attr.Type.
@@ -54,7 +61,7 @@ func (p Plan) GetAttribute(ctx context.Context, path *tftypes.AttributePath) (at
 	}
 
 	if attrTypeWithValidate, ok := attrType.(attr.TypeWithValidate); ok {
-		diags.Append(attrTypeWithValidate.Validate(ctx, tfValue, path)...)
+		diags.Append(attrTypeWithValidate.Validate(valuestring.WithSensitive(ctx, p.Schema.sensitiveAtPath), tfValue, path)...)
 
 		if diags.HasError() {
 			return nil, diags
@@ -133,12 +140,14 @@ func (p *Plan) SetAttribute(ctx context.Context, path *tftypes.AttributePath, va
 		return diags
 	}
 
+	validateCtx := valuestring.WithSensitive(ctx, p.Schema.sensitiveAtPath)
+
 	transformFunc := func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
 		if p.Equal(path) {
 			tfVal := tftypes.NewValue(attrType.TerraformType(ctx), newTfVal)
 
 			if attrTypeWithValidate, ok := attrType.(attr.TypeWithValidate); ok {
-				diags.Append(attrTypeWithValidate.Validate(ctx, tfVal, path)...)
+				diags.Append(attrTypeWithValidate.Validate(validateCtx, tfVal, path)...)
 
 				if diags.HasError() {
 					return v, nil
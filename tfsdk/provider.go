@@ -39,3 +39,13 @@ type ProviderWithProviderMeta interface {
 	// GetMetaSchema returns the provider meta schema.
 	GetMetaSchema(context.Context) (Schema, diag.Diagnostics)
 }
+
+// ProviderWithModel is a provider that declares the struct it reads its
+// configuration into, so it can be checked against its schema with
+// ValidateModel when it's served with ServeOpts.ValidateModels.
+type ProviderWithModel interface {
+	Provider
+
+	// Model returns a pointer to a zero value of the model struct.
+	Model() interface{}
+}
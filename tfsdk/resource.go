@@ -17,6 +17,17 @@ type ResourceType interface {
 	NewResource(context.Context, Provider) (Resource, diag.Diagnostics)
 }
 
+// ResourceTypeWithModel is a ResourceType that declares the struct its
+// resources read and write state with, so it can be checked against its
+// schema with ValidateModel when the provider is served with
+// ServeOpts.ValidateModels.
+type ResourceTypeWithModel interface {
+	ResourceType
+
+	// Model returns a pointer to a zero value of the model struct.
+	Model() interface{}
+}
+
 // Resource represents a resource instance. This is the core interface that all
 // resources must implement.
 type Resource interface {
@@ -0,0 +1,71 @@
+package tfsdk
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The fuzz targets make the same checks as the round trip tests, with the
+// fuzzer's input driving the generators. Run them with, for example:
+//
+//	go test ./tfsdk -run '^$' -fuzz FuzzValueRoundTrips
+
+func FuzzValueRoundTrips(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{5, 3, 1, 0, 2})
+	f.Add([]byte{8, 3, 0, 6, 3, 2, 2, 1, 5})
+	f.Add([]byte{6, 8, 2, 0, 0, 3, 4, 4, 0, 2, 7})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkRandomValueRoundTrips(t, &byteChooser{data: data})
+	})
+}
+
+func FuzzStateRoundTrips(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{3, 0, 1, 2, 0, 5, 2, 3, 0, 0, 1})
+	f.Add([]byte{2, 0, 0, 1, 2, 1, 0, 0, 8, 3, 0, 4, 0, 2})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkRandomStateRoundTrips(t, &byteChooser{data: data})
+	})
+}
+
+func FuzzNumberRoundTrips(f *testing.F) {
+	f.Add("0")
+	f.Add("-0.1")
+	f.Add("9223372036854775807")
+	f.Add("9007199254740993")
+	f.Add("123456789012345678901234567890.123456789")
+	f.Add("1e400")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		n, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+		if err != nil || n.IsInf() {
+			t.Skip()
+		}
+
+		ctx := context.Background()
+		val := tftypes.NewValue(tftypes.Number, n)
+
+		typs := []attr.Type{types.NumberType}
+
+		if _, accuracy := n.Int64(); n.IsInt() && accuracy == big.Exact {
+			typs = append(typs, types.Int64Type)
+		}
+
+		if _, accuracy := n.Float64(); accuracy == big.Exact {
+			typs = append(typs, types.Float64Type)
+		}
+
+		for _, typ := range typs {
+			checkValueRoundTrips(ctx, t, typ, val)
+			checkValueRoundTrips(ctx, t, types.SetType{ElemType: typ}, tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{val}))
+		}
+	})
+}
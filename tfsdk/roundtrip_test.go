@@ -0,0 +1,578 @@
+package tfsdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The round trip tests generate random types, schemas, and values, with
+// null and unknown values at every depth, and check that converting them
+// through the framework and back doesn't change them. The same checks are
+// used as fuzz targets in roundtrip_fuzz_test.go.
+
+// roundTripIterations is how many random cases each round trip test checks.
+const roundTripIterations = 300
+
+// maxRoundTripDepth limits how deeply generated types nest.
+const maxRoundTripDepth = 3
+
+// chooser makes the choices the generators need. *rand.Rand is a chooser,
+// as is byteChooser, which fuzz targets use so the fuzzer can control the
+// generated cases.
+type chooser interface {
+	Intn(n int) int
+	Int63() int64
+}
+
+// byteChooser makes choices by consuming `data`. Once it runs out, every
+// choice is 0, which always picks the smallest option, so generation
+// finishes.
+type byteChooser struct {
+	data []byte
+}
+
+func (c *byteChooser) next() byte {
+	if len(c.data) == 0 {
+		return 0
+	}
+
+	b := c.data[0]
+	c.data = c.data[1:]
+
+	return b
+}
+
+func (c *byteChooser) Intn(n int) int {
+	return int(c.next()) % n
+}
+
+func (c *byteChooser) Int63() int64 {
+	var i int64
+
+	for n := 0; n < 8; n++ {
+		i = i<<8 | int64(c.next())
+	}
+
+	return i & math.MaxInt64
+}
+
+// randomType returns a random attr.Type, which is a primitive once `depth`
+// reaches maxRoundTripDepth.
+func randomType(c chooser, depth int) attr.Type {
+	primitives := []attr.Type{
+		types.StringType,
+		types.BoolType,
+		types.NumberType,
+		types.Int64Type,
+		types.Float64Type,
+	}
+
+	if depth >= maxRoundTripDepth {
+		return primitives[c.Intn(len(primitives))]
+	}
+
+	switch n := c.Intn(len(primitives) + 4); n {
+	case len(primitives):
+		return types.ListType{ElemType: randomType(c, depth+1)}
+	case len(primitives) + 1:
+		return types.SetType{ElemType: randomType(c, depth+1)}
+	case len(primitives) + 2:
+		return types.MapType{ElemType: randomType(c, depth+1)}
+	case len(primitives) + 3:
+		attrTypes := map[string]attr.Type{}
+
+		for i, count := 0, c.Intn(4); i < count; i++ {
+			attrTypes[randomName(c)] = randomType(c, depth+1)
+		}
+
+		return types.ObjectType{AttrTypes: attrTypes}
+	default:
+		return primitives[n]
+	}
+}
+
+// randomName returns a random attribute name or map key.
+func randomName(c chooser) string {
+	names := []string{"a", "b", "c", "name", "id", "", "a_b", "ü", "with space"}
+
+	return names[c.Intn(len(names))]
+}
+
+// randomSchema returns a random schema, with nested attributes of every
+// nesting mode.
+func randomSchema(c chooser) Schema {
+	return Schema{
+		Attributes: randomAttributes(c, 0),
+	}
+}
+
+func randomAttributes(c chooser, depth int) map[string]Attribute {
+	attributes := map[string]Attribute{}
+
+	for i, count := 0, 1+c.Intn(4); i < count; i++ {
+		name := fmt.Sprintf("attr_%d", i)
+
+		if depth >= maxRoundTripDepth-1 || c.Intn(3) != 0 {
+			attributes[name] = Attribute{
+				Type:     randomType(c, depth),
+				Optional: true,
+			}
+
+			continue
+		}
+
+		nested := randomAttributes(c, depth+1)
+
+		var nestedAttributes NestedAttributes
+
+		switch c.Intn(4) {
+		case 0:
+			nestedAttributes = SingleNestedAttributes(nested)
+		case 1:
+			nestedAttributes = ListNestedAttributes(nested, ListNestedAttributesOptions{})
+		case 2:
+			nestedAttributes = SetNestedAttributes(nested, SetNestedAttributesOptions{})
+		default:
+			nestedAttributes = MapNestedAttributes(nested, MapNestedAttributesOptions{})
+		}
+
+		attributes[name] = Attribute{
+			Attributes: nestedAttributes,
+			Optional:   true,
+		}
+	}
+
+	return attributes
+}
+
+// randomValue returns a random value of `typ`, which may be null or unknown,
+// or contain null or unknown values.
+func randomValue(ctx context.Context, c chooser, typ attr.Type) tftypes.Value {
+	tfType := typ.TerraformType(ctx)
+
+	switch c.Intn(8) {
+	case 1:
+		return tftypes.NewValue(tfType, nil)
+	case 2:
+		return tftypes.NewValue(tfType, tftypes.UnknownValue)
+	}
+
+	return randomKnownValue(ctx, c, typ)
+}
+
+// randomKnownValue returns a random value of `typ` that isn't null or
+// unknown itself, but may contain null or unknown values.
+func randomKnownValue(ctx context.Context, c chooser, typ attr.Type) tftypes.Value {
+	tfType := typ.TerraformType(ctx)
+
+	switch typ := typ.(type) {
+	case types.ListType:
+		elems := []tftypes.Value{}
+
+		for i, count := 0, c.Intn(4); i < count; i++ {
+			elems = append(elems, randomValue(ctx, c, typ.ElemType))
+		}
+
+		return tftypes.NewValue(tfType, elems)
+	case types.SetType:
+		elems := []tftypes.Value{}
+
+		for i, count := 0, c.Intn(4); i < count; i++ {
+			elem := randomValue(ctx, c, typ.ElemType)
+
+			// Sets can't hold duplicates, but elements that aren't
+			// fully known can't be compared, so they're kept.
+			if elem.IsFullyKnown() && containsValue(elems, elem) {
+				continue
+			}
+
+			elems = append(elems, elem)
+		}
+
+		return tftypes.NewValue(tfType, elems)
+	case types.MapType:
+		elems := map[string]tftypes.Value{}
+
+		for i, count := 0, c.Intn(4); i < count; i++ {
+			elems[randomName(c)] = randomValue(ctx, c, typ.ElemType)
+		}
+
+		return tftypes.NewValue(tfType, elems)
+	case attr.TypeWithAttributeTypes:
+		attrs := map[string]tftypes.Value{}
+
+		for name, attrType := range typ.AttributeTypes() {
+			attrs[name] = randomValue(ctx, c, attrType)
+		}
+
+		return tftypes.NewValue(tfType, attrs)
+	}
+
+	switch {
+	case typ.Equal(types.StringType):
+		strs := []string{"", "hello", "ü", "two words", "\x00", "null", "9007199254740993"}
+
+		return tftypes.NewValue(tfType, strs[c.Intn(len(strs))])
+	case typ.Equal(types.BoolType):
+		return tftypes.NewValue(tfType, c.Intn(2) == 1)
+	case typ.Equal(types.Int64Type):
+		return tftypes.NewValue(tfType, new(big.Float).SetInt64(randomInt64(c)))
+	case typ.Equal(types.Float64Type):
+		return tftypes.NewValue(tfType, big.NewFloat(randomFloat64(c)))
+	case typ.Equal(types.NumberType):
+		return tftypes.NewValue(tfType, randomNumber(c))
+	}
+
+	panic(fmt.Sprintf("can't generate values of %s", typ))
+}
+
+func containsValue(values []tftypes.Value, value tftypes.Value) bool {
+	for _, v := range values {
+		if v.IsFullyKnown() && v.Equal(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func randomInt64(c chooser) int64 {
+	edges := []int64{0, 1, -1, math.MaxInt64, math.MinInt64, 1 << 53, 1<<53 + 1}
+
+	if n := c.Intn(len(edges) + 1); n < len(edges) {
+		return edges[n]
+	}
+
+	return c.Int63() - c.Int63()
+}
+
+func randomFloat64(c chooser) float64 {
+	edges := []float64{0, 1.5, -0.1, math.MaxFloat64, math.SmallestNonzeroFloat64, 1 << 53}
+
+	if n := c.Intn(len(edges) + 1); n < len(edges) {
+		return edges[n]
+	}
+
+	// Terraform numbers can't be infinite or NaN.
+	f := math.Float64frombits(uint64(c.Int63()) << 1)
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0
+	}
+
+	return f
+}
+
+// randomNumber returns a random number, including ones that need more
+// precision than a float64 or int64 can hold.
+func randomNumber(c chooser) *big.Float {
+	edges := []string{"0", "-1", "0.1", "9007199254740993", "123456789012345678901234567890.123456789", "1e400"}
+
+	var s string
+
+	if n := c.Intn(len(edges) + 1); n < len(edges) {
+		s = edges[n]
+	} else {
+		s = fmt.Sprintf("%d.%d", c.Int63()-c.Int63(), c.Int63())
+	}
+
+	f, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// checkValueRoundTrips checks that `val`, which is of `typ`, survives
+// ValueFromTerraform and ToTerraformValue, ConvertValue, and Into and
+// FromValue with interface{} targets.
+func checkValueRoundTrips(ctx context.Context, t *testing.T, typ attr.Type, val tftypes.Value) {
+	t.Helper()
+
+	attrValue, err := typ.ValueFromTerraform(ctx, val)
+	if err != nil {
+		t.Errorf("%s: unexpected ValueFromTerraform error for %s: %s", typ, val, err)
+		return
+	}
+
+	if got := toTerraformValue(ctx, t, attrValue); got != nil && !got.Equal(val) {
+		t.Errorf("%s: ValueFromTerraform and ToTerraformValue changed %s to %s", typ, val, got)
+	}
+
+	converted, diags := ConvertValue(ctx, attrValue, typ)
+	if diags.HasError() {
+		t.Errorf("%s: unexpected ConvertValue diagnostics for %s: %v", typ, val, diags)
+	} else if !converted.Equal(attrValue) {
+		t.Errorf("%s: ConvertValue changed %s to %s", typ, attrValue, converted)
+	}
+
+	var target interface{}
+
+	opts := refl.Options{UnknownInterfaceValue: tftypes.UnknownValue}
+
+	diags = refl.Into(ctx, typ, val, &target, opts)
+	if diags.HasError() {
+		t.Errorf("%s: unexpected Into diagnostics for %s: %v", typ, val, diags)
+		return
+	}
+
+	fromValue, diags := refl.FromValue(ctx, typ, target, tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Errorf("%s: unexpected FromValue diagnostics for %s (%#v): %v", typ, val, target, diags)
+		return
+	}
+
+	if !fromValue.Equal(attrValue) {
+		t.Errorf("%s: Into and FromValue changed %s to %s", typ, attrValue, fromValue)
+	}
+}
+
+func toTerraformValue(ctx context.Context, t *testing.T, val attr.Value) *tftypes.Value {
+	t.Helper()
+
+	raw, err := val.ToTerraformValue(ctx)
+	if err != nil {
+		t.Errorf("unexpected ToTerraformValue error for %s: %s", val, err)
+		return nil
+	}
+
+	tfType := val.Type(ctx).TerraformType(ctx)
+
+	if err := tftypes.ValidateValue(tfType, raw); err != nil {
+		t.Errorf("ToTerraformValue returned an invalid %s for %s: %s", tfType, val, err)
+		return nil
+	}
+
+	result := tftypes.NewValue(tfType, raw)
+
+	return &result
+}
+
+// checkStateRoundTrips checks that every attribute of `state`, at every
+// depth, survives GetAttribute and SetAttribute, that its top-level
+// attributes can be copied to `other` with them, and that the whole state
+// survives Get and Set with a struct target.
+func checkStateRoundTrips(ctx context.Context, t *testing.T, state, other State) {
+	t.Helper()
+
+	for _, path := range attributePaths(ctx, state.Schema.AttributeType(), state.Raw, tftypes.NewAttributePath()) {
+		val, diags := state.GetAttribute(ctx, path)
+		if diags.HasError() {
+			t.Errorf("unexpected GetAttribute diagnostics for %s in %s: %v", path, state.Raw, diags)
+			continue
+		}
+
+		set := State{Raw: state.Raw.Copy(), Schema: state.Schema}
+
+		diags = set.SetAttribute(ctx, path, val)
+		if diags.HasError() {
+			t.Errorf("unexpected SetAttribute diagnostics for %s in %s: %v", path, state.Raw, diags)
+			continue
+		}
+
+		if !set.Raw.Equal(state.Raw) {
+			t.Errorf("GetAttribute and SetAttribute of %s changed %s to %s", path, state.Raw, set.Raw)
+		}
+	}
+
+	copied := State{Raw: other.Raw.Copy(), Schema: other.Schema}
+
+	for name := range state.Schema.Attributes {
+		path := tftypes.NewAttributePath().WithAttributeName(name)
+
+		val, diags := state.GetAttribute(ctx, path)
+		if diags.HasError() {
+			t.Errorf("unexpected GetAttribute diagnostics for %s in %s: %v", path, state.Raw, diags)
+			return
+		}
+
+		diags = copied.SetAttribute(ctx, path, val)
+		if diags.HasError() {
+			t.Errorf("unexpected SetAttribute diagnostics for %s in %s: %v", path, other.Raw, diags)
+			return
+		}
+	}
+
+	if !copied.Raw.Equal(state.Raw) {
+		t.Errorf("copying the attributes of %s with GetAttribute and SetAttribute produced %s", state.Raw, copied.Raw)
+	}
+
+	target := reflect.New(modelType(ctx, state.Schema))
+
+	diags := state.Get(ctx, target.Interface())
+	if diags.HasError() {
+		t.Errorf("unexpected Get diagnostics for %s: %v", state.Raw, diags)
+		return
+	}
+
+	set := State{Raw: other.Raw.Copy(), Schema: other.Schema}
+
+	diags = set.Set(ctx, target.Elem().Interface())
+	if diags.HasError() {
+		t.Errorf("unexpected Set diagnostics for %s: %v", state.Raw, diags)
+		return
+	}
+
+	if !set.Raw.Equal(state.Raw) {
+		t.Errorf("Get and Set changed %s to %s", state.Raw, set.Raw)
+	}
+}
+
+// attributePaths returns the paths of `val`, which is of `typ` and found at
+// `path`, and everything it contains.
+func attributePaths(ctx context.Context, typ attr.Type, val tftypes.Value, path *tftypes.AttributePath) []*tftypes.AttributePath {
+	var paths []*tftypes.AttributePath
+
+	if len(path.Steps()) > 0 {
+		paths = append(paths, path)
+	}
+
+	if !val.IsKnown() || val.IsNull() {
+		return paths
+	}
+
+	switch typ := typ.(type) {
+	case types.ListType:
+		var elems []tftypes.Value
+
+		if err := val.As(&elems); err != nil {
+			panic(err)
+		}
+
+		for i, elem := range elems {
+			paths = append(paths, attributePaths(ctx, typ.ElemType, elem, path.WithElementKeyInt(int64(i)))...)
+		}
+	case types.SetType:
+		var elems []tftypes.Value
+
+		if err := val.As(&elems); err != nil {
+			panic(err)
+		}
+
+		for _, elem := range elems {
+			// Set elements are identified by their values, which
+			// can't be unknown.
+			if elem.IsFullyKnown() {
+				paths = append(paths, attributePaths(ctx, typ.ElemType, elem, path.WithElementKeyValue(elem))...)
+			}
+		}
+	case types.MapType:
+		var elems map[string]tftypes.Value
+
+		if err := val.As(&elems); err != nil {
+			panic(err)
+		}
+
+		for key, elem := range elems {
+			paths = append(paths, attributePaths(ctx, typ.ElemType, elem, path.WithElementKeyString(key))...)
+		}
+	case attr.TypeWithAttributeTypes:
+		var attrs map[string]tftypes.Value
+
+		if err := val.As(&attrs); err != nil {
+			panic(err)
+		}
+
+		for name, attrType := range typ.AttributeTypes() {
+			paths = append(paths, attributePaths(ctx, attrType, attrs[name], path.WithAttributeName(name))...)
+		}
+	}
+
+	return paths
+}
+
+// modelType returns a struct type for `schema`, with a field of the
+// attr.Value type of each attribute.
+func modelType(ctx context.Context, schema Schema) reflect.Type {
+	var names []string
+
+	for name := range schema.Attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var fields []reflect.StructField
+
+	for i, name := range names {
+		attrType, err := schema.AttributeTypeAtPath(tftypes.NewAttributePath().WithAttributeName(name))
+		if err != nil {
+			panic(err)
+		}
+
+		null, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+		if err != nil {
+			panic(err)
+		}
+
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Attr%d", i),
+			Type: reflect.TypeOf(null),
+			Tag:  reflect.StructTag(fmt.Sprintf(`tfsdk:%q`, name)),
+		})
+	}
+
+	return reflect.StructOf(fields)
+}
+
+// checkRandomValueRoundTrips generates a random type and value with `c`,
+// and checks their round trips.
+func checkRandomValueRoundTrips(t *testing.T, c chooser) {
+	t.Helper()
+
+	ctx := context.Background()
+	typ := randomType(c, 0)
+
+	checkValueRoundTrips(ctx, t, typ, randomValue(ctx, c, typ))
+}
+
+// checkRandomStateRoundTrips generates a random schema and two states for
+// it with `c`, and checks their round trips.
+func checkRandomStateRoundTrips(t *testing.T, c chooser) {
+	t.Helper()
+
+	ctx := context.Background()
+	schema := randomSchema(c)
+
+	state := State{Raw: randomKnownValue(ctx, c, schema.AttributeType()), Schema: schema}
+	other := State{Raw: randomKnownValue(ctx, c, schema.AttributeType()), Schema: schema}
+
+	checkStateRoundTrips(ctx, t, state, other)
+}
+
+func TestValueRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	for seed := int64(0); seed < roundTripIterations; seed++ {
+		seed := seed
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			t.Parallel()
+
+			checkRandomValueRoundTrips(t, rand.New(rand.NewSource(seed)))
+		})
+	}
+}
+
+func TestStateRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	for seed := int64(0); seed < roundTripIterations; seed++ {
+		seed := seed
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			t.Parallel()
+
+			checkRandomStateRoundTrips(t, rand.New(rand.NewSource(seed)))
+		})
+	}
+}
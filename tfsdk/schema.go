@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -129,6 +130,58 @@ func (s Schema) AttributeAtPath(path *tftypes.AttributePath) (Attribute, error)
 	return a, nil
 }
 
+// AttributeValueString returns a human-readable, HCL-like representation of
+// `val`, the value of the attribute at `path`, such as ["a", <unknown>, null]
+// or { name = "x" }. If the attribute at `path`, one of its parents, or any
+// attribute nested within it is Sensitive, its value is rendered as
+// <sensitive> instead.
+//
+// AttributeValueString is intended for use in diagnostics and logs, for
+// example by validators and plan modifiers, and its output should not be
+// parsed.
+func (s Schema) AttributeValueString(ctx context.Context, path *tftypes.AttributePath, val attr.Value) string {
+	if s.sensitiveAtPath(path) {
+		return valuestring.Sensitive
+	}
+
+	if val == nil {
+		return valuestring.Null
+	}
+
+	raw, err := val.ToTerraformValue(ctx)
+	if err != nil {
+		return fmt.Sprintf("<invalid %T: %s>", val, err)
+	}
+
+	typ := val.Type(ctx).TerraformType(ctx)
+
+	err = tftypes.ValidateValue(typ, raw)
+	if err != nil {
+		return fmt.Sprintf("<invalid %T: %s>", val, err)
+	}
+
+	return valuestring.Tftypes(tftypes.NewValue(typ, raw), path, s.sensitiveAtPath)
+}
+
+// sensitiveAtPath returns true if the attribute at `path`, or any attribute
+// containing it, is Sensitive.
+func (s Schema) sensitiveAtPath(path *tftypes.AttributePath) bool {
+	if path == nil {
+		return false
+	}
+
+	for len(path.Steps()) > 0 {
+		a, err := s.AttributeAtPath(path)
+		if err == nil && a.Sensitive {
+			return true
+		}
+
+		path = path.WithoutLastStep()
+	}
+
+	return false
+}
+
 // tfprotov6Schema returns the *tfprotov6.Schema equivalent of a Schema. At least
 // one attribute must be set in the schema, or an error will be returned.
 func (s Schema) tfprotov6Schema(ctx context.Context) (*tfprotov6.Schema, error) {
@@ -284,7 +337,7 @@ func modifyAttributesPlans(ctx context.Context, attrs map[string]Attribute, path
 					tfValueRaw, err := value.ToTerraformValue(ctx)
 
 					if err != nil {
-						err := fmt.Errorf("error running ToTerraformValue on element value: %v", value)
+						err := fmt.Errorf("error running ToTerraformValue on element value %s: %w", req.Plan.Schema.AttributeValueString(ctx, attrPath, value), err)
 						resp.Diagnostics.AddAttributeError(
 							attrPath,
 							"Attribute Plan Modification Error",
@@ -0,0 +1,208 @@
+package tfsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/schemajson"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// ProviderSchemaJSON returns the schemas of `provider` as indented JSON, in
+// the format `terraform providers schema -json` outputs, under the provider
+// address `name`, such as registry.terraform.io/hashicorp/random. The
+// schemas are the ones the provider serves to Terraform.
+//
+// Unlike Terraform's output, the provider_meta schema is included as
+// "provider_meta" next to "provider", if the provider has one.
+//
+// Object keys are sorted, so the output is the same for the same schemas,
+// and can be checked in and diffed.
+func ProviderSchemaJSON(ctx context.Context, provider Provider, name string) ([]byte, diag.Diagnostics) {
+	s := &server{
+		p: provider,
+	}
+
+	resp := new(getProviderSchemaResponse)
+	s.getProviderSchema(ctx, resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp.Diagnostics
+	}
+
+	out, err := providerSchemaResponseJSON(resp, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error marshaling provider schema",
+			"The provider schema couldn't be marshaled to JSON. This is always a problem with the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return nil, resp.Diagnostics
+	}
+
+	return out, resp.Diagnostics
+}
+
+func providerSchemaResponseJSON(resp *getProviderSchemaResponse, name string) ([]byte, error) {
+	schemas := &schemajson.Provider{}
+
+	var err error
+
+	schemas.Provider, err = schemaToJSON(resp.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("provider: %w", err)
+	}
+
+	schemas.ProviderMeta, err = schemaToJSON(resp.ProviderMeta)
+	if err != nil {
+		return nil, fmt.Errorf("provider_meta: %w", err)
+	}
+
+	if len(resp.ResourceSchemas) > 0 {
+		schemas.ResourceSchemas = map[string]*schemajson.Schema{}
+	}
+
+	for typeName, schema := range resp.ResourceSchemas {
+		schemaJSON, err := schemaToJSON(schema)
+		if err != nil {
+			return nil, fmt.Errorf("resource %q: %w", typeName, err)
+		}
+		schemas.ResourceSchemas[typeName] = schemaJSON
+	}
+
+	if len(resp.DataSourceSchemas) > 0 {
+		schemas.DataSourceSchemas = map[string]*schemajson.Schema{}
+	}
+
+	for typeName, schema := range resp.DataSourceSchemas {
+		schemaJSON, err := schemaToJSON(schema)
+		if err != nil {
+			return nil, fmt.Errorf("data source %q: %w", typeName, err)
+		}
+		schemas.DataSourceSchemas[typeName] = schemaJSON
+	}
+
+	out, err := json.MarshalIndent(schemajson.Schemas{
+		FormatVersion: schemajson.FormatVersion,
+		ProviderSchemas: map[string]*schemajson.Provider{
+			name: schemas,
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
+
+func schemaToJSON(schema *tfprotov6.Schema) (*schemajson.Schema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	block, err := blockToJSON(schema.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemajson.Schema{
+		Version: schema.Version,
+		Block:   block,
+	}, nil
+}
+
+func blockToJSON(block *tfprotov6.SchemaBlock) (*schemajson.Block, error) {
+	if block == nil {
+		return nil, nil
+	}
+
+	attributes, err := attributesToJSON(block.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &schemajson.Block{
+		Attributes:      attributes,
+		Description:     block.Description,
+		DescriptionKind: stringKindToJSON(block.DescriptionKind),
+		Deprecated:      block.Deprecated,
+	}
+
+	if len(block.BlockTypes) > 0 {
+		result.BlockTypes = map[string]*schemajson.BlockType{}
+	}
+
+	for _, blockType := range block.BlockTypes {
+		nestedBlock, err := blockToJSON(blockType.Block)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", blockType.TypeName, err)
+		}
+
+		result.BlockTypes[blockType.TypeName] = &schemajson.BlockType{
+			NestingMode: strings.ToLower(blockType.Nesting.String()),
+			Block:       nestedBlock,
+			MinItems:    blockType.MinItems,
+			MaxItems:    blockType.MaxItems,
+		}
+	}
+
+	return result, nil
+}
+
+func attributesToJSON(attributes []*tfprotov6.SchemaAttribute) (map[string]*schemajson.Attribute, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]*schemajson.Attribute, len(attributes))
+
+	for _, attribute := range attributes {
+		attrJSON := &schemajson.Attribute{
+			Description:     attribute.Description,
+			DescriptionKind: stringKindToJSON(attribute.DescriptionKind),
+			Deprecated:      attribute.Deprecated,
+			Required:        attribute.Required,
+			Optional:        attribute.Optional,
+			Computed:        attribute.Computed,
+			Sensitive:       attribute.Sensitive,
+		}
+
+		if attribute.Type != nil {
+			// tftypes.Type marshals to the JSON type signatures Terraform
+			// uses.
+			//nolint:staticcheck
+			typ, err := attribute.Type.MarshalJSON()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", attribute.Name, err)
+			}
+			attrJSON.Type = typ
+		}
+
+		if attribute.NestedType != nil {
+			nestedAttributes, err := attributesToJSON(attribute.NestedType.Attributes)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", attribute.Name, err)
+			}
+
+			attrJSON.NestedType = &schemajson.NestedType{
+				Attributes:  nestedAttributes,
+				NestingMode: strings.ToLower(attribute.NestedType.Nesting.String()),
+				MinItems:    attribute.NestedType.MinItems,
+				MaxItems:    attribute.NestedType.MaxItems,
+			}
+		}
+
+		result[attribute.Name] = attrJSON
+	}
+
+	return result, nil
+}
+
+func stringKindToJSON(kind tfprotov6.StringKind) string {
+	if kind == tfprotov6.StringKindMarkdown {
+		return "markdown"
+	}
+
+	return "plain"
+}
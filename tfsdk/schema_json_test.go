@@ -0,0 +1,284 @@
+package tfsdk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/internal/schemajson"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderSchemaResponseJSON(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		resp     *getProviderSchemaResponse
+		expected string
+	}
+
+	tests := map[string]testCase{
+		"empty": {
+			resp: &getProviderSchemaResponse{
+				Provider: &tfprotov6.Schema{
+					Block: &tfprotov6.SchemaBlock{},
+				},
+			},
+			expected: `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/example/test": {
+      "provider": {
+        "version": 0,
+        "block": {
+          "description_kind": "plain"
+        }
+      }
+    }
+  }
+}
+`,
+		},
+		"schemas": {
+			resp: &getProviderSchemaResponse{
+				Provider: &tfprotov6.Schema{
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:      "token",
+								Type:      tftypes.String,
+								Optional:  true,
+								Sensitive: true,
+							},
+						},
+					},
+				},
+				ProviderMeta: &tfprotov6.Schema{
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "module_name",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+				},
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"test_thing": {
+						Version: 1,
+						Block: &tfprotov6.SchemaBlock{
+							Description:     "A **thing**.",
+							DescriptionKind: tfprotov6.StringKindMarkdown,
+							Deprecated:      true,
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name:     "tags",
+									Type:     tftypes.Map{AttributeType: tftypes.String},
+									Optional: true,
+									Computed: true,
+								},
+								{
+									Name: "rule",
+									NestedType: &tfprotov6.SchemaObject{
+										Nesting:  tfprotov6.SchemaObjectNestingModeList,
+										MaxItems: 2,
+										Attributes: []*tfprotov6.SchemaAttribute{
+											{
+												Name:        "port",
+												Type:        tftypes.Number,
+												Required:    true,
+												Description: "The port.",
+											},
+										},
+									},
+									Optional: true,
+								},
+								{
+									Name:       "id",
+									Type:       tftypes.String,
+									Computed:   true,
+									Deprecated: true,
+								},
+							},
+						},
+					},
+				},
+				DataSourceSchemas: map[string]*tfprotov6.Schema{
+					"test_thing": {
+						Block: &tfprotov6.SchemaBlock{
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name: "target",
+									Type: tftypes.Object{
+										AttributeTypes: map[string]tftypes.Type{
+											"host": tftypes.String,
+											"port": tftypes.Number,
+										},
+									},
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/example/test": {
+      "provider": {
+        "version": 0,
+        "block": {
+          "attributes": {
+            "token": {
+              "type": "string",
+              "description_kind": "plain",
+              "optional": true,
+              "sensitive": true
+            }
+          },
+          "description_kind": "plain"
+        }
+      },
+      "provider_meta": {
+        "version": 0,
+        "block": {
+          "attributes": {
+            "module_name": {
+              "type": "string",
+              "description_kind": "plain",
+              "optional": true
+            }
+          },
+          "description_kind": "plain"
+        }
+      },
+      "resource_schemas": {
+        "test_thing": {
+          "version": 1,
+          "block": {
+            "attributes": {
+              "id": {
+                "type": "string",
+                "description_kind": "plain",
+                "deprecated": true,
+                "computed": true
+              },
+              "rule": {
+                "nested_type": {
+                  "attributes": {
+                    "port": {
+                      "type": "number",
+                      "description": "The port.",
+                      "description_kind": "plain",
+                      "required": true
+                    }
+                  },
+                  "nesting_mode": "list",
+                  "max_items": 2
+                },
+                "description_kind": "plain",
+                "optional": true
+              },
+              "tags": {
+                "type": [
+                  "map",
+                  "string"
+                ],
+                "description_kind": "plain",
+                "optional": true,
+                "computed": true
+              }
+            },
+            "description": "A **thing**.",
+            "description_kind": "markdown",
+            "deprecated": true
+          }
+        }
+      },
+      "data_source_schemas": {
+        "test_thing": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "target": {
+                "type": [
+                  "object",
+                  {
+                    "host": "string",
+                    "port": "number"
+                  }
+                ],
+                "description_kind": "plain",
+                "computed": true
+              }
+            },
+            "description_kind": "plain"
+          }
+        }
+      }
+    }
+  }
+}
+`,
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := providerSchemaResponseJSON(tc.resp, "registry.terraform.io/example/test")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(string(got), tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderSchemaJSON(t *testing.T) {
+	t.Parallel()
+
+	provider := &testServeProviderWithMetaSchema{new(testServeProvider)}
+
+	got, diags := ProviderSchemaJSON(context.Background(), provider, "registry.terraform.io/example/test")
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", diags)
+	}
+
+	var schemas schemajson.Schemas
+	if err := json.Unmarshal(got, &schemas); err != nil {
+		t.Fatalf("error unmarshaling output: %s", err)
+	}
+
+	schema := schemas.ProviderSchemas["registry.terraform.io/example/test"]
+
+	if schema.ProviderMeta == nil || schema.ProviderMeta.Version != 2 {
+		t.Errorf("expected provider_meta schema version 2, got %+v", schema.ProviderMeta)
+	}
+
+	if _, ok := schema.ResourceSchemas["test_one"]; !ok {
+		t.Error("expected resource schema for test_one")
+	}
+
+	if _, ok := schema.DataSourceSchemas["test_two"]; !ok {
+		t.Error("expected data source schema for test_two")
+	}
+
+	// The output is compared in code review, so it has to be the same
+	// every time, no matter the order of maps.
+	for i := 0; i < 10; i++ {
+		again, _ := ProviderSchemaJSON(context.Background(), provider, "registry.terraform.io/example/test")
+		if diff := cmp.Diff(string(again), string(got)); diff != "" {
+			t.Fatalf("Unexpected diff between runs (+wanted, -got): %s", diff)
+		}
+	}
+}
@@ -695,3 +695,113 @@ func TestSchemaValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaAttributeValueString(t *testing.T) {
+	t.Parallel()
+
+	testSchema := Schema{
+		Attributes: map[string]Attribute{
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"password": {
+				Type:      types.StringType,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"tokens": {
+				Type:      types.MapType{ElemType: types.StringType},
+				Optional:  true,
+				Sensitive: true,
+			},
+			"users": {
+				Attributes: ListNestedAttributes(map[string]Attribute{
+					"name": {
+						Type:     types.StringType,
+						Required: true,
+					},
+					"password": {
+						Type:      types.StringType,
+						Optional:  true,
+						Sensitive: true,
+					},
+				}, ListNestedAttributesOptions{}),
+				Optional: true,
+			},
+		},
+	}
+
+	userType := map[string]attr.Type{
+		"name":     types.StringType,
+		"password": types.StringType,
+	}
+
+	type testCase struct {
+		path     *tftypes.AttributePath
+		value    attr.Value
+		expected string
+	}
+	tests := map[string]testCase{
+		"not-sensitive": {
+			path:     tftypes.NewAttributePath().WithAttributeName("name"),
+			value:    types.String{Value: "example"},
+			expected: `"example"`,
+		},
+		"sensitive": {
+			path:     tftypes.NewAttributePath().WithAttributeName("password"),
+			value:    types.String{Value: "hunter2"},
+			expected: "<sensitive>",
+		},
+		"sensitive-null": {
+			path:     tftypes.NewAttributePath().WithAttributeName("password"),
+			value:    types.String{Null: true},
+			expected: "<sensitive>",
+		},
+		"inside-sensitive": {
+			path:     tftypes.NewAttributePath().WithAttributeName("tokens").WithElementKeyString("api"),
+			value:    types.String{Value: "secret"},
+			expected: "<sensitive>",
+		},
+		"nested-sensitive": {
+			path: tftypes.NewAttributePath().WithAttributeName("users"),
+			value: types.List{
+				ElemType: types.ObjectType{AttrTypes: userType},
+				Elems: []attr.Value{
+					types.Object{
+						AttrTypes: userType,
+						Attrs: map[string]attr.Value{
+							"name":     types.String{Value: "alice"},
+							"password": types.String{Value: "hunter2"},
+						},
+					},
+					types.Object{
+						AttrTypes: userType,
+						Attrs: map[string]attr.Value{
+							"name":     types.String{Unknown: true},
+							"password": types.String{Null: true},
+						},
+					},
+				},
+			},
+			expected: `[{ name = "alice", password = <sensitive> }, { name = <unknown>, password = <sensitive> }]`,
+		},
+		"nil": {
+			path:     tftypes.NewAttributePath().WithAttributeName("name"),
+			expected: "null",
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testSchema.AttributeValueString(context.Background(), tc.path, tc.value)
+
+			if got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -17,9 +17,12 @@ import (
 var _ tfprotov6.ProviderServer = &server{}
 
 type server struct {
-	p                Provider
-	contextCancels   []context.CancelFunc
-	contextCancelsMu sync.Mutex
+	p                   Provider
+	collapseDiagnostics bool
+	strictConsistency   bool
+	validateModels      bool
+	contextCancels      []context.CancelFunc
+	contextCancelsMu    sync.Mutex
 }
 
 // ServeOpts are options for serving the provider.
@@ -27,25 +30,69 @@ type ServeOpts struct {
 	// Name is the name of the provider, in full address form. For example:
 	// registry.terraform.io/hashicorp/random.
 	Name string
+
+	// CollapseDiagnostics collapses diagnostics that only differ by the
+	// element keys in their paths, such as those returned by a validator
+	// for every element of a list, into a single diagnostic annotated
+	// with their count. See diag.Diagnostics.Collapsed for details.
+	CollapseDiagnostics bool
+
+	// StrictConsistency checks that plans are consistent with their
+	// configuration, and that the states returned by Create and Update
+	// are consistent with their plans, returning errors that name the
+	// attributes and values that differ. Terraform rejects inconsistent
+	// plans and states with less detail, so this is meant to be enabled
+	// while developing and debugging providers.
+	StrictConsistency bool
+
+	// ValidateModels checks the models of the provider, and of its
+	// resource and data source types, that implement ProviderWithModel,
+	// ResourceTypeWithModel, or DataSourceTypeWithModel against their
+	// schemas with ValidateModel, and returns the diagnostics with the
+	// provider's schema. Like StrictConsistency, it's meant to be enabled
+	// while developing providers, to report models that don't match
+	// their schemas before they're used.
+	ValidateModels bool
 }
 
 // NewProtocol6Server returns a tfprotov6.ProviderServer implementation based
 // on the passed Provider implementation.
 func NewProtocol6Server(p Provider) tfprotov6.ProviderServer {
+	return NewProtocol6ServerWithOpts(p, ServeOpts{})
+}
+
+// NewProtocol6ServerWithOpts is like NewProtocol6Server, but the server
+// behaves as configured by `opts`, as it would when served by Serve.
+// opts.Name is ignored.
+func NewProtocol6ServerWithOpts(p Provider, opts ServeOpts) tfprotov6.ProviderServer {
 	return &server{
-		p: p,
+		p:                   p,
+		collapseDiagnostics: opts.CollapseDiagnostics,
+		strictConsistency:   opts.StrictConsistency,
+		validateModels:      opts.ValidateModels,
 	}
 }
 
 // Serve serves a provider, blocking until the context is canceled.
 func Serve(ctx context.Context, factory func() Provider, opts ServeOpts) error {
 	return tf6server.Serve(opts.Name, func() tfprotov6.ProviderServer {
-		return &server{
-			p: factory(),
-		}
+		return NewProtocol6ServerWithOpts(factory(), opts)
 	}) // TODO: set up debug serving if the --debug flag is passed
 }
 
+// diagnostics returns `diags` in a stable order, with errors first, then
+// ordered by attribute path, collapsing similar diagnostics if configured.
+// Sorting first means collapsed diagnostics keep the lowest path.
+func (s *server) diagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	diags = diags.Sorted()
+
+	if s.collapseDiagnostics {
+		diags = diags.Collapsed()
+	}
+
+	return diags
+}
+
 func (s *server) registerContext(in context.Context) context.Context {
 	ctx, cancel := context.WithCancel(in)
 	s.contextCancelsMu.Lock()
@@ -120,6 +167,8 @@ func (s *server) GetProviderSchema(ctx context.Context, _ *tfprotov6.GetProvider
 
 	s.getProviderSchema(ctx, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -130,6 +179,12 @@ func (s *server) getProviderSchema(ctx context.Context, resp *getProviderSchemaR
 	if diags.HasError() {
 		return
 	}
+	if pm, ok := s.p.(ProviderWithModel); ok && s.validateModels {
+		resp.Diagnostics.Append(validateModelDiags(ctx, "provider", providerSchema, pm.Model())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	// convert the provider schema to a *tfprotov6.Schema
 	provider6Schema, err := providerSchema.tfprotov6Schema(ctx)
 	if err != nil {
@@ -179,6 +234,12 @@ func (s *server) getProviderSchema(ctx context.Context, resp *getProviderSchemaR
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		if rt, ok := v.(ResourceTypeWithModel); ok && s.validateModels {
+			resp.Diagnostics.Append(validateModelDiags(ctx, "resource \""+k+"\"", schema, rt.Model())...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 		schema6, err := schema.tfprotov6Schema(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -203,6 +264,12 @@ func (s *server) getProviderSchema(ctx context.Context, resp *getProviderSchemaR
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		if dt, ok := v.(DataSourceTypeWithModel); ok && s.validateModels {
+			resp.Diagnostics.Append(validateModelDiags(ctx, "data source \""+k+"\"", schema, dt.Model())...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 		schema6, err := schema.tfprotov6Schema(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -222,6 +289,20 @@ func (s *server) getProviderSchema(ctx context.Context, resp *getProviderSchemaR
 	resp.DataSourceSchemas = dataSource6Schemas
 }
 
+// validateModelDiags returns the diagnostics of ValidateModel for `model`
+// and `schema`, with metadata naming `owner`, the provider, resource, or data
+// source they belong to, as the diagnostics of every schema are returned
+// together.
+func validateModelDiags(ctx context.Context, owner string, schema Schema, model interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, d := range ValidateModel(ctx, schema, model) {
+		diags.Append(diag.WithMetadata(d, map[string]string{"model": owner}))
+	}
+
+	return diags
+}
+
 // validateProviderConfigResponse is a thin abstraction to allow native Diagnostics usage
 type validateProviderConfigResponse struct {
 	PreparedConfig *tfprotov6.DynamicValue
@@ -251,6 +332,8 @@ func (s *server) ValidateProviderConfig(ctx context.Context, req *tfprotov6.Vali
 
 	s.validateProviderConfig(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -334,6 +417,8 @@ func (s *server) ConfigureProvider(ctx context.Context, req *tfprotov6.Configure
 
 	s.configureProvider(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -386,6 +471,8 @@ func (s *server) ValidateResourceConfig(ctx context.Context, req *tfprotov6.Vali
 
 	s.validateResourceConfig(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -505,6 +592,8 @@ func (s *server) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRe
 
 	s.readResource(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -627,6 +716,8 @@ func (s *server) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanReso
 
 	s.planResourceChange(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -809,6 +900,10 @@ func (s *server) planResourceChange(ctx context.Context, req *tfprotov6.PlanReso
 		return
 	}
 
+	if s.strictConsistency && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(checkPlannedState(ctx, req.TypeName, resourceSchema, config, state, modifiedPlan)...)
+	}
+
 	plannedState, err := tfprotov6.NewDynamicValue(modifiedPlan.Type(), modifiedPlan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -876,6 +971,8 @@ func (s *server) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyRe
 
 	s.applyResourceChange(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -1001,6 +1098,9 @@ func (s *server) applyResourceChange(ctx context.Context, req *tfprotov6.ApplyRe
 		}
 		resource.Create(ctx, createReq, &createResp)
 		resp.Diagnostics = createResp.Diagnostics
+		if s.strictConsistency && !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(checkAppliedState(req.TypeName, resourceSchema, plan, createResp.State.Raw)...)
+		}
 		newState, err := tfprotov6.NewDynamicValue(resourceSchema.TerraformType(ctx), createResp.State.Raw)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -1057,6 +1157,9 @@ func (s *server) applyResourceChange(ctx context.Context, req *tfprotov6.ApplyRe
 		}
 		resource.Update(ctx, updateReq, &updateResp)
 		resp.Diagnostics = updateResp.Diagnostics
+		if s.strictConsistency && !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(checkAppliedState(req.TypeName, resourceSchema, plan, updateResp.State.Raw)...)
+		}
 		newState, err := tfprotov6.NewDynamicValue(resourceSchema.TerraformType(ctx), updateResp.State.Raw)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -1139,6 +1242,8 @@ func (s *server) ValidateDataResourceConfig(ctx context.Context, req *tfprotov6.
 
 	s.validateDataResourceConfig(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -1245,6 +1350,8 @@ func (s *server) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSour
 
 	s.readDataSource(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(), nil
 }
 
@@ -0,0 +1,86 @@
+package tfsdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/consistency"
+	"github.com/hashicorp/terraform-plugin-framework/internal/pathstring"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// checkPlannedState returns error diagnostics for every value in `planned`,
+// the planned state for the resource of type `typeName`, that Terraform
+// would reject, as described by consistency.PlannedValues: values that
+// differ from those in `config`, unless they're kept from `prior`, the prior
+// state.
+func checkPlannedState(ctx context.Context, typeName string, resourceSchema Schema, config, prior, planned tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	protoSchema, err := resourceSchema.tfprotov6Schema(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error converting resource schema",
+			"The schema for the resource \""+typeName+"\" couldn't be converted into a usable type. This is always a problem with the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return diags
+	}
+
+	for _, inconsistency := range consistency.PlannedValues(config, prior, planned, consistency.ComputedAtPath(protoSchema.Block)) {
+		diags.Append(inconsistencyDiagnostic(
+			inconsistency.Path,
+			"Provider Produced Invalid Plan",
+			fmt.Sprintf("When planning changes to %s, the provider planned a value for %s that doesn't match the configuration: the configured value is %s, but the planned value is %s. Terraform will reject this plan.\n\n"+
+				"This is always an error in the provider. Please report this to the provider developer, who should make sure that attribute plan modifiers and ModifyPlan only change the values of computed attributes that aren't configured.",
+				typeName,
+				inconsistencyPathString(inconsistency.Path),
+				valuestring.Tftypes(inconsistency.Expected, inconsistency.Path, resourceSchema.sensitiveAtPath),
+				valuestring.Tftypes(inconsistency.Actual, inconsistency.Path, resourceSchema.sensitiveAtPath),
+			),
+		))
+	}
+
+	return diags
+}
+
+// checkAppliedState returns error diagnostics for every value in `newState`,
+// the state returned by creating or updating the resource of type
+// `typeName`, that differs from a known value in `planned`, which Terraform
+// would reject.
+func checkAppliedState(typeName string, resourceSchema Schema, planned, newState tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, inconsistency := range consistency.KnownValues(planned, newState) {
+		diags.Append(inconsistencyDiagnostic(
+			inconsistency.Path,
+			"Provider Produced Inconsistent Result",
+			fmt.Sprintf("When applying changes to %s, the provider produced an unexpected new value for %s: the planned value was %s, but the new value is %s. Terraform will reject this result.\n\n"+
+				"This is always an error in the provider. Please report this to the provider developer, who should mark values that can't be known until apply as unknown when planning, and make sure Create and Update don't change known planned values.",
+				typeName,
+				inconsistencyPathString(inconsistency.Path),
+				valuestring.Tftypes(inconsistency.Expected, inconsistency.Path, resourceSchema.sensitiveAtPath),
+				valuestring.Tftypes(inconsistency.Actual, inconsistency.Path, resourceSchema.sensitiveAtPath),
+			),
+		))
+	}
+
+	return diags
+}
+
+func inconsistencyDiagnostic(path *tftypes.AttributePath, summary, detail string) diag.Diagnostic {
+	if len(path.Steps()) == 0 {
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+
+	return diag.NewAttributeErrorDiagnostic(path, summary, detail)
+}
+
+func inconsistencyPathString(path *tftypes.AttributePath) string {
+	if len(path.Steps()) == 0 {
+		return "the resource"
+	}
+
+	return pathstring.String(path)
+}
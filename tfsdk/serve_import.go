@@ -127,5 +127,7 @@ func (s *server) ImportResourceState(ctx context.Context, req *tfprotov6.ImportR
 
 	s.importResourceState(ctx, req, resp)
 
+	resp.Diagnostics = s.diagnostics(resp.Diagnostics)
+
 	return resp.toTfprotov6(ctx), nil
 }
@@ -0,0 +1,125 @@
+package tfsdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type testServeModelProvider struct{}
+
+type testServeModelProviderModel struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+}
+
+func (p testServeModelProvider) GetSchema(_ context.Context) (Schema, diag.Diagnostics) {
+	return Schema{
+		Attributes: map[string]Attribute{
+			"endpoint": {
+				Type:     types.StringType,
+				Optional: true,
+			},
+		},
+	}, nil
+}
+
+func (p testServeModelProvider) Model() interface{} {
+	return &testServeModelProviderModel{}
+}
+
+func (p testServeModelProvider) Configure(_ context.Context, _ ConfigureProviderRequest, _ *ConfigureProviderResponse) {
+}
+
+func (p testServeModelProvider) GetResources(_ context.Context) (map[string]ResourceType, diag.Diagnostics) {
+	return map[string]ResourceType{
+		"test_model": testServeModelResourceType{},
+	}, nil
+}
+
+func (p testServeModelProvider) GetDataSources(_ context.Context) (map[string]DataSourceType, diag.Diagnostics) {
+	return map[string]DataSourceType{}, nil
+}
+
+type testServeModelResourceType struct{}
+
+type testServeModelResourceModel struct {
+	ID   string `tfsdk:"id"`
+	Name string `tfsdk:"name"`
+}
+
+func (r testServeModelResourceType) GetSchema(_ context.Context) (Schema, diag.Diagnostics) {
+	return Schema{
+		Attributes: map[string]Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				Type:     types.NumberType,
+				Required: true,
+			},
+		},
+	}, nil
+}
+
+func (r testServeModelResourceType) NewResource(_ context.Context, _ Provider) (Resource, diag.Diagnostics) {
+	return nil, nil
+}
+
+func (r testServeModelResourceType) Model() interface{} {
+	return &testServeModelResourceModel{}
+}
+
+func TestServerGetProviderSchemaValidateModels(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		validateModels bool
+		expected       []*tfprotov6.Diagnostic
+	}
+
+	tests := map[string]testCase{
+		"disabled": {},
+		"enabled": {
+			validateModels: true,
+			expected: []*tfprotov6.Diagnostic{
+				{
+					Severity:  tfprotov6.DiagnosticSeverityError,
+					Summary:   "Incompatible Model Type",
+					Detail:    "The Go type used for the attribute can't hold its values. This is always an error in the provider. Please report the following to the provider developer:\n\ncan't use string for tftypes.Number, it can only be used for tftypes.String\n\nmodel: resource \"test_model\"",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testServer := &server{
+				p:              testServeModelProvider{},
+				validateModels: tc.validateModels,
+			}
+
+			got, err := testServer.GetProviderSchema(context.Background(), new(tfprotov6.GetProviderSchemaRequest))
+			if err != nil {
+				t.Fatalf("Got unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got.Diagnostics, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+
+			if tc.expected == nil && got.ResourceSchemas["test_model"] == nil {
+				t.Error("expected the resource schema to be returned")
+			}
+		})
+	}
+}
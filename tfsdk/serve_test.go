@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -54,6 +55,59 @@ func TestServerCancelInFlightContexts(t *testing.T) {
 	// canceled, or we have an error reported
 }
 
+func TestServerDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	diags := diag.Diagnostics{
+		diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("name"), "Deprecated", "Use title instead."),
+		diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+		diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+	}
+
+	tests := map[string]struct {
+		collapse bool
+		expected diag.Diagnostics
+	}{
+		"sorted": {
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1).WithAttributeName("port"), "Invalid Port", "Port must be positive."),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("name"), "Deprecated", "Use title instead."),
+			},
+		},
+		"collapsed": {
+			collapse: true,
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"), "Invalid Port", "Port must be positive.\n\n2 similar diagnostics were collapsed into this one."),
+				diag.NewAttributeWarningDiagnostic(tftypes.NewAttributePath().WithAttributeName("name"), "Deprecated", "Use title instead."),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &server{
+				collapseDiagnostics: tc.collapse,
+			}
+
+			got := s.diagnostics(diags)
+
+			if diff := cmp.Diff(got, tc.expected, cmp.AllowUnexported(
+				diag.AttributeErrorDiagnostic{},
+				diag.AttributeWarningDiagnostic{},
+				diag.ErrorDiagnostic{},
+				diag.WarningDiagnostic{},
+			)); diff != "" {
+				t.Errorf("Unexpected diff in diagnostics (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestMarkComputedNilsAsUnknown(t *testing.T) {
 	t.Parallel()
 
@@ -537,17 +591,17 @@ func TestServerValidateProviderConfig(t *testing.T) {
 			providerType: testServeProviderProviderType,
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityWarning,
+					Summary:  "Deprecated",
+					Detail:   "Deprecated in favor of other_resource",
+				},
 				{
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Summary:   "Attribute Deprecated",
 					Detail:    `Deprecated, please use "optional" instead`,
 					Attribute: tftypes.NewAttributePath().WithAttributeName("deprecated"),
 				},
-				{
-					Severity: tfprotov6.DiagnosticSeverityWarning,
-					Summary:  "Deprecated",
-					Detail:   "Deprecated in favor of other_resource",
-				},
 			},
 		},
 		"config_validators_no_diags": {
@@ -632,29 +686,29 @@ func TestServerValidateProviderConfig(t *testing.T) {
 			providerType: testServeProviderWithConfigValidatorsType,
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				// ConfigValidators includes multiple calls
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
+				{
+					Summary:  "This is another error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops again.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
-				// ConfigValidators includes multiple calls
 				{
 					Summary:   "This is another warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is really your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is another error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops again.",
-				},
 			},
 		},
 		"validate_config_no_diags": {
@@ -714,17 +768,17 @@ func TestServerValidateProviderConfig(t *testing.T) {
 			providerType: testServeProviderWithValidateConfigType,
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
 			},
 		},
 	}
@@ -1142,12 +1196,12 @@ func TestServerValidateResourceConfig(t *testing.T) {
 			},
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				// ConfigValidators includes multiple calls
 				{
 					Summary:  "This is an error",
 					Severity: tfprotov6.DiagnosticSeverityError,
 					Detail:   "Oops.",
 				},
-				// ConfigValidators includes multiple calls
 				{
 					Summary:  "This is another error",
 					Severity: tfprotov6.DiagnosticSeverityError,
@@ -1187,29 +1241,29 @@ func TestServerValidateResourceConfig(t *testing.T) {
 			},
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				// ConfigValidators includes multiple calls
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
+				{
+					Summary:  "This is another error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops again.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
-				// ConfigValidators includes multiple calls
 				{
 					Summary:   "This is another warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is really your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is another error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops again.",
-				},
 			},
 		},
 		"validate_config_no_diags": {
@@ -1263,17 +1317,17 @@ func TestServerValidateResourceConfig(t *testing.T) {
 			},
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
 			},
 		},
 	}
@@ -1565,17 +1619,17 @@ func TestServerReadResource(t *testing.T) {
 			}),
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
 			},
 		},
 	}
@@ -1695,6 +1749,8 @@ func TestServerPlanResourceChange(t *testing.T) {
 
 		modifyPlanFunc func(context.Context, ModifyResourcePlanRequest, *ModifyResourcePlanResponse)
 
+		strictConsistency bool
+
 		// response expectations
 		expectedPlannedState    tftypes.Value
 		expectedRequiresReplace []*tftypes.AttributePath
@@ -2466,16 +2522,16 @@ func TestServerPlanResourceChange(t *testing.T) {
 			resource:     "test_attribute_plan_modifiers",
 			resourceType: testServeResourceTypeAttributePlanModifiersType,
 			expectedDiags: []*tfprotov6.Diagnostic{
-				{
-					Severity: tfprotov6.DiagnosticSeverityWarning,
-					Summary:  "Warning diag",
-					Detail:   "This is a warning",
-				},
 				{
 					Severity: tfprotov6.DiagnosticSeverityError,
 					Summary:  "Error diag",
 					Detail:   "This is an error",
 				},
+				{
+					Severity: tfprotov6.DiagnosticSeverityWarning,
+					Summary:  "Warning diag",
+					Detail:   "This is a warning",
+				},
 			},
 			expectedRequiresReplace: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("scratch_disk").WithAttributeName("interface")},
 		},
@@ -2734,6 +2790,192 @@ func TestServerPlanResourceChange(t *testing.T) {
 			resourceType:            testServeResourceTypeAttributePlanModifiersType,
 			expectedRequiresReplace: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("scratch_disk").WithAttributeName("interface")},
 		},
+		"two_modifyplan_strict_consistency": {
+			priorState: tftypes.NewValue(testServeResourceTypeTwoType, nil),
+			proposedNewState: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, nil),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, nil),
+			}),
+			config: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, nil),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, nil),
+			}),
+			resource:          "test_two",
+			resourceType:      testServeResourceTypeTwoType,
+			strictConsistency: true,
+			modifyPlanFunc: func(ctx context.Context, req ModifyResourcePlanRequest, resp *ModifyResourcePlanResponse) {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), "123456")...)
+			},
+			expectedPlannedState: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "123456"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, tftypes.UnknownValue),
+			}),
+		},
+		"one_strict_consistency_optional_not_configured": {
+			priorState: tftypes.NewValue(testServeResourceTypeOneType, nil),
+			proposedNewState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "red"),
+				}),
+				"created_timestamp": tftypes.NewValue(tftypes.String, nil),
+			}),
+			config: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"created_timestamp": tftypes.NewValue(tftypes.String, nil),
+			}),
+			resource:          "test_one",
+			resourceType:      testServeResourceTypeOneType,
+			strictConsistency: true,
+			expectedPlannedState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "red"),
+				}),
+				"created_timestamp": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Severity:  tfprotov6.DiagnosticSeverityError,
+					Summary:   "Provider Produced Invalid Plan",
+					Detail:    "When planning changes to test_one, the provider planned a value for favorite_colors that doesn't match the configuration: the configured value is null, but the planned value is [\"red\"]. Terraform will reject this plan.\n\nThis is always an error in the provider. Please report this to the provider developer, who should make sure that attribute plan modifiers and ModifyPlan only change the values of computed attributes that aren't configured.",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("favorite_colors"),
+				},
+			},
+		},
+		"one_strict_consistency_optional_removed": {
+			priorState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "red"),
+				}),
+				"created_timestamp": tftypes.NewValue(tftypes.String, "when the earth was young"),
+			}),
+			proposedNewState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "red"),
+				}),
+				"created_timestamp": tftypes.NewValue(tftypes.String, "when the earth was young"),
+			}),
+			config: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"created_timestamp": tftypes.NewValue(tftypes.String, nil),
+			}),
+			resource:          "test_one",
+			resourceType:      testServeResourceTypeOneType,
+			strictConsistency: true,
+			expectedPlannedState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "red"),
+				}),
+				"created_timestamp": tftypes.NewValue(tftypes.String, "when the earth was young"),
+			}),
+			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Severity:  tfprotov6.DiagnosticSeverityError,
+					Summary:   "Provider Produced Invalid Plan",
+					Detail:    "When planning changes to test_one, the provider planned a value for favorite_colors that doesn't match the configuration: the configured value is null, but the planned value is [\"red\"]. Terraform will reject this plan.\n\nThis is always an error in the provider. Please report this to the provider developer, who should make sure that attribute plan modifiers and ModifyPlan only change the values of computed attributes that aren't configured.",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("favorite_colors"),
+				},
+			},
+		},
+		"two_modifyplan_strict_consistency_prior_kept": {
+			priorState: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "ABC123"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, nil),
+			}),
+			proposedNewState: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "abc123"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, nil),
+			}),
+			config: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "abc123"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, nil),
+			}),
+			resource:          "test_two",
+			resourceType:      testServeResourceTypeTwoType,
+			strictConsistency: true,
+			modifyPlanFunc: func(ctx context.Context, req ModifyResourcePlanRequest, resp *ModifyResourcePlanResponse) {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), "ABC123")...)
+			},
+			expectedPlannedState: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "ABC123"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, tftypes.UnknownValue),
+			}),
+		},
+		"two_modifyplan_strict_consistency_config_changed": {
+			priorState: tftypes.NewValue(testServeResourceTypeTwoType, nil),
+			proposedNewState: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "123456"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, nil),
+			}),
+			config: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "123456"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, nil),
+			}),
+			resource:          "test_two",
+			resourceType:      testServeResourceTypeTwoType,
+			strictConsistency: true,
+			modifyPlanFunc: func(ctx context.Context, req ModifyResourcePlanRequest, resp *ModifyResourcePlanResponse) {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), "654321")...)
+			},
+			expectedPlannedState: tftypes.NewValue(testServeResourceTypeTwoType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "654321"),
+				"disks": tftypes.NewValue(tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+					"name":    tftypes.String,
+					"size_gb": tftypes.Number,
+					"boot":    tftypes.Bool,
+				}}}, tftypes.UnknownValue),
+			}),
+			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Severity:  tfprotov6.DiagnosticSeverityError,
+					Summary:   "Provider Produced Invalid Plan",
+					Detail:    "When planning changes to test_two, the provider planned a value for id that doesn't match the configuration: the configured value is \"123456\", but the planned value is \"654321\". Terraform will reject this plan.\n\nThis is always an error in the provider. Please report this to the provider developer, who should make sure that attribute plan modifiers and ModifyPlan only change the values of computed attributes that aren't configured.",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("id"),
+				},
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -2745,7 +2987,8 @@ func TestServerPlanResourceChange(t *testing.T) {
 				modifyPlanFunc: tc.modifyPlanFunc,
 			}
 			testServer := &server{
-				p: s,
+				p:                 s,
+				strictConsistency: tc.strictConsistency,
 			}
 
 			priorStateDV, err := tfprotov6.NewDynamicValue(tc.resourceType, tc.priorState)
@@ -2825,6 +3068,8 @@ func TestServerApplyResourceChange(t *testing.T) {
 		update  func(context.Context, UpdateResourceRequest, *UpdateResourceResponse)
 		destroy func(context.Context, DeleteResourceRequest, *DeleteResourceResponse)
 
+		strictConsistency bool
+
 		// response expectations
 		expectedNewState tftypes.Value
 		expectedDiags    []*tfprotov6.Diagnostic
@@ -3835,6 +4080,80 @@ func TestServerApplyResourceChange(t *testing.T) {
 			},
 			expectedNewState: tftypes.NewValue(testServeResourceTypeTwoType, nil),
 		},
+		"one_create_strict_consistency": {
+			plannedState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"created_timestamp": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+			config: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"created_timestamp": tftypes.NewValue(tftypes.String, nil),
+			}),
+			resource:          "test_one",
+			action:            "create",
+			resourceType:      testServeResourceTypeOneType,
+			strictConsistency: true,
+			create: func(ctx context.Context, req CreateResourceRequest, resp *CreateResourceResponse) {
+				resp.State.Raw = tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+					"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+					"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+					"created_timestamp": tftypes.NewValue(tftypes.String, "right now I guess"),
+				})
+			},
+			expectedNewState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"created_timestamp": tftypes.NewValue(tftypes.String, "right now I guess"),
+			}),
+		},
+		"one_create_strict_consistency_inconsistent": {
+			plannedState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"created_timestamp": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+			config: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name":              tftypes.NewValue(tftypes.String, "hello, world"),
+				"favorite_colors":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"created_timestamp": tftypes.NewValue(tftypes.String, nil),
+			}),
+			resource:          "test_one",
+			action:            "create",
+			resourceType:      testServeResourceTypeOneType,
+			strictConsistency: true,
+			create: func(ctx context.Context, req CreateResourceRequest, resp *CreateResourceResponse) {
+				resp.State.Raw = tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+					"name": tftypes.NewValue(tftypes.String, "goodbye, world"),
+					"favorite_colors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+						tftypes.NewValue(tftypes.String, "red"),
+					}),
+					"created_timestamp": tftypes.NewValue(tftypes.String, "right now I guess"),
+				})
+			},
+			expectedNewState: tftypes.NewValue(testServeResourceTypeOneType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "goodbye, world"),
+				"favorite_colors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "red"),
+				}),
+				"created_timestamp": tftypes.NewValue(tftypes.String, "right now I guess"),
+			}),
+			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Severity:  tfprotov6.DiagnosticSeverityError,
+					Summary:   "Provider Produced Inconsistent Result",
+					Detail:    "When applying changes to test_one, the provider produced an unexpected new value for favorite_colors: the planned value was null, but the new value is [\"red\"]. Terraform will reject this result.\n\nThis is always an error in the provider. Please report this to the provider developer, who should mark values that can't be known until apply as unknown when planning, and make sure Create and Update don't change known planned values.",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("favorite_colors"),
+				},
+				{
+					Severity:  tfprotov6.DiagnosticSeverityError,
+					Summary:   "Provider Produced Inconsistent Result",
+					Detail:    "When applying changes to test_one, the provider produced an unexpected new value for name: the planned value was \"hello, world\", but the new value is \"goodbye, world\". Terraform will reject this result.\n\nThis is always an error in the provider. Please report this to the provider developer, who should mark values that can't be known until apply as unknown when planning, and make sure Create and Update don't change known planned values.",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -3849,7 +4168,8 @@ func TestServerApplyResourceChange(t *testing.T) {
 				deleteFunc: tc.destroy,
 			}
 			testServer := &server{
-				p: s,
+				p:                 s,
+				strictConsistency: tc.strictConsistency,
 			}
 			var pmSchema Schema
 			if tc.providerMeta.Type() != nil {
@@ -4033,12 +4353,12 @@ func TestServerValidateDataResourceConfig(t *testing.T) {
 			},
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				// ConfigValidators includes multiple calls
 				{
 					Summary:  "This is an error",
 					Severity: tfprotov6.DiagnosticSeverityError,
 					Detail:   "Oops.",
 				},
-				// ConfigValidators includes multiple calls
 				{
 					Summary:  "This is another error",
 					Severity: tfprotov6.DiagnosticSeverityError,
@@ -4078,29 +4398,29 @@ func TestServerValidateDataResourceConfig(t *testing.T) {
 			},
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				// ConfigValidators includes multiple calls
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
+				{
+					Summary:  "This is another error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops again.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
-				// ConfigValidators includes multiple calls
 				{
 					Summary:   "This is another warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is really your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is another error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops again.",
-				},
 			},
 		},
 		"validate_config_no_diags": {
@@ -4154,17 +4474,17 @@ func TestServerValidateDataResourceConfig(t *testing.T) {
 			},
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
 			},
 		},
 	}
@@ -4346,17 +4666,17 @@ func TestServerReadDataSource(t *testing.T) {
 			}),
 
 			expectedDiags: []*tfprotov6.Diagnostic{
+				{
+					Summary:  "This is an error",
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Detail:   "Oops.",
+				},
 				{
 					Summary:   "This is a warning",
 					Severity:  tfprotov6.DiagnosticSeverityWarning,
 					Detail:    "This is your final warning",
 					Attribute: tftypes.NewAttributePath().WithAttributeName("disks").WithElementKeyInt(0),
 				},
-				{
-					Summary:  "This is an error",
-					Severity: tfprotov6.DiagnosticSeverityError,
-					Detail:   "Oops.",
-				},
 			},
 		},
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -21,6 +22,12 @@ func (s State) Get(ctx context.Context, target interface{}) diag.Diagnostics {
 	return reflect.Into(ctx, s.Schema.AttributeType(), s.Raw, target, reflect.Options{})
 }
 
+// GetWithOptions populates the struct passed as `target` with the entire
+// state, using `opts` to control how values are converted.
+func (s State) GetWithOptions(ctx context.Context, target interface{}, opts GetOptions) diag.Diagnostics {
+	return reflect.Into(ctx, s.Schema.AttributeType(), s.Raw, target, opts.reflectOptions())
+}
+
 // GetAttribute retrieves the attribute found at `path` and returns it as an
 // attr.Value. Consumers should assert the type of the returned value with the
 // desired attr.Type.
@@ -54,7 +61,7 @@ func (s State) GetAttribute(ctx context.Context, path *tftypes.AttributePath) (a
 	}
 
 	if attrTypeWithValidate, ok := attrType.(attr.TypeWithValidate); ok {
-		diags.Append(attrTypeWithValidate.Validate(ctx, tfValue, path)...)
+		diags.Append(attrTypeWithValidate.Validate(valuestring.WithSensitive(ctx, s.Schema.sensitiveAtPath), tfValue, path)...)
 
 		if diags.HasError() {
 			return nil, diags
@@ -147,7 +154,7 @@ func (s *State) SetAttribute(ctx context.Context, path *tftypes.AttributePath, v
 			tfVal := tftypes.NewValue(attrType.TerraformType(ctx), newTfVal)
 
 			if attrTypeWithValidate, ok := attrType.(attr.TypeWithValidate); ok {
-				diags.Append(attrTypeWithValidate.Validate(ctx, tfVal, path)...)
+				diags.Append(attrTypeWithValidate.Validate(valuestring.WithSensitive(ctx, s.Schema.sensitiveAtPath), tfVal, path)...)
 
 				if diags.HasError() {
 					return v, nil
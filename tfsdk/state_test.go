@@ -849,6 +849,112 @@ func TestStateGet_testTypes(t *testing.T) {
 	}
 }
 
+func TestStateGetWithOptions(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{
+		Attributes: map[string]Attribute{
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"count": {
+				Type:     types.NumberType,
+				Optional: true,
+			},
+		},
+	}
+
+	raw := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"count": tftypes.Number,
+		},
+	}, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, nil),
+		"count": tftypes.NewValue(tftypes.Number, 1.5),
+	})
+
+	type nameOnly struct {
+		Name types.String `tfsdk:"name"`
+	}
+	type nameAndID struct {
+		Name types.String `tfsdk:"name"`
+		ID   types.String `tfsdk:"id"`
+	}
+	type primitives struct {
+		Name  string `tfsdk:"name"`
+		Count int64  `tfsdk:"count"`
+	}
+
+	type testCase struct {
+		target      interface{}
+		opts        GetOptions
+		expected    interface{}
+		expectError bool
+	}
+
+	testCases := map[string]testCase{
+		"strict-extra-attribute": {
+			target:      &nameOnly{},
+			opts:        GetOptions{},
+			expectError: true,
+		},
+		"lenient-extra-attribute": {
+			target:   &nameOnly{},
+			opts:     GetOptions{Mode: GetModeLenient},
+			expected: &nameOnly{Name: types.String{Null: true}},
+		},
+		"lenient-missing-attribute": {
+			target:      &nameAndID{},
+			opts:        GetOptions{Mode: GetModeLenient},
+			expectError: true,
+		},
+		"unhandled-null-and-rounding": {
+			target:      &primitives{},
+			opts:        GetOptions{},
+			expectError: true,
+		},
+		"unhandled-null-as-empty-and-allow-rounding": {
+			target: &primitives{},
+			opts: GetOptions{
+				UnhandledNullAsEmpty: true,
+				AllowRoundingNumbers: true,
+			},
+			expected: &primitives{Count: 1},
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := State{
+				Raw:    raw,
+				Schema: schema,
+			}
+
+			diags := state.GetWithOptions(context.Background(), tc.target, tc.opts)
+
+			if tc.expectError {
+				if !diags.HasError() {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if diff := cmp.Diff(tc.target, tc.expected); diff != "" {
+				t.Errorf("unexpected value (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
 func TestStateGetAttribute(t *testing.T) {
 	t.Parallel()
 
@@ -881,6 +987,42 @@ func TestStateGetAttribute(t *testing.T) {
 			path:     tftypes.NewAttributePath().WithAttributeName("name"),
 			expected: types.String{Value: "hello, world"},
 		},
+		"set-duplicates-sensitive": {
+			state: State{
+				Raw: tftypes.NewValue(tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"tokens": tftypes.Set{ElementType: tftypes.String},
+					},
+				}, map[string]tftypes.Value{
+					"tokens": tftypes.NewValue(tftypes.Set{
+						ElementType: tftypes.String,
+					}, []tftypes.Value{
+						tftypes.NewValue(tftypes.String, "hunter2"),
+						tftypes.NewValue(tftypes.String, "hunter2"),
+					}),
+				}),
+				Schema: Schema{
+					Attributes: map[string]Attribute{
+						"tokens": {
+							Type: types.SetType{
+								ElemType: types.StringType,
+							},
+							Optional:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+			path:     tftypes.NewAttributePath().WithAttributeName("tokens"),
+			expected: nil,
+			expectedDiags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("tokens"),
+					"Duplicate Set Element",
+					"This attribute contains duplicate values of: <sensitive>",
+				),
+			},
+		},
 		"list": {
 			state: State{
 				Raw: tftypes.NewValue(tftypes.Object{
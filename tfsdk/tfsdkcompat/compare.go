@@ -0,0 +1,421 @@
+package tfsdkcompat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/internal/schemajson"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// The schemas are compared in the JSON format output by `terraform providers
+// schema -json`, which providers are converted to, so providers and JSON
+// files are compared the same way.
+
+// providerAddress is the address providers are exported under when
+// comparing them directly.
+const providerAddress = "provider"
+
+// Options configure the comparison. The zero value assumes the new provider
+// can't upgrade any resource states.
+type Options struct {
+	// StateUpgrades are the schema versions the new provider can upgrade
+	// resource states from, keyed by resource type name. The framework
+	// doesn't upgrade states itself, and passes prior states through
+	// unchanged, so this is for providers that handle the
+	// UpgradeResourceState RPC themselves. A schema version bump that
+	// changes the shape of a resource's state is reported as a breaking
+	// ChangeStateUpgradeMissing change unless the old version is listed.
+	StateUpgrades map[string][]int64
+}
+
+// canUpgradeState returns true if the states of resources of type
+// `typeName` can be upgraded from schema version `version`.
+func (o Options) canUpgradeState(typeName string, version int64) bool {
+	for _, upgradable := range o.StateUpgrades[typeName] {
+		if upgradable == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CompareProviders compares the schemas of `old` and `new`, usually two
+// releases of the same provider.
+func CompareProviders(ctx context.Context, old, new tfsdk.Provider, opts Options) (Report, error) {
+	oldJSON, diags := tfsdk.ProviderSchemaJSON(ctx, old, providerAddress)
+	if err := diags.ToError(); err != nil {
+		return Report{}, fmt.Errorf("error getting old provider schema: %w", err)
+	}
+
+	newJSON, diags := tfsdk.ProviderSchemaJSON(ctx, new, providerAddress)
+	if err := diags.ToError(); err != nil {
+		return Report{}, fmt.Errorf("error getting new provider schema: %w", err)
+	}
+
+	return CompareJSON(oldJSON, newJSON, providerAddress, opts)
+}
+
+// CompareJSON compares the schemas of the provider at `address`, such as
+// registry.terraform.io/hashicorp/random, in `old` and `new`, which are in
+// the format output by tfsdk.ProviderSchemaJSON and `terraform providers
+// schema -json`. If `address` is empty, `old` and `new` must each contain a
+// single provider, which are compared whatever their addresses.
+func CompareJSON(old, new []byte, address string, opts Options) (Report, error) {
+	oldProvider, err := schemajson.ProviderFromJSON(old, address)
+	if err != nil {
+		return Report{}, fmt.Errorf("error reading old schema: %w", err)
+	}
+
+	newProvider, err := schemajson.ProviderFromJSON(new, address)
+	if err != nil {
+		return Report{}, fmt.Errorf("error reading new schema: %w", err)
+	}
+
+	return compareProviders(oldProvider, newProvider, opts), nil
+}
+
+func compareProviders(old, new *schemajson.Provider, opts Options) Report {
+	var report Report
+
+	c := comparer{report: &report, schemaType: SchemaTypeProvider}
+	c.compareSchema(old.Provider, new.Provider)
+
+	c = comparer{report: &report, schemaType: SchemaTypeProviderMeta}
+	switch {
+	case old.ProviderMeta != nil && new.ProviderMeta == nil:
+		c.add(true, ChangeSchemaRemoved, "", "the provider_meta schema was removed")
+	default:
+		c.compareSchema(old.ProviderMeta, new.ProviderMeta)
+	}
+
+	compareSchemas(&report, opts, SchemaTypeResource, old.ResourceSchemas, new.ResourceSchemas)
+	compareSchemas(&report, opts, SchemaTypeDataSource, old.DataSourceSchemas, new.DataSourceSchemas)
+
+	report.Breaking = len(report.BreakingChanges()) > 0
+
+	return report
+}
+
+// compareSchemas compares the resource or data source schemas in `old` and
+// `new`.
+func compareSchemas(report *Report, opts Options, schemaType SchemaType, old, new map[string]*schemajson.Schema) {
+	typeNames := map[string]struct{}{}
+	for typeName := range old {
+		typeNames[typeName] = struct{}{}
+	}
+	for typeName := range new {
+		typeNames[typeName] = struct{}{}
+	}
+
+	for _, typeName := range sortedKeys(typeNames) {
+		c := comparer{report: report, opts: opts, schemaType: schemaType, typeName: typeName}
+
+		oldSchema, inOld := old[typeName]
+		newSchema, inNew := new[typeName]
+
+		switch {
+		case !inOld:
+			c.add(false, ChangeSchemaAdded, "", "added")
+		case !inNew:
+			c.add(true, ChangeSchemaRemoved, "", "removed")
+		default:
+			c.compareSchema(oldSchema, newSchema)
+		}
+	}
+}
+
+// comparer compares a single schema, adding its changes to a report.
+type comparer struct {
+	report     *Report
+	opts       Options
+	schemaType SchemaType
+	typeName   string
+
+	// stateChanged is true if attributes were removed or changed type,
+	// so states written with the old schema can't be decoded with the new
+	// one.
+	stateChanged bool
+}
+
+func (c *comparer) add(breaking bool, kind ChangeKind, path, format string, a ...interface{}) {
+	c.report.Changes = append(c.report.Changes, Change{
+		Breaking:   breaking,
+		Kind:       kind,
+		SchemaType: c.schemaType,
+		TypeName:   c.typeName,
+		Path:       path,
+		Message:    fmt.Sprintf(format, a...),
+	})
+}
+
+// addStateChange adds a change to the shape of the state, which is
+// breaking.
+func (c *comparer) addStateChange(kind ChangeKind, path, format string, a ...interface{}) {
+	c.stateChanged = true
+	c.add(true, kind, path, format, a...)
+}
+
+func (c *comparer) compareSchema(old, new *schemajson.Schema) {
+	if old == nil {
+		old = &schemajson.Schema{}
+	}
+	if new == nil {
+		new = &schemajson.Schema{}
+	}
+
+	if !blockDeprecated(old.Block) && blockDeprecated(new.Block) {
+		c.add(false, ChangeSchemaDeprecated, "", "deprecated")
+	}
+
+	c.compareBlock("", old.Block, new.Block)
+
+	if c.schemaType != SchemaTypeResource {
+		return
+	}
+
+	switch {
+	case new.Version < old.Version:
+		c.add(true, ChangeVersionDecreased, "", "the schema version decreased from %d to %d", old.Version, new.Version)
+	case c.stateChanged && new.Version == old.Version:
+		c.add(true, ChangeVersionBumpMissing, "", "attributes were removed or changed type, but the schema version is still %d", old.Version)
+	case c.stateChanged && !c.opts.canUpgradeState(c.typeName, old.Version):
+		c.add(true, ChangeStateUpgradeMissing, "", "the schema version was bumped from %d to %d, but states can't be upgraded from version %d, so Terraform can't decode existing states", old.Version, new.Version, old.Version)
+	}
+}
+
+func blockDeprecated(block *schemajson.Block) bool {
+	return block != nil && block.Deprecated
+}
+
+func (c *comparer) compareBlock(path string, old, new *schemajson.Block) {
+	if old == nil {
+		old = &schemajson.Block{}
+	}
+	if new == nil {
+		new = &schemajson.Block{}
+	}
+
+	c.compareAttributes(path, old.Attributes, new.Attributes)
+
+	names := map[string]struct{}{}
+	for name := range old.BlockTypes {
+		names[name] = struct{}{}
+	}
+	for name := range new.BlockTypes {
+		names[name] = struct{}{}
+	}
+
+	for _, name := range sortedKeys(names) {
+		blockPath := joinPath(path, name)
+		oldBlockType, inOld := old.BlockTypes[name]
+		newBlockType, inNew := new.BlockTypes[name]
+
+		switch {
+		case !inOld:
+			// Blocks are required if they have a minimum number of
+			// items.
+			c.add(newBlockType.MinItems > 0, ChangeAttributeAdded, blockPath, "block added")
+		case !inNew:
+			c.addStateChange(ChangeAttributeRemoved, blockPath, "block removed")
+		case oldBlockType.NestingMode != newBlockType.NestingMode:
+			c.addStateChange(ChangeNestingChanged, blockPath, "nesting mode changed from %s to %s", oldBlockType.NestingMode, newBlockType.NestingMode)
+		default:
+			c.compareItemLimits(blockPath, oldBlockType.MinItems, oldBlockType.MaxItems, newBlockType.MinItems, newBlockType.MaxItems)
+			c.compareBlock(blockPath, oldBlockType.Block, newBlockType.Block)
+		}
+	}
+}
+
+func (c *comparer) compareAttributes(path string, old, new map[string]*schemajson.Attribute) {
+	names := map[string]struct{}{}
+	for name := range old {
+		names[name] = struct{}{}
+	}
+	for name := range new {
+		names[name] = struct{}{}
+	}
+
+	for _, name := range sortedKeys(names) {
+		attrPath := joinPath(path, name)
+		oldAttribute, inOld := old[name]
+		newAttribute, inNew := new[name]
+
+		switch {
+		case !inOld:
+			if newAttribute.Required {
+				c.add(true, ChangeAttributeAdded, attrPath, "required attribute added")
+			} else {
+				c.add(false, ChangeAttributeAdded, attrPath, "%s attribute added", attributeMode(newAttribute))
+			}
+		case !inNew:
+			c.addStateChange(ChangeAttributeRemoved, attrPath, "attribute removed")
+		default:
+			c.compareAttribute(attrPath, oldAttribute, newAttribute)
+		}
+	}
+}
+
+func (c *comparer) compareAttribute(path string, old, new *schemajson.Attribute) {
+	oldMode, newMode := attributeMode(old), attributeMode(new)
+	if oldMode != newMode {
+		c.add(!compatibleModes[oldMode+" to "+newMode], ChangeModeChanged, path, "changed from %s to %s", oldMode, newMode)
+	}
+
+	if old.Sensitive != new.Sensitive {
+		if new.Sensitive {
+			c.add(false, ChangeSensitivityChanged, path, "marked as sensitive")
+		} else {
+			c.add(false, ChangeSensitivityChanged, path, "no longer marked as sensitive")
+		}
+	}
+
+	if !old.Deprecated && new.Deprecated {
+		c.add(false, ChangeAttributeDeprecated, path, "deprecated")
+	}
+
+	switch {
+	case old.NestedType != nil && new.NestedType != nil:
+		if old.NestedType.NestingMode != new.NestedType.NestingMode {
+			c.addStateChange(ChangeNestingChanged, path, "nesting mode changed from %s to %s", old.NestedType.NestingMode, new.NestedType.NestingMode)
+			return
+		}
+
+		c.compareItemLimits(path, old.NestedType.MinItems, old.NestedType.MaxItems, new.NestedType.MinItems, new.NestedType.MaxItems)
+		c.compareAttributes(path, old.NestedType.Attributes, new.NestedType.Attributes)
+	case old.NestedType != nil || new.NestedType != nil:
+		c.addStateChange(ChangeTypeChanged, path, "type changed from %s to %s", attributeTypeString(old), attributeTypeString(new))
+	default:
+		oldType, newType := typeString(old.Type), typeString(new.Type)
+		if oldType != newType {
+			c.addStateChange(ChangeTypeChanged, path, "type changed from %s to %s", oldType, newType)
+		}
+	}
+}
+
+func (c *comparer) compareItemLimits(path string, oldMin, oldMax, newMin, newMax int64) {
+	if oldMin == newMin && oldMax == newMax {
+		return
+	}
+
+	// A maximum of 0 means there's no maximum.
+	stricter := newMin > oldMin || (newMax > 0 && (oldMax == 0 || newMax < oldMax))
+
+	c.add(stricter, ChangeItemLimitsChanged, path, "item limits changed from %s to %s", itemLimits(oldMin, oldMax), itemLimits(newMin, newMax))
+}
+
+func itemLimits(min, max int64) string {
+	if max == 0 {
+		return fmt.Sprintf("min %d, no max", min)
+	}
+
+	return fmt.Sprintf("min %d, max %d", min, max)
+}
+
+// compatibleModes are the changes to attribute modes that keep existing
+// configurations valid, and don't stop computing values that were computed
+// before.
+var compatibleModes = map[string]bool{
+	"required to optional":              true,
+	"required to optional and computed": true,
+	"optional to optional and computed": true,
+	"computed to optional and computed": true,
+}
+
+func attributeMode(attribute *schemajson.Attribute) string {
+	switch {
+	case attribute.Required:
+		return "required"
+	case attribute.Optional && attribute.Computed:
+		return "optional and computed"
+	case attribute.Optional:
+		return "optional"
+	default:
+		return "computed"
+	}
+}
+
+func attributeTypeString(attribute *schemajson.Attribute) string {
+	if attribute.NestedType != nil {
+		return "nested attributes (" + attribute.NestedType.NestingMode + ")"
+	}
+
+	return typeString(attribute.Type)
+}
+
+// typeString returns a JSON type signature, such as ["list","string"], in
+// the form Terraform writes types in configurations, such as list(string).
+func typeString(raw json.RawMessage) string {
+	var typ interface{}
+
+	if err := json.Unmarshal(raw, &typ); err != nil {
+		return string(raw)
+	}
+
+	return typeSignatureString(typ)
+}
+
+func typeSignatureString(typ interface{}) string {
+	switch t := typ.(type) {
+	case string:
+		return t
+	case []interface{}:
+		if len(t) < 2 {
+			break
+		}
+
+		kind, _ := t[0].(string)
+
+		switch kind {
+		case "list", "set", "map":
+			return kind + "(" + typeSignatureString(t[1]) + ")"
+		case "object":
+			attributeTypes, _ := t[1].(map[string]interface{})
+			names := make([]string, 0, len(attributeTypes))
+			for name := range attributeTypes {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			attributes := make([]string, 0, len(names))
+			for _, name := range names {
+				attributes = append(attributes, name+"="+typeSignatureString(attributeTypes[name]))
+			}
+
+			return "object({" + strings.Join(attributes, ", ") + "})"
+		case "tuple":
+			elementTypes, _ := t[1].([]interface{})
+			elements := make([]string, 0, len(elementTypes))
+			for _, elementType := range elementTypes {
+				elements = append(elements, typeSignatureString(elementType))
+			}
+
+			return "tuple([" + strings.Join(elements, ", ") + "])"
+		}
+	}
+
+	out, _ := json.Marshal(typ)
+
+	return string(out)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,520 @@
+package tfsdkcompat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resourceJSON returns provider schema JSON with a single resource,
+// test_thing, with `version` and `attributes`.
+func resourceJSON(version, attributes string) []byte {
+	return []byte(`{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/example/test": {
+      "provider": {"version": 0, "block": {}},
+      "resource_schemas": {
+        "test_thing": {
+          "version": ` + version + `,
+          "block": {"attributes": ` + attributes + `}
+        }
+      }
+    }
+  }
+}`)
+}
+
+func TestCompareJSON(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		old, new []byte
+		opts     Options
+		expected Report
+	}
+
+	tests := map[string]testCase{
+		"no-changes": {
+			old:      resourceJSON("0", `{"name": {"type": "string", "required": true}}`),
+			new:      resourceJSON("0", `{"name": {"type": "string", "required": true}}`),
+			expected: Report{},
+		},
+		"attribute-added": {
+			old: resourceJSON("0", `{"name": {"type": "string", "required": true}}`),
+			new: resourceJSON("0", `{
+				"name": {"type": "string", "required": true},
+				"size": {"type": "number", "optional": true},
+				"zone": {"type": "string", "required": true}
+			}`),
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Kind:       ChangeAttributeAdded,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "size",
+						Message:    "optional attribute added",
+					},
+					{
+						Breaking:   true,
+						Kind:       ChangeAttributeAdded,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "zone",
+						Message:    "required attribute added",
+					},
+				},
+			},
+		},
+		"mode-changed": {
+			old: resourceJSON("0", `{
+				"a": {"type": "string", "optional": true},
+				"b": {"type": "string", "required": true},
+				"c": {"type": "string", "optional": true, "computed": true},
+				"d": {"type": "string", "computed": true}
+			}`),
+			new: resourceJSON("0", `{
+				"a": {"type": "string", "required": true},
+				"b": {"type": "string", "optional": true},
+				"c": {"type": "string", "computed": true},
+				"d": {"type": "string", "optional": true, "computed": true}
+			}`),
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Breaking:   true,
+						Kind:       ChangeModeChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "a",
+						Message:    "changed from optional to required",
+					},
+					{
+						Kind:       ChangeModeChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "b",
+						Message:    "changed from required to optional",
+					},
+					{
+						Breaking:   true,
+						Kind:       ChangeModeChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "c",
+						Message:    "changed from optional and computed to computed",
+					},
+					{
+						Kind:       ChangeModeChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "d",
+						Message:    "changed from computed to optional and computed",
+					},
+				},
+			},
+		},
+		"removed-without-version-bump": {
+			old: resourceJSON("1", `{
+				"name": {"type": "string", "required": true},
+				"tags": {"type": ["list", "string"], "optional": true}
+			}`),
+			new: resourceJSON("1", `{"name": {"type": "string", "required": true}}`),
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Breaking:   true,
+						Kind:       ChangeAttributeRemoved,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "tags",
+						Message:    "attribute removed",
+					},
+					{
+						Breaking:   true,
+						Kind:       ChangeVersionBumpMissing,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Message:    "attributes were removed or changed type, but the schema version is still 1",
+					},
+				},
+			},
+		},
+		"type-changed-with-version-bump": {
+			old: resourceJSON("0", `{"tags": {"type": ["list", "string"], "optional": true}}`),
+			new: resourceJSON("1", `{"tags": {"type": ["map", ["object", {"value": "string"}]], "optional": true}}`),
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Breaking:   true,
+						Kind:       ChangeTypeChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "tags",
+						Message:    "type changed from list(string) to map(object({value=string}))",
+					},
+					{
+						Breaking:   true,
+						Kind:       ChangeStateUpgradeMissing,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Message:    "the schema version was bumped from 0 to 1, but states can't be upgraded from version 0, so Terraform can't decode existing states",
+					},
+				},
+			},
+		},
+		"type-changed-with-state-upgrade": {
+			old: resourceJSON("0", `{"tags": {"type": ["list", "string"], "optional": true}}`),
+			new: resourceJSON("1", `{"tags": {"type": ["map", ["object", {"value": "string"}]], "optional": true}}`),
+			opts: Options{
+				StateUpgrades: map[string][]int64{"test_thing": {0}},
+			},
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Breaking:   true,
+						Kind:       ChangeTypeChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "tags",
+						Message:    "type changed from list(string) to map(object({value=string}))",
+					},
+				},
+			},
+		},
+		"type-changed-with-other-state-upgrade": {
+			old: resourceJSON("1", `{"tags": {"type": ["list", "string"], "optional": true}}`),
+			new: resourceJSON("2", `{"tags": {"type": ["map", ["object", {"value": "string"}]], "optional": true}}`),
+			opts: Options{
+				StateUpgrades: map[string][]int64{"test_thing": {0}, "test_other": {1}},
+			},
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Breaking:   true,
+						Kind:       ChangeTypeChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "tags",
+						Message:    "type changed from list(string) to map(object({value=string}))",
+					},
+					{
+						Breaking:   true,
+						Kind:       ChangeStateUpgradeMissing,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Message:    "the schema version was bumped from 1 to 2, but states can't be upgraded from version 1, so Terraform can't decode existing states",
+					},
+				},
+			},
+		},
+		"version-decreased": {
+			old: resourceJSON("2", `{}`),
+			new: resourceJSON("1", `{}`),
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Breaking:   true,
+						Kind:       ChangeVersionDecreased,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Message:    "the schema version decreased from 2 to 1",
+					},
+				},
+			},
+		},
+		"nested-attributes": {
+			old: resourceJSON("0", `{
+				"rule": {
+					"nested_type": {
+						"nesting_mode": "list",
+						"max_items": 5,
+						"attributes": {
+							"port": {"type": "number", "required": true},
+							"secret": {"type": "string", "optional": true}
+						}
+					},
+					"optional": true
+				},
+				"target": {
+					"nested_type": {
+						"nesting_mode": "list",
+						"attributes": {"host": {"type": "string", "required": true}}
+					},
+					"optional": true
+				}
+			}`),
+			new: resourceJSON("0", `{
+				"rule": {
+					"nested_type": {
+						"nesting_mode": "list",
+						"max_items": 3,
+						"attributes": {
+							"port": {"type": "number", "required": true},
+							"secret": {"type": "string", "optional": true, "sensitive": true, "deprecated": true}
+						}
+					},
+					"optional": true
+				},
+				"target": {
+					"nested_type": {
+						"nesting_mode": "set",
+						"attributes": {"host": {"type": "string", "required": true}}
+					},
+					"optional": true
+				}
+			}`),
+			expected: Report{
+				Breaking: true,
+				Changes: []Change{
+					{
+						Breaking:   true,
+						Kind:       ChangeItemLimitsChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "rule",
+						Message:    "item limits changed from min 0, max 5 to min 0, max 3",
+					},
+					{
+						Kind:       ChangeSensitivityChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "rule.secret",
+						Message:    "marked as sensitive",
+					},
+					{
+						Kind:       ChangeAttributeDeprecated,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "rule.secret",
+						Message:    "deprecated",
+					},
+					{
+						Breaking:   true,
+						Kind:       ChangeNestingChanged,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "target",
+						Message:    "nesting mode changed from list to set",
+					},
+					{
+						Breaking:   true,
+						Kind:       ChangeVersionBumpMissing,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Message:    "attributes were removed or changed type, but the schema version is still 0",
+					},
+				},
+			},
+		},
+		"blocks": {
+			old: resourceJSON("0", `{}`),
+			new: []byte(`{
+				"format_version": "1.0",
+				"provider_schemas": {
+					"registry.terraform.io/example/test": {
+						"resource_schemas": {
+							"test_thing": {
+								"version": 0,
+								"block": {
+									"block_types": {
+										"timeouts": {"nesting_mode": "single", "block": {}}
+									}
+								}
+							}
+						}
+					}
+				}
+			}`),
+			expected: Report{
+				Changes: []Change{
+					{
+						Kind:       ChangeAttributeAdded,
+						SchemaType: SchemaTypeResource,
+						TypeName:   "test_thing",
+						Path:       "timeouts",
+						Message:    "block added",
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := CompareJSON(tc.old, tc.new, "", tc.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompareJSONAddress(t *testing.T) {
+	t.Parallel()
+
+	in := []byte(`{
+		"format_version": "1.0",
+		"provider_schemas": {
+			"registry.terraform.io/example/one": {},
+			"registry.terraform.io/example/two": {}
+		}
+	}`)
+
+	if _, err := CompareJSON(in, in, "", Options{}); err == nil {
+		t.Error("expected an error comparing several providers without an address")
+	}
+
+	if _, err := CompareJSON(in, in, "registry.terraform.io/example/three", Options{}); err == nil {
+		t.Error("expected an error comparing a missing provider")
+	}
+
+	got, err := CompareJSON(in, in, "registry.terraform.io/example/two", Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(got, Report{}); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+	}
+}
+
+type testProvider struct {
+	resources map[string]tfsdk.ResourceType
+}
+
+func (p testProvider) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"endpoint": {
+				Type:     types.StringType,
+				Optional: true,
+			},
+		},
+	}, nil
+}
+
+func (p testProvider) Configure(context.Context, tfsdk.ConfigureProviderRequest, *tfsdk.ConfigureProviderResponse) {
+}
+
+func (p testProvider) GetResources(context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
+	return p.resources, nil
+}
+
+func (p testProvider) GetDataSources(context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
+	return nil, nil
+}
+
+// testResourceType is a resource type that's only used for its schema.
+type testResourceType struct {
+	schema tfsdk.Schema
+}
+
+func (r testResourceType) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return r.schema, nil
+}
+
+func (r testResourceType) NewResource(context.Context, tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return nil, nil
+}
+
+func TestCompareProviders(t *testing.T) {
+	t.Parallel()
+
+	old := testProvider{
+		resources: map[string]tfsdk.ResourceType{
+			"test_thing": testResourceType{
+				schema: tfsdk.Schema{
+					Attributes: map[string]tfsdk.Attribute{
+						"name": {
+							Type:     types.StringType,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"test_other": testResourceType{
+				schema: tfsdk.Schema{
+					Attributes: map[string]tfsdk.Attribute{
+						"id": {
+							Type:     types.StringType,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	new := testProvider{
+		resources: map[string]tfsdk.ResourceType{
+			"test_thing": testResourceType{
+				schema: tfsdk.Schema{
+					Attributes: map[string]tfsdk.Attribute{
+						"name": {
+							Type:     types.StringType,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := CompareProviders(context.Background(), old, new, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := Report{
+		Breaking: true,
+		Changes: []Change{
+			{
+				Breaking:   true,
+				Kind:       ChangeSchemaRemoved,
+				SchemaType: SchemaTypeResource,
+				TypeName:   "test_other",
+				Message:    "removed",
+			},
+			{
+				Breaking:   true,
+				Kind:       ChangeModeChanged,
+				SchemaType: SchemaTypeResource,
+				TypeName:   "test_thing",
+				Path:       "name",
+				Message:    "changed from optional to required",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+	}
+
+	expectedString := "BREAKING resource test_other: removed\n" +
+		"BREAKING resource test_thing: name: changed from optional to required\n" +
+		"2 changes, 2 breaking\n"
+
+	if diff := cmp.Diff(got.String(), expectedString); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+	}
+}
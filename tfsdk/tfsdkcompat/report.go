@@ -0,0 +1,178 @@
+// Package tfsdkcompat compares provider schemas between releases, reporting
+// changes that break existing configurations or states.
+//
+// Schemas can come from providers built with tfsdk, or from the JSON output
+// of tfsdk.ProviderSchemaJSON or `terraform providers schema -json`. The
+// cmd/tfsdkcompat command compares JSON files, for use in CI.
+package tfsdkcompat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaType is the kind of schema a change was found in.
+type SchemaType string
+
+const (
+	SchemaTypeProvider     SchemaType = "provider"
+	SchemaTypeProviderMeta SchemaType = "provider_meta"
+	SchemaTypeResource     SchemaType = "resource"
+	SchemaTypeDataSource   SchemaType = "data_source"
+)
+
+// ChangeKind identifies a kind of change.
+type ChangeKind string
+
+const (
+	// ChangeSchemaAdded is a resource or data source that was added.
+	ChangeSchemaAdded ChangeKind = "schema_added"
+
+	// ChangeSchemaRemoved is a resource or data source, or the
+	// provider_meta schema, that was removed. Configurations using it
+	// break.
+	ChangeSchemaRemoved ChangeKind = "schema_removed"
+
+	// ChangeSchemaDeprecated is a schema that was deprecated.
+	ChangeSchemaDeprecated ChangeKind = "schema_deprecated"
+
+	// ChangeAttributeAdded is an attribute or block that was added. It's
+	// breaking if it's required, as existing configurations don't set it.
+	ChangeAttributeAdded ChangeKind = "attribute_added"
+
+	// ChangeAttributeRemoved is an attribute or block that was removed.
+	// Configurations setting it break, and so do resource states, which
+	// need a schema version bump and state upgrade.
+	ChangeAttributeRemoved ChangeKind = "attribute_removed"
+
+	// ChangeAttributeDeprecated is an attribute that was deprecated.
+	ChangeAttributeDeprecated ChangeKind = "attribute_deprecated"
+
+	// ChangeTypeChanged is an attribute whose type changed, which needs a
+	// schema version bump and state upgrade for resources.
+	ChangeTypeChanged ChangeKind = "type_changed"
+
+	// ChangeNestingChanged is a nested attribute or block whose nesting
+	// mode changed, such as from list to set, which needs a schema version
+	// bump and state upgrade for resources.
+	ChangeNestingChanged ChangeKind = "nesting_changed"
+
+	// ChangeModeChanged is an attribute that changed between required,
+	// optional, optional and computed, and computed. Changes that accept
+	// every configuration that was valid before, and keep computing values
+	// that were computed before, aren't breaking.
+	ChangeModeChanged ChangeKind = "mode_changed"
+
+	// ChangeSensitivityChanged is an attribute that was marked or
+	// unmarked as sensitive.
+	ChangeSensitivityChanged ChangeKind = "sensitivity_changed"
+
+	// ChangeItemLimitsChanged is a nested attribute or block whose minimum
+	// or maximum number of items changed. It's breaking if the limits are
+	// stricter.
+	ChangeItemLimitsChanged ChangeKind = "item_limits_changed"
+
+	// ChangeVersionDecreased is a resource whose schema version
+	// decreased. Terraform can't use states written by the newer version.
+	ChangeVersionDecreased ChangeKind = "version_decreased"
+
+	// ChangeVersionBumpMissing is a resource whose state changed shape,
+	// with attributes removed or changing type, without a schema version
+	// bump. Terraform fails to decode existing states.
+	ChangeVersionBumpMissing ChangeKind = "version_bump_missing"
+
+	// ChangeStateUpgradeMissing is a resource whose schema version was
+	// bumped because its state changed shape, but whose states can't be
+	// upgraded from the old version, according to Options.StateUpgrades.
+	// The framework passes prior states through to Terraform unchanged,
+	// so Terraform fails to decode them.
+	ChangeStateUpgradeMissing ChangeKind = "state_upgrade_missing"
+)
+
+// Change is a difference between two provider schemas.
+type Change struct {
+	// Breaking is true if the change breaks existing configurations or
+	// states.
+	Breaking bool `json:"breaking"`
+
+	// Kind identifies the kind of change.
+	Kind ChangeKind `json:"kind"`
+
+	// SchemaType is the kind of schema the change is in.
+	SchemaType SchemaType `json:"schema_type"`
+
+	// TypeName is the type name of the resource or data source the change
+	// is in. It's empty for the provider and provider_meta schemas.
+	TypeName string `json:"type_name,omitempty"`
+
+	// Path is the path to the attribute that changed, with the names of
+	// nested attributes separated by periods, such as `rule.port`. It's
+	// empty for changes to the schema itself.
+	Path string `json:"path,omitempty"`
+
+	// Message describes the change.
+	Message string `json:"message"`
+}
+
+// String returns the change in the form
+// `BREAKING resource test_thing: rule.port: message`.
+func (c Change) String() string {
+	var b strings.Builder
+
+	if c.Breaking {
+		b.WriteString("BREAKING ")
+	} else {
+		b.WriteString("ok ")
+	}
+
+	b.WriteString(strings.ReplaceAll(string(c.SchemaType), "_", " "))
+
+	if c.TypeName != "" {
+		b.WriteString(" " + c.TypeName)
+	}
+
+	if c.Path != "" {
+		b.WriteString(": " + c.Path)
+	}
+
+	b.WriteString(": " + c.Message)
+
+	return b.String()
+}
+
+// Report is the result of comparing two provider schemas.
+type Report struct {
+	// Breaking is true if any of the changes are breaking.
+	Breaking bool `json:"breaking"`
+
+	// Changes are the differences between the schemas, ordered by schema
+	// type, type name, and path.
+	Changes []Change `json:"changes"`
+}
+
+// BreakingChanges returns the changes that are breaking.
+func (r Report) BreakingChanges() []Change {
+	var result []Change
+
+	for _, change := range r.Changes {
+		if change.Breaking {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+// String returns the changes, one per line, followed by a count of breaking
+// changes.
+func (r Report) String() string {
+	var b strings.Builder
+
+	for _, change := range r.Changes {
+		b.WriteString(change.String() + "\n")
+	}
+
+	fmt.Fprintf(&b, "%d changes, %d breaking\n", len(r.Changes), len(r.BreakingChanges()))
+
+	return b.String()
+}
@@ -0,0 +1,300 @@
+// Package tfsdkdocs generates Terraform Registry documentation for providers
+// built with tfsdk from their schemas.
+//
+// It's meant to be called from a small program run by go generate, as the
+// provider's Go code has to be compiled into the program:
+//
+//	//go:generate go run ./internal/docs
+//
+// where internal/docs/main.go calls Generate with the provider.
+package tfsdkdocs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// Options configures Generate.
+type Options struct {
+	// ProviderName is the name of the provider, such as "example". It's
+	// used in page titles, and removed from the start of resource and data
+	// source type names to name their files. It defaults to the text
+	// before the first underscore in the first resource or data source
+	// type name.
+	ProviderName string
+
+	// OutputDir is the directory the documentation is written to. It
+	// defaults to "docs".
+	OutputDir string
+
+	// TemplatesDir is the directory user templates are read from. A
+	// template at index.md.tmpl, resources/<name>.md.tmpl, or
+	// data-sources/<name>.md.tmpl, where <name> is the type name without
+	// the provider name, is used in place of the default template for
+	// that page. It defaults to "templates".
+	//
+	// Templates are text/template templates executed with TemplateData,
+	// and have these functions available in addition to the builtins:
+	//
+	//	codefile "hcl" "path/to/file.hcl"  the file in a fenced code block
+	//	tffile "path/to/file.tf"           the same, for Terraform files
+	//	trimspace "  text  "               strings.TrimSpace
+	//	prefixlines "  " "text"            prefixes every line
+	//
+	// File paths are relative to the working directory.
+	TemplatesDir string
+
+	// ExamplesDir is the directory examples are read from. The example at
+	// provider/provider.tf, resources/<type name>/resource.tf, or
+	// data-sources/<type name>/data-source.tf is included in the default
+	// templates' Example Usage section, if it exists. It defaults to
+	// "examples".
+	ExamplesDir string
+}
+
+// TemplateData is the data templates are executed with.
+type TemplateData struct {
+	// Type is the kind of page: "Provider", "Resource", or "Data Source".
+	Type string
+
+	// Name is the type name of the resource or data source, or the
+	// provider name for the provider.
+	Name string
+
+	// ShortName is Name without the provider name.
+	ShortName string
+
+	// ProviderName is the name of the provider.
+	ProviderName string
+
+	// Description is the schema's Markdown description, or its plain
+	// description if that's not set.
+	Description string
+
+	// DeprecationMessage is the schema's deprecation message.
+	DeprecationMessage string
+
+	// HasExample is true if ExampleFile exists.
+	HasExample bool
+
+	// ExampleFile is the path of the default example for the page.
+	ExampleFile string
+
+	// SchemaMarkdown is the documentation of the schema, as returned by
+	// SchemaMarkdown.
+	SchemaMarkdown string
+}
+
+// defaultTemplate is used for pages without a user template.
+const defaultTemplate = `---
+page_title: "{{ .Name }} {{ .Type }} - {{ .ProviderName }}"
+subcategory: ""
+description: |-
+{{ .Description | prefixlines "  " }}
+---
+
+# {{ .Name }} ({{ .Type }})
+{{ if .DeprecationMessage }}
+~> **Deprecated** {{ .DeprecationMessage }}
+{{ end }}
+{{ .Description }}
+{{ if .HasExample }}
+## Example Usage
+
+{{ tffile .ExampleFile }}
+{{ end }}
+{{ .SchemaMarkdown }}`
+
+// Generate writes documentation for `provider` to opts.OutputDir: an
+// index.md for the provider, and a Markdown file for each resource and data
+// source in the resources and data-sources directories.
+func Generate(ctx context.Context, provider tfsdk.Provider, opts Options) error {
+	if opts.OutputDir == "" {
+		opts.OutputDir = "docs"
+	}
+	if opts.TemplatesDir == "" {
+		opts.TemplatesDir = "templates"
+	}
+	if opts.ExamplesDir == "" {
+		opts.ExamplesDir = "examples"
+	}
+
+	providerSchema, diags := provider.GetSchema(ctx)
+	if err := diags.ToError(); err != nil {
+		return fmt.Errorf("error getting provider schema: %w", err)
+	}
+
+	resourceTypes, diags := provider.GetResources(ctx)
+	if err := diags.ToError(); err != nil {
+		return fmt.Errorf("error getting resources: %w", err)
+	}
+
+	dataSourceTypes, diags := provider.GetDataSources(ctx)
+	if err := diags.ToError(); err != nil {
+		return fmt.Errorf("error getting data sources: %w", err)
+	}
+
+	resourceNames := make([]string, 0, len(resourceTypes))
+	for name := range resourceTypes {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+
+	dataSourceNames := make([]string, 0, len(dataSourceTypes))
+	for name := range dataSourceTypes {
+		dataSourceNames = append(dataSourceNames, name)
+	}
+	sort.Strings(dataSourceNames)
+
+	if opts.ProviderName == "" {
+		opts.ProviderName = providerName(append(resourceNames, dataSourceNames...))
+	}
+
+	if err := opts.writePage(ctx, "Provider", opts.ProviderName, "index.md", filepath.Join("provider", "provider.tf"), providerSchema); err != nil {
+		return err
+	}
+
+	for _, name := range resourceNames {
+		schema, diags := resourceTypes[name].GetSchema(ctx)
+		if err := diags.ToError(); err != nil {
+			return fmt.Errorf("error getting schema for resource %s: %w", name, err)
+		}
+
+		shortName := opts.shortName(name)
+		if err := opts.writePage(ctx, "Resource", name, filepath.Join("resources", shortName+".md"), filepath.Join("resources", name, "resource.tf"), schema); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range dataSourceNames {
+		schema, diags := dataSourceTypes[name].GetSchema(ctx)
+		if err := diags.ToError(); err != nil {
+			return fmt.Errorf("error getting schema for data source %s: %w", name, err)
+		}
+
+		shortName := opts.shortName(name)
+		if err := opts.writePage(ctx, "Data Source", name, filepath.Join("data-sources", shortName+".md"), filepath.Join("data-sources", name, "data-source.tf"), schema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writePage renders the page for `schema` to `file`, relative to
+// opts.OutputDir, using the user template for `file` if there is one.
+func (opts Options) writePage(ctx context.Context, typ, name, file, example string, schema tfsdk.Schema) error {
+	data := TemplateData{
+		Type:               typ,
+		Name:               name,
+		ShortName:          opts.shortName(name),
+		ProviderName:       opts.ProviderName,
+		Description:        markdownDescription(schema.MarkdownDescription, schema.Description),
+		DeprecationMessage: schema.DeprecationMessage,
+		ExampleFile:        filepath.Join(opts.ExamplesDir, example),
+		SchemaMarkdown:     SchemaMarkdown(ctx, schema),
+	}
+
+	if _, err := os.Stat(data.ExampleFile); err == nil {
+		data.HasExample = true
+	}
+
+	text := defaultTemplate
+	templateFile := filepath.Join(opts.TemplatesDir, file+".tmpl")
+
+	userTemplate, err := os.ReadFile(templateFile)
+	switch {
+	case err == nil:
+		text = string(userTemplate)
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("error reading template: %w", err)
+	}
+
+	tmpl, err := template.New(file).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("error parsing template for %s: %w", file, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("error executing template for %s: %w", file, err)
+	}
+
+	path := filepath.Join(opts.OutputDir, file)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating directory for %s: %w", file, err)
+	}
+
+	if err := os.WriteFile(path, cleanMarkdown(buf.Bytes()), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", file, err)
+	}
+
+	return nil
+}
+
+func (opts Options) shortName(name string) string {
+	return strings.TrimPrefix(name, opts.ProviderName+"_")
+}
+
+var templateFuncs = template.FuncMap{
+	"codefile": codeFile,
+	"tffile": func(file string) (string, error) {
+		return codeFile("terraform", file)
+	},
+	"trimspace": strings.TrimSpace,
+	"prefixlines": func(prefix, text string) string {
+		lines := strings.Split(text, "\n")
+		for i := range lines {
+			lines[i] = prefix + lines[i]
+		}
+		return strings.Join(lines, "\n")
+	},
+}
+
+// codeFile returns the contents of `file` in a fenced code block for
+// `language`.
+func codeFile(language, file string) (string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	return "```" + language + "\n" + strings.TrimSpace(string(content)) + "\n```", nil
+}
+
+// cleanMarkdown collapses runs of blank lines, which templates with
+// optional sections tend to leave behind, and ends `md` with a single
+// newline.
+func cleanMarkdown(md []byte) []byte {
+	lines := strings.Split(strings.TrimSpace(string(md)), "\n")
+	result := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && len(result) > 0 && result[len(result)-1] == "" {
+			continue
+		}
+		result = append(result, line)
+	}
+
+	return []byte(strings.Join(result, "\n") + "\n")
+}
+
+// providerName guesses the provider name from the type names of its
+// resources and data sources.
+func providerName(typeNames []string) string {
+	if len(typeNames) == 0 {
+		return ""
+	}
+
+	return strings.SplitN(typeNames[0], "_", 2)[0]
+}
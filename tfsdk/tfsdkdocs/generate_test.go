@@ -0,0 +1,267 @@
+package tfsdkdocs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type testProvider struct{}
+
+func (p testProvider) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "The example provider manages things.",
+		Attributes: map[string]tfsdk.Attribute{
+			"endpoint": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "The API endpoint",
+			},
+		},
+	}, nil
+}
+
+func (p testProvider) Configure(context.Context, tfsdk.ConfigureProviderRequest, *tfsdk.ConfigureProviderResponse) {
+}
+
+func (p testProvider) GetResources(context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
+	return map[string]tfsdk.ResourceType{
+		"example_thing": testType{
+			schema: tfsdk.Schema{
+				Description:        "Manages a thing.",
+				DeprecationMessage: "Use example_widget instead.",
+				Attributes: map[string]tfsdk.Attribute{
+					"name": {
+						Type:     types.StringType,
+						Required: true,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (p testProvider) GetDataSources(context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
+	return map[string]tfsdk.DataSourceType{
+		"example_thing": testType{
+			schema: tfsdk.Schema{
+				Description: "Reads a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"id": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// testType is a resource and data source type that's only used for its
+// schema.
+type testType struct {
+	schema tfsdk.Schema
+}
+
+func (t testType) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return t.schema, nil
+}
+
+func (t testType) NewResource(context.Context, tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return nil, nil
+}
+
+func (t testType) NewDataSource(context.Context, tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return nil, nil
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		files    map[string]string
+		expected map[string]string
+	}
+
+	tests := map[string]testCase{
+		"default": {
+			expected: map[string]string{
+				"index.md": `---
+page_title: "example Provider - example"
+subcategory: ""
+description: |-
+  The example provider manages things.
+---
+
+# example (Provider)
+
+The example provider manages things.
+
+## Schema
+
+### Optional
+
+- ` + "`endpoint`" + ` (String) The API endpoint.
+`,
+				"resources/thing.md": `---
+page_title: "example_thing Resource - example"
+subcategory: ""
+description: |-
+  Manages a thing.
+---
+
+# example_thing (Resource)
+
+~> **Deprecated** Use example_widget instead.
+
+Manages a thing.
+
+## Schema
+
+### Required
+
+- ` + "`name`" + ` (String)
+`,
+				"data-sources/thing.md": `---
+page_title: "example_thing Data Source - example"
+subcategory: ""
+description: |-
+  Reads a thing.
+---
+
+# example_thing (Data Source)
+
+Reads a thing.
+
+## Schema
+
+### Read-Only
+
+- ` + "`id`" + ` (String)
+`,
+			},
+		},
+		"examples-and-templates": {
+			files: map[string]string{
+				"examples/resources/example_thing/resource.tf": "resource \"example_thing\" \"foo\" {\n  name = \"foo\"\n}\n",
+				"templates/index.md.tmpl":                      "# {{ .ProviderName }}\n\n{{ codefile \"hcl\" \"$DIR/examples/provider/custom.tf\" }}\n\n{{ .SchemaMarkdown }}",
+				"examples/provider/custom.tf":                  "provider \"example\" {}\n",
+			},
+			expected: map[string]string{
+				"index.md": "# example\n\n" +
+					"```hcl\nprovider \"example\" {}\n```\n\n" +
+					"## Schema\n\n" +
+					"### Optional\n\n" +
+					"- `endpoint` (String) The API endpoint.\n",
+				"resources/thing.md": `---
+page_title: "example_thing Resource - example"
+subcategory: ""
+description: |-
+  Manages a thing.
+---
+
+# example_thing (Resource)
+
+~> **Deprecated** Use example_widget instead.
+
+Manages a thing.
+
+## Example Usage
+
+` + "```terraform" + `
+resource "example_thing" "foo" {
+  name = "foo"
+}
+` + "```" + `
+
+## Schema
+
+### Required
+
+- ` + "`name`" + ` (String)
+`,
+				"data-sources/thing.md": `---
+page_title: "example_thing Data Source - example"
+subcategory: ""
+description: |-
+  Reads a thing.
+---
+
+# example_thing (Data Source)
+
+Reads a thing.
+
+## Schema
+
+### Read-Only
+
+- ` + "`id`" + ` (String)
+`,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			for file, content := range tc.files {
+				path := filepath.Join(dir, file)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				content = strings.ReplaceAll(content, "$DIR", filepath.ToSlash(dir))
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			opts := Options{
+				OutputDir:    filepath.Join(dir, "docs"),
+				TemplatesDir: filepath.Join(dir, "templates"),
+				ExamplesDir:  filepath.Join(dir, "examples"),
+			}
+
+			if err := Generate(context.Background(), testProvider{}, opts); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got := map[string]string{}
+			err := filepath.Walk(opts.OutputDir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+
+				rel, err := filepath.Rel(opts.OutputDir, path)
+				if err != nil {
+					return err
+				}
+
+				got[filepath.ToSlash(rel)] = string(content)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
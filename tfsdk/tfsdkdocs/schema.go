@@ -0,0 +1,253 @@
+package tfsdkdocs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/attrvalue"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// PlanModifierWithDefault is an AttributePlanModifier that sets a default
+// value for its attribute. The value returned by DefaultValue is documented
+// as the attribute's default.
+type PlanModifierWithDefault interface {
+	tfsdk.AttributePlanModifier
+
+	// DefaultValue returns the value the attribute defaults to.
+	DefaultValue(context.Context) attr.Value
+}
+
+// nestedSchema is a nested attribute whose attributes are documented in
+// their own section.
+type nestedSchema struct {
+	path       []string
+	attributes map[string]tfsdk.Attribute
+}
+
+// SchemaMarkdown renders the attributes of `schema` as Markdown, in
+// Required, Optional, and Read-Only sections, followed by a section for each
+// nested attribute. Attributes that are both optional and computed are
+// documented as optional.
+func SchemaMarkdown(ctx context.Context, schema tfsdk.Schema) string {
+	var b strings.Builder
+
+	b.WriteString("## Schema\n")
+
+	nested := writeAttributes(ctx, &b, "###", nil, schema.Attributes)
+
+	for len(nested) > 0 {
+		n := nested[0]
+		nested = nested[1:]
+
+		fmt.Fprintf(&b, "\n<a id=\"%s\"></a>\n### Nested Schema for `%s`\n", nestedAnchor(n.path), strings.Join(n.path, "."))
+
+		nested = append(nested, writeAttributes(ctx, &b, "", n.path, n.attributes)...)
+	}
+
+	return b.String()
+}
+
+// writeAttributes writes the attributes in `attributes`, which are nested
+// within the attribute at `path`, grouped into sections with headings at
+// `level`, or with plain labels if `level` is empty. It returns the nested
+// attributes that need their own sections.
+func writeAttributes(ctx context.Context, b *strings.Builder, level string, path []string, attributes map[string]tfsdk.Attribute) []nestedSchema {
+	var nested []nestedSchema
+
+	groups := []struct {
+		name    string
+		include func(tfsdk.Attribute) bool
+	}{
+		{"Required", func(a tfsdk.Attribute) bool { return a.Required }},
+		{"Optional", func(a tfsdk.Attribute) bool { return a.Optional }},
+		{"Read-Only", func(a tfsdk.Attribute) bool { return a.Computed && !a.Optional && !a.Required }},
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, group := range groups {
+		var groupNames []string
+		for _, name := range names {
+			if group.include(attributes[name]) {
+				groupNames = append(groupNames, name)
+			}
+		}
+
+		if len(groupNames) == 0 {
+			continue
+		}
+
+		if level == "" {
+			fmt.Fprintf(b, "\n%s:\n\n", group.name)
+		} else {
+			fmt.Fprintf(b, "\n%s %s\n\n", level, group.name)
+		}
+
+		for _, name := range groupNames {
+			attrPath := append(append([]string{}, path...), name)
+			attribute := attributes[name]
+
+			b.WriteString(attributeMarkdown(ctx, attrPath, attribute) + "\n")
+
+			if attribute.Attributes != nil {
+				nested = append(nested, nestedSchema{
+					path:       attrPath,
+					attributes: attribute.Attributes.GetAttributes(),
+				})
+			}
+		}
+	}
+
+	return nested
+}
+
+// attributeMarkdown returns the list item documenting `attribute`, which is
+// at `path`.
+func attributeMarkdown(ctx context.Context, path []string, attribute tfsdk.Attribute) string {
+	annotations := []string{attributeTypeName(ctx, attribute)}
+
+	if attribute.Attributes != nil {
+		if min := attribute.Attributes.GetMinItems(); min > 0 {
+			annotations = append(annotations, fmt.Sprintf("Min: %d", min))
+		}
+		if max := attribute.Attributes.GetMaxItems(); max > 0 {
+			annotations = append(annotations, fmt.Sprintf("Max: %d", max))
+		}
+	}
+	if attribute.Sensitive {
+		annotations = append(annotations, "Sensitive")
+	}
+	if attribute.DeprecationMessage != "" {
+		annotations = append(annotations, "Deprecated")
+	}
+
+	sentences := []string{}
+
+	if description := markdownDescription(attribute.MarkdownDescription, attribute.Description); description != "" {
+		sentences = append(sentences, sentence(description))
+	}
+
+	for _, validator := range attribute.Validators {
+		if description := markdownDescription(validator.MarkdownDescription(ctx), validator.Description(ctx)); description != "" {
+			sentences = append(sentences, sentence(description))
+		}
+	}
+
+	for _, modifier := range attribute.PlanModifiers {
+		if withDefault, ok := modifier.(PlanModifierWithDefault); ok {
+			sentences = append(sentences, fmt.Sprintf("Defaults to `%s`.", attrvalue.String(ctx, withDefault.DefaultValue(ctx))))
+			continue
+		}
+
+		if description := markdownDescription(modifier.MarkdownDescription(ctx), modifier.Description(ctx)); description != "" {
+			sentences = append(sentences, sentence(description))
+		}
+	}
+
+	if attribute.DeprecationMessage != "" {
+		sentences = append(sentences, "**Deprecated** "+sentence(attribute.DeprecationMessage))
+	}
+
+	if attribute.Attributes != nil {
+		sentences = append(sentences, fmt.Sprintf("(see [below for nested schema](#%s))", nestedAnchor(path)))
+	}
+
+	item := fmt.Sprintf("- `%s` (%s)", path[len(path)-1], strings.Join(annotations, ", "))
+	if len(sentences) > 0 {
+		item += " " + strings.Join(sentences, " ")
+	}
+
+	return item
+}
+
+// attributeTypeName returns a short description of the type of
+// `attribute`, such as "String", "List of Number", or "Attributes List".
+func attributeTypeName(ctx context.Context, attribute tfsdk.Attribute) string {
+	if attribute.Attributes != nil {
+		switch attribute.Attributes.GetNestingMode() {
+		case tfsdk.NestingModeList:
+			return "Attributes List"
+		case tfsdk.NestingModeSet:
+			return "Attributes Set"
+		case tfsdk.NestingModeMap:
+			return "Attributes Map"
+		default:
+			return "Attributes"
+		}
+	}
+
+	if attribute.Type == nil {
+		return "Unknown"
+	}
+
+	return typeName(attribute.Type.TerraformType(ctx))
+}
+
+func typeName(typ tftypes.Type) string {
+	switch t := typ.(type) {
+	case tftypes.List:
+		return "List of " + typeName(t.ElementType)
+	case tftypes.Set:
+		return "Set of " + typeName(t.ElementType)
+	case tftypes.Map:
+		return "Map of " + typeName(t.AttributeType)
+	case tftypes.Object:
+		return "Object"
+	case tftypes.Tuple:
+		return "Tuple"
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		return "String"
+	case typ.Is(tftypes.Number):
+		return "Number"
+	case typ.Is(tftypes.Bool):
+		return "Boolean"
+	case typ.Is(tftypes.DynamicPseudoType):
+		return "Dynamic"
+	default:
+		return typ.String()
+	}
+}
+
+// markdownDescription returns `markdown` if it's set, and `plain`
+// otherwise.
+func markdownDescription(markdown, plain string) string {
+	if markdown != "" {
+		return strings.TrimSpace(markdown)
+	}
+
+	return strings.TrimSpace(plain)
+}
+
+// sentence returns `s` with its first letter capitalized and a trailing
+// period, if it doesn't already end with punctuation.
+func sentence(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return s
+	}
+
+	s = strings.ToUpper(s[:1]) + s[1:]
+
+	switch s[len(s)-1] {
+	case '.', '!', '?':
+		return s
+	default:
+		return s + "."
+	}
+}
+
+func nestedAnchor(path []string) string {
+	return "nestedatt--" + strings.Join(path, "--")
+}
@@ -0,0 +1,182 @@
+package tfsdkdocs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultModifier documents a default value. It's only used for its
+// descriptions, so Modify doesn't do anything.
+type defaultModifier struct {
+	value attr.Value
+}
+
+func (m defaultModifier) Description(context.Context) string {
+	return "Sets a default value."
+}
+
+func (m defaultModifier) MarkdownDescription(context.Context) string {
+	return "Sets a default value."
+}
+
+func (m defaultModifier) Modify(context.Context, tfsdk.ModifyAttributePlanRequest, *tfsdk.ModifyAttributePlanResponse) {
+}
+
+func (m defaultModifier) DefaultValue(context.Context) attr.Value {
+	return m.value
+}
+
+// oneOf validates that a string is one of a set of values.
+type oneOf struct{}
+
+func (v oneOf) Description(context.Context) string {
+	return "value must be one of tcp or udp"
+}
+
+func (v oneOf) MarkdownDescription(context.Context) string {
+	return "value must be one of `tcp` or `udp`"
+}
+
+func (v oneOf) Validate(context.Context, tfsdk.ValidateAttributeRequest, *tfsdk.ValidateAttributeResponse) {
+}
+
+func TestSchemaMarkdown(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		schema   tfsdk.Schema
+		expected string
+	}
+
+	tests := map[string]testCase{
+		"empty": {
+			schema:   tfsdk.Schema{},
+			expected: "## Schema\n",
+		},
+		"sections": {
+			schema: tfsdk.Schema{
+				Attributes: map[string]tfsdk.Attribute{
+					"name": {
+						Type:          types.StringType,
+						Required:      true,
+						Description:   "the name of the thing",
+						PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+					},
+					"id": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"size": {
+						Type:                types.NumberType,
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "The size, in **bytes**",
+						Description:         "The size, in bytes",
+					},
+					"tags": {
+						Type:               types.ListType{ElemType: types.StringType},
+						Optional:           true,
+						DeprecationMessage: "use labels instead",
+					},
+					"password": {
+						Type:      types.StringType,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+			expected: "## Schema\n" +
+				"\n### Required\n\n" +
+				"- `name` (String) The name of the thing. If the value of this attribute changes, Terraform will destroy and recreate the resource.\n" +
+				"\n### Optional\n\n" +
+				"- `password` (String, Sensitive)\n" +
+				"- `size` (Number) The size, in **bytes**.\n" +
+				"- `tags` (List of String, Deprecated) **Deprecated** Use labels instead.\n" +
+				"\n### Read-Only\n\n" +
+				"- `id` (String)\n",
+		},
+		"validators-and-defaults": {
+			schema: tfsdk.Schema{
+				Attributes: map[string]tfsdk.Attribute{
+					"protocol": {
+						Type:          types.StringType,
+						Optional:      true,
+						Computed:      true,
+						Validators:    []tfsdk.AttributeValidator{oneOf{}},
+						PlanModifiers: []tfsdk.AttributePlanModifier{defaultModifier{value: types.String{Value: "tcp"}}},
+					},
+				},
+			},
+			expected: "## Schema\n" +
+				"\n### Optional\n\n" +
+				"- `protocol` (String) Value must be one of `tcp` or `udp`. Defaults to `\"tcp\"`.\n",
+		},
+		"nested": {
+			schema: tfsdk.Schema{
+				Attributes: map[string]tfsdk.Attribute{
+					"rule": {
+						Optional: true,
+						Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+							"port": {
+								Type:     types.NumberType,
+								Required: true,
+							},
+							"target": {
+								Optional: true,
+								Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+									"address": {
+										Type:     types.StringType,
+										Required: true,
+									},
+								}),
+							},
+						}, tfsdk.ListNestedAttributesOptions{MaxItems: 2}),
+					},
+					"labels": {
+						Computed: true,
+						Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
+							"value": {
+								Type:     types.StringType,
+								Computed: true,
+							},
+						}, tfsdk.MapNestedAttributesOptions{}),
+					},
+				},
+			},
+			expected: "## Schema\n" +
+				"\n### Optional\n\n" +
+				"- `rule` (Attributes List, Max: 2) (see [below for nested schema](#nestedatt--rule))\n" +
+				"\n### Read-Only\n\n" +
+				"- `labels` (Attributes Map) (see [below for nested schema](#nestedatt--labels))\n" +
+				"\n<a id=\"nestedatt--rule\"></a>\n### Nested Schema for `rule`\n" +
+				"\nRequired:\n\n" +
+				"- `port` (Number)\n" +
+				"\nOptional:\n\n" +
+				"- `target` (Attributes) (see [below for nested schema](#nestedatt--rule--target))\n" +
+				"\n<a id=\"nestedatt--labels\"></a>\n### Nested Schema for `labels`\n" +
+				"\nRead-Only:\n\n" +
+				"- `value` (String)\n" +
+				"\n<a id=\"nestedatt--rule--target\"></a>\n### Nested Schema for `rule.target`\n" +
+				"\nRequired:\n\n" +
+				"- `address` (String)\n",
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SchemaMarkdown(context.Background(), tc.schema)
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
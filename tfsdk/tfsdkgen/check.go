@@ -0,0 +1,21 @@
+package tfsdkgen
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// CheckModel returns an error if `model` can't be used with Get and Set for
+// `schema`: if an attribute has no field, a field has no attribute, or a
+// field's type can't hold its attribute's values, including in nested
+// attributes. It's tfsdk.ValidateModel, with its error diagnostics returned
+// as an error and its warnings ignored; the diagnostics can be retrieved
+// with diag.FromErr.
+//
+// Generated models match their schemas by construction. CheckModel is meant
+// to be called from tests, to catch them drifting apart after hand edits, or
+// to check hand-written models.
+func CheckModel(ctx context.Context, schema tfsdk.Schema, model interface{}) error {
+	return tfsdk.ValidateModel(ctx, schema, model).ToError()
+}
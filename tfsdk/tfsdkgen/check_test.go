@@ -0,0 +1,118 @@
+package tfsdkgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/pathstring"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckModel(t *testing.T) {
+	t.Parallel()
+
+	schema := tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"rule": {
+				Optional: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"port": {
+						Type:     types.NumberType,
+						Required: true,
+					},
+					"protocol": {
+						Type:     types.StringType,
+						Optional: true,
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+			},
+		},
+	}
+
+	type rule struct {
+		Port types.Number `tfsdk:"port"`
+		Cidr types.String `tfsdk:"cidr"`
+	}
+
+	type common struct {
+		ID types.String `tfsdk:"id"`
+	}
+
+	type testCase struct {
+		model         interface{}
+		expectedPaths []string
+	}
+
+	tests := map[string]testCase{
+		"match": {
+			model: &struct {
+				common
+				Name types.String `tfsdk:"name"`
+				Rule types.List   `tfsdk:"rule"`
+			}{},
+		},
+		"mismatch": {
+			model: struct {
+				Name    types.String `tfsdk:"name"`
+				Size    types.Int64  `tfsdk:"size"`
+				Rule    []rule       `tfsdk:"rule"`
+				Ignored string       `tfsdk:"-"`
+			}{},
+			expectedPaths: []string{
+				"id",
+				"rule.cidr",
+				"rule.protocol",
+				"size",
+			},
+		},
+		"wrong-type": {
+			model: &struct {
+				common
+				Name int64      `tfsdk:"name"`
+				Rule types.List `tfsdk:"rule"`
+			}{},
+			expectedPaths: []string{"name"},
+		},
+		"not-a-struct": {
+			model:         map[string]string{},
+			expectedPaths: []string{""},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckModel(context.Background(), schema, tc.model)
+
+			var gotPaths []string
+			for _, d := range diag.FromErr(err) {
+				if d.Severity() != diag.SeverityError {
+					t.Errorf("Unexpected %s diagnostic: %s", d.Severity(), d.Summary())
+				}
+
+				var path string
+				if d, ok := d.(diag.DiagnosticWithPath); ok {
+					path = pathstring.String(d.Path())
+				}
+				gotPaths = append(gotPaths, path)
+			}
+
+			if diff := cmp.Diff(gotPaths, tc.expectedPaths); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
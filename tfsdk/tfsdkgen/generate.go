@@ -0,0 +1,343 @@
+package tfsdkgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generator accumulates generated code.
+type generator struct {
+	buf bytes.Buffer
+
+	// usesAttr is true if the code refers to the attr package, which is
+	// only needed for object types.
+	usesAttr bool
+}
+
+func (g *generator) printf(format string, a ...interface{}) {
+	fmt.Fprintf(&g.buf, format, a...)
+}
+
+// Generate returns the Go source file for `spec`: a GetSchema method for
+// every schema, and a model struct for every schema and nested attribute,
+// with a field for every attribute, that State.Get, Plan.Set, and so on
+// accept.
+func Generate(spec Spec) ([]byte, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &generator{}
+
+	if spec.Provider != nil {
+		goType := spec.Provider.GoType
+		if goType == "" {
+			goType = "provider"
+		}
+
+		g.schema("the provider", "p *"+goType, "provider", *spec.Provider)
+	}
+
+	for _, typeName := range sortedKeys(spec.Resources) {
+		schema := spec.Resources[typeName]
+		prefix := lowerGoName(typeName) + "Resource"
+		goType := schema.GoType
+
+		if goType == "" {
+			goType = prefix + "Type"
+			g.printf("// %s is the resource type of %s.\n", goType, typeName)
+			g.printf("type %s struct{}\n\n", goType)
+		}
+
+		g.schema("the "+typeName+" resource", "r "+goType, prefix, schema)
+	}
+
+	for _, typeName := range sortedKeys(spec.DataSources) {
+		schema := spec.DataSources[typeName]
+		prefix := lowerGoName(typeName) + "DataSource"
+		goType := schema.GoType
+
+		if goType == "" {
+			goType = prefix + "Type"
+			g.printf("// %s is the data source type of %s.\n", goType, typeName)
+			g.printf("type %s struct{}\n\n", goType)
+		}
+
+		g.schema("the "+typeName+" data source", "d "+goType, prefix, schema)
+	}
+
+	var file bytes.Buffer
+
+	file.WriteString("// Code generated by tfsdkgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&file, "package %s\n\n", spec.Package)
+	file.WriteString(g.imports(spec.Imports))
+	file.Write(g.buf.Bytes())
+
+	out, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code, check the validator and plan modifier expressions: %w", err)
+	}
+
+	return out, nil
+}
+
+func (g *generator) imports(extra []string) string {
+	imports := []string{
+		"github.com/hashicorp/terraform-plugin-framework/diag",
+		"github.com/hashicorp/terraform-plugin-framework/tfsdk",
+		"github.com/hashicorp/terraform-plugin-framework/types",
+	}
+
+	if g.usesAttr {
+		imports = append(imports, "github.com/hashicorp/terraform-plugin-framework/attr")
+	}
+
+	seen := map[string]bool{}
+	for _, path := range imports {
+		seen[path] = true
+	}
+
+	for _, path := range extra {
+		if !seen[path] {
+			seen[path] = true
+			imports = append(imports, path)
+		}
+	}
+
+	sort.Strings(imports)
+
+	var b strings.Builder
+
+	b.WriteString("import (\n\t\"context\"\n\n")
+	for _, path := range imports {
+		b.WriteString("\t" + strconv.Quote(path) + "\n")
+	}
+	b.WriteString(")\n\n")
+
+	return b.String()
+}
+
+// schema generates the GetSchema method with `receiver` for `schema`,
+// which is described as `description` in comments, and its models, named
+// starting with `prefix`.
+func (g *generator) schema(description, receiver, prefix string, schema SchemaSpec) {
+	g.printf("// GetSchema returns the schema of %s.\n", description)
+	g.printf("func (%s) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {\n", receiver)
+	g.printf("return tfsdk.Schema{\n")
+
+	if schema.Version != 0 {
+		g.printf("Version: %d,\n", schema.Version)
+	}
+	g.stringField("Description", schema.Description)
+	g.stringField("MarkdownDescription", schema.MarkdownDescription)
+	g.stringField("DeprecationMessage", schema.DeprecationMessage)
+
+	g.printf("Attributes: ")
+	g.attributes(schema.Attributes)
+	g.printf(",\n")
+
+	g.printf("}, nil\n")
+	g.printf("}\n\n")
+
+	g.models(prefix, description, "", schema.Attributes)
+}
+
+func (g *generator) stringField(name, value string) {
+	if value != "" {
+		g.printf("%s: %s,\n", name, strconv.Quote(value))
+	}
+}
+
+func (g *generator) boolField(name string, value bool) {
+	if value {
+		g.printf("%s: true,\n", name)
+	}
+}
+
+func (g *generator) attributes(attributes map[string]AttributeSpec) {
+	g.printf("map[string]tfsdk.Attribute{\n")
+
+	for _, name := range sortedKeys(attributes) {
+		attribute := attributes[name]
+
+		g.printf("%s: {\n", strconv.Quote(name))
+
+		if attribute.Nesting == "" {
+			g.printf("Type: %s,\n", g.attrType(attribute.TypeSpec))
+		} else {
+			g.nestedAttributes(attribute)
+		}
+
+		g.boolField("Required", attribute.Required)
+		g.boolField("Optional", attribute.Optional)
+		g.boolField("Computed", attribute.Computed)
+		g.boolField("Sensitive", attribute.Sensitive)
+		g.stringField("Description", attribute.Description)
+		g.stringField("MarkdownDescription", attribute.MarkdownDescription)
+		g.stringField("DeprecationMessage", attribute.DeprecationMessage)
+
+		if len(attribute.Validators) > 0 {
+			g.printf("Validators: []tfsdk.AttributeValidator{\n")
+			for _, validator := range attribute.Validators {
+				g.printf("%s,\n", validator)
+			}
+			g.printf("},\n")
+		}
+
+		if len(attribute.PlanModifiers) > 0 {
+			g.printf("PlanModifiers: tfsdk.AttributePlanModifiers{\n")
+			for _, modifier := range attribute.PlanModifiers {
+				g.printf("%s,\n", modifier)
+			}
+			g.printf("},\n")
+		}
+
+		g.printf("},\n")
+	}
+
+	g.printf("}")
+}
+
+func (g *generator) nestedAttributes(attribute AttributeSpec) {
+	if attribute.Nesting == "single" {
+		g.printf("Attributes: tfsdk.SingleNestedAttributes(")
+		g.attributes(attribute.Attributes)
+		g.printf("),\n")
+		return
+	}
+
+	mode := goName(attribute.Nesting)
+
+	g.printf("Attributes: tfsdk.%sNestedAttributes(", mode)
+	g.attributes(attribute.Attributes)
+	g.printf(", tfsdk.%sNestedAttributesOptions{", mode)
+
+	var options []string
+	if attribute.MinItems != 0 {
+		options = append(options, fmt.Sprintf("MinItems: %d", attribute.MinItems))
+	}
+	if attribute.MaxItems != 0 {
+		options = append(options, fmt.Sprintf("MaxItems: %d", attribute.MaxItems))
+	}
+
+	g.printf("%s}),\n", strings.Join(options, ", "))
+}
+
+// attrType returns the Go expression for the attr.Type of `typ`.
+func (g *generator) attrType(typ TypeSpec) string {
+	switch typ.Type {
+	case "string":
+		return "types.StringType"
+	case "number":
+		return "types.NumberType"
+	case "int64":
+		return "types.Int64Type"
+	case "float64":
+		return "types.Float64Type"
+	case "bool":
+		return "types.BoolType"
+	case "list":
+		return "types.ListType{ElemType: " + g.attrType(*typ.ElementType) + "}"
+	case "set":
+		return "types.SetType{ElemType: " + g.attrType(*typ.ElementType) + "}"
+	case "map":
+		return "types.MapType{ElemType: " + g.attrType(*typ.ElementType) + "}"
+	case "object":
+		g.usesAttr = true
+
+		var b strings.Builder
+
+		b.WriteString("types.ObjectType{AttrTypes: map[string]attr.Type{")
+		for _, name := range sortedKeys(typ.AttributeTypes) {
+			fmt.Fprintf(&b, "\n%s: %s,", strconv.Quote(name), g.attrType(typ.AttributeTypes[name]))
+		}
+		b.WriteString("\n}}")
+
+		return b.String()
+	}
+
+	// Validate rejects every other type.
+	panic(fmt.Sprintf("unexpected type %q", typ.Type))
+}
+
+// valueType returns the Go type of the model field for an attribute of type
+// `typ`.
+func valueType(typ TypeSpec) string {
+	switch typ.Type {
+	case "string":
+		return "types.String"
+	case "number":
+		return "types.Number"
+	case "int64":
+		return "types.Int64"
+	case "float64":
+		return "types.Float64"
+	case "bool":
+		return "types.Bool"
+	case "list":
+		return "types.List"
+	case "set":
+		return "types.Set"
+	case "map":
+		return "types.Map"
+	case "object":
+		return "types.Object"
+	}
+
+	// Validate rejects every other type.
+	panic(fmt.Sprintf("unexpected type %q", typ.Type))
+}
+
+// models generates the model struct named `prefix`Model for `attributes`,
+// which are nested at `path` in `description`, and the model structs for
+// their nested attributes, which are named after the path to them, like
+// exampleThingResourceRuleTargetModel for the attributes nested in
+// rule.target.
+func (g *generator) models(prefix, description, path string, attributes map[string]AttributeSpec) {
+	var nested []string
+
+	if path == "" {
+		g.printf("// %sModel is the model of %s, for use with State.Get, Plan.Set, and so on.\n", prefix, description)
+	} else {
+		g.printf("// %sModel is the model of the %s attribute of %s.\n", prefix, path, description)
+	}
+	g.printf("type %sModel struct {\n", prefix)
+
+	for _, name := range sortedKeys(attributes) {
+		attribute := attributes[name]
+
+		var fieldType string
+
+		switch attribute.Nesting {
+		case "":
+			fieldType = valueType(attribute.TypeSpec)
+		case "single":
+			fieldType = "*" + prefix + goName(name) + "Model"
+		case "list", "set":
+			fieldType = "[]" + prefix + goName(name) + "Model"
+		case "map":
+			fieldType = "map[string]" + prefix + goName(name) + "Model"
+		}
+
+		if attribute.Nesting != "" {
+			nested = append(nested, name)
+		}
+
+		g.printf("%s %s `tfsdk:%s`\n", goName(name), fieldType, strconv.Quote(name))
+	}
+
+	g.printf("}\n\n")
+
+	for _, name := range nested {
+		nestedPath := name
+		if path != "" {
+			nestedPath = path + "." + name
+		}
+
+		g.models(prefix+goName(name), description, nestedPath, attributes[name].Attributes)
+	}
+}
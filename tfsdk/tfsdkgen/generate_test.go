@@ -0,0 +1,142 @@
+package tfsdkgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestGenerateExample checks that internal/example/schemas_gen.go, which is
+// compiled and tested in its own package, is what Generate generates for
+// testdata/example.json.
+func TestGenerateExample(t *testing.T) {
+	t.Parallel()
+
+	in, err := os.ReadFile(filepath.Join("testdata", "example.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join("internal", "example", "schemas_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := ParseSpec(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := Generate(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(string(got), string(expected)); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got), run go generate in internal/example: %s", diff)
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		in          string
+		expectedErr string
+	}
+
+	tests := map[string]testCase{
+		"valid": {
+			in: `{"package": "provider", "resources": {"example_thing": {"attributes": {"id": {"type": "string", "computed": true}}}}}`,
+		},
+		"unknown-field": {
+			in:          `{"package": "provider", "resource": {}}`,
+			expectedErr: `error decoding spec: json: unknown field "resource"`,
+		},
+		"package": {
+			in:          `{"package": "my-provider"}`,
+			expectedErr: `invalid package name "my-provider"`,
+		},
+		"type-name": {
+			in:          `{"package": "provider", "resources": {"Example": {"attributes": {"id": {"type": "string", "computed": true}}}}}`,
+			expectedErr: `resource Example: invalid type name, must only use lowercase letters, underscores, and numbers, and must start with a letter`,
+		},
+		"no-attributes": {
+			in:          `{"package": "provider", "data_sources": {"example_thing": {}}}`,
+			expectedErr: `data source example_thing: must have at least one attribute`,
+		},
+		"no-mode": {
+			in:          `{"package": "provider", "provider": {"attributes": {"token": {"type": "string"}}}}`,
+			expectedErr: `provider: token: must be required, optional, or computed`,
+		},
+		"required-and-computed": {
+			in:          `{"package": "provider", "provider": {"attributes": {"token": {"type": "string", "required": true, "computed": true}}}}`,
+			expectedErr: `provider: token: can't be both required and optional or computed`,
+		},
+		"invalid-type": {
+			in:          `{"package": "provider", "provider": {"attributes": {"port": {"type": "integer", "optional": true}}}}`,
+			expectedErr: `provider: port: invalid type "integer"`,
+		},
+		"missing-element-type": {
+			in:          `{"package": "provider", "provider": {"attributes": {"tags": {"type": "list", "optional": true}}}}`,
+			expectedErr: `provider: tags: list needs an element type`,
+		},
+		"type-and-nesting": {
+			in:          `{"package": "provider", "provider": {"attributes": {"rule": {"type": "string", "nesting": "list", "optional": true, "attributes": {"port": {"type": "number", "required": true}}}}}}`,
+			expectedErr: `provider: rule: can't have both a type and a nesting mode`,
+		},
+		"nested-error": {
+			in:          `{"package": "provider", "provider": {"attributes": {"rule": {"nesting": "list", "optional": true, "attributes": {"port": {"type": "number"}}}}}}`,
+			expectedErr: `provider: rule: port: must be required, optional, or computed`,
+		},
+		"single-items": {
+			in:          `{"package": "provider", "provider": {"attributes": {"rule": {"nesting": "single", "max_items": 1, "optional": true, "attributes": {"port": {"type": "number", "required": true}}}}}}`,
+			expectedErr: `provider: rule: min_items and max_items can't be used with single nesting`,
+		},
+		"field-name-collision": {
+			in:          `{"package": "provider", "provider": {"attributes": {"a_b": {"type": "string", "optional": true}, "a__b": {"type": "string", "optional": true}}}}`,
+			expectedErr: `provider: a_b: has the same Go field name as a__b, AB`,
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseSpec([]byte(tc.in))
+
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+
+			if diff := cmp.Diff(gotErr, tc.expectedErr); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidExpression(t *testing.T) {
+	t.Parallel()
+
+	spec := Spec{
+		Package: "provider",
+		Provider: &SchemaSpec{
+			Attributes: map[string]AttributeSpec{
+				"token": {
+					TypeSpec:   TypeSpec{Type: "string"},
+					Optional:   true,
+					Validators: []string{"oneOf("},
+				},
+			},
+		},
+	}
+
+	if _, err := Generate(spec); err == nil {
+		t.Error("expected an error generating code with an invalid validator expression")
+	}
+}
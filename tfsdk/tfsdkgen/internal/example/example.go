@@ -0,0 +1,36 @@
+// Package example is generated from ../../testdata/example.json, to check
+// that generated code compiles, and works with State.Get and Plan.Set.
+package example
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+//go:generate go run ../../../../cmd/tfsdkgen -o schemas_gen.go ../../testdata/example.json
+
+type provider struct{}
+
+type thingDataSourceType struct{}
+
+// oneOfValidator validates that a string is one of a set of values. It's only
+// used for its descriptions.
+type oneOfValidator struct {
+	values []string
+}
+
+func oneOf(values ...string) tfsdk.AttributeValidator {
+	return oneOfValidator{values: values}
+}
+
+func (v oneOfValidator) Description(context.Context) string {
+	return "value must be one of the allowed values"
+}
+
+func (v oneOfValidator) MarkdownDescription(context.Context) string {
+	return "value must be one of the allowed values"
+}
+
+func (v oneOfValidator) Validate(context.Context, tfsdk.ValidateAttributeRequest, *tfsdk.ValidateAttributeResponse) {
+}
@@ -0,0 +1,106 @@
+package example
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk/tfsdkgen"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestModelsMatchSchemas(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		getSchema func(context.Context) (tfsdk.Schema, diag.Diagnostics)
+		model     interface{}
+	}
+
+	tests := map[string]testCase{
+		"provider": {
+			getSchema: (&provider{}).GetSchema,
+			model:     providerModel{},
+		},
+		"resource": {
+			getSchema: exampleThingResourceType{}.GetSchema,
+			model:     exampleThingResourceModel{},
+		},
+		"data-source": {
+			getSchema: thingDataSourceType{}.GetSchema,
+			model:     exampleThingDataSourceModel{},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			schema, diags := tc.getSchema(context.Background())
+			if diags.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", diags)
+			}
+
+			if err := tfsdkgen.CheckModel(context.Background(), schema, tc.model); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestResourceModelRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	schema, diags := exampleThingResourceType{}.GetSchema(ctx)
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", diags)
+	}
+
+	model := exampleThingResourceModel{
+		Id:   types.String{Value: "thing-1"},
+		Name: types.String{Value: "thing"},
+		Rule: []exampleThingResourceRuleModel{
+			{
+				Port:     types.Number{Value: big.NewFloat(443)},
+				Protocol: types.String{Value: "tcp"},
+				Source: &exampleThingResourceRuleSourceModel{
+					CidrBlocks: types.Set{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "10.0.0.0/8"}}},
+				},
+			},
+		},
+		Size: types.Int64{Null: true},
+		Tags: types.Map{ElemType: types.StringType, Null: true},
+		Target: types.Object{
+			AttrTypes: map[string]attr.Type{
+				"host":  types.StringType,
+				"ports": types.ListType{ElemType: types.NumberType},
+			},
+			Null: true,
+		},
+	}
+
+	state := tfsdk.State{Schema: schema}
+
+	diags = state.Set(ctx, model)
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics setting state: %v", diags)
+	}
+
+	var got exampleThingResourceModel
+
+	diags = state.Get(ctx, &got)
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics getting state: %v", diags)
+	}
+
+	if diff := cmp.Diff(got, model); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+	}
+}
@@ -0,0 +1,152 @@
+// Code generated by tfsdkgen. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// GetSchema returns the schema of the provider.
+func (p *provider) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"endpoint": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "The API endpoint.",
+			},
+			"token": {
+				Type:      types.StringType,
+				Optional:  true,
+				Sensitive: true,
+			},
+		},
+	}, nil
+}
+
+// providerModel is the model of the provider, for use with State.Get, Plan.Set, and so on.
+type providerModel struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	Token    types.String `tfsdk:"token"`
+}
+
+// exampleThingResourceType is the resource type of example_thing.
+type exampleThingResourceType struct{}
+
+// GetSchema returns the schema of the example_thing resource.
+func (r exampleThingResourceType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Version:     1,
+		Description: "Manages a thing.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "The name of the thing.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"rule": {
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"port": {
+						Type:     types.NumberType,
+						Required: true,
+					},
+					"protocol": {
+						Type:     types.StringType,
+						Optional: true,
+						Validators: []tfsdk.AttributeValidator{
+							oneOf("tcp", "udp"),
+						},
+					},
+					"source": {
+						Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+							"cidr_blocks": {
+								Type:     types.SetType{ElemType: types.StringType},
+								Required: true,
+							},
+						}),
+						Optional: true,
+					},
+				}, tfsdk.ListNestedAttributesOptions{MaxItems: 10}),
+				Optional: true,
+			},
+			"size": {
+				Type:     types.Int64Type,
+				Optional: true,
+				Computed: true,
+			},
+			"tags": {
+				Type:     types.MapType{ElemType: types.StringType},
+				Optional: true,
+			},
+			"target": {
+				Type: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"host":  types.StringType,
+					"ports": types.ListType{ElemType: types.NumberType},
+				}},
+				Optional: true,
+			},
+		},
+	}, nil
+}
+
+// exampleThingResourceModel is the model of the example_thing resource, for use with State.Get, Plan.Set, and so on.
+type exampleThingResourceModel struct {
+	Id     types.String                    `tfsdk:"id"`
+	Name   types.String                    `tfsdk:"name"`
+	Rule   []exampleThingResourceRuleModel `tfsdk:"rule"`
+	Size   types.Int64                     `tfsdk:"size"`
+	Tags   types.Map                       `tfsdk:"tags"`
+	Target types.Object                    `tfsdk:"target"`
+}
+
+// exampleThingResourceRuleModel is the model of the rule attribute of the example_thing resource.
+type exampleThingResourceRuleModel struct {
+	Port     types.Number                         `tfsdk:"port"`
+	Protocol types.String                         `tfsdk:"protocol"`
+	Source   *exampleThingResourceRuleSourceModel `tfsdk:"source"`
+}
+
+// exampleThingResourceRuleSourceModel is the model of the rule.source attribute of the example_thing resource.
+type exampleThingResourceRuleSourceModel struct {
+	CidrBlocks types.Set `tfsdk:"cidr_blocks"`
+}
+
+// GetSchema returns the schema of the example_thing data source.
+func (d thingDataSourceType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"enabled": {
+				Type:     types.BoolType,
+				Computed: true,
+			},
+			"id": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"ratio": {
+				Type:               types.Float64Type,
+				Computed:           true,
+				DeprecationMessage: "Use size instead.",
+			},
+		},
+	}, nil
+}
+
+// exampleThingDataSourceModel is the model of the example_thing data source, for use with State.Get, Plan.Set, and so on.
+type exampleThingDataSourceModel struct {
+	Enabled types.Bool    `tfsdk:"enabled"`
+	Id      types.String  `tfsdk:"id"`
+	Ratio   types.Float64 `tfsdk:"ratio"`
+}
@@ -0,0 +1,349 @@
+// Package tfsdkgen generates tfsdk schemas, and model structs to use with
+// them, from a declarative spec of a provider's resources and data sources.
+//
+// Specs are JSON, and are usually generated from an API description, like
+// an OpenAPI document, or written by hand:
+//
+//	{
+//	  "package": "provider",
+//	  "resources": {
+//	    "example_thing": {
+//	      "description": "Manages a thing.",
+//	      "attributes": {
+//	        "id": {"type": "string", "computed": true},
+//	        "name": {"type": "string", "required": true, "plan_modifiers": ["tfsdk.RequiresReplace()"]},
+//	        "tags": {"type": "list", "element_type": {"type": "string"}, "optional": true}
+//	      }
+//	    }
+//	  }
+//	}
+//
+// Spec has JSON struct tags, so specs in other formats can be decoded into a
+// Spec with a package that honors them and passed to Generate.
+//
+// The cmd/tfsdkgen command generates code from a JSON or YAML spec file, for
+// use with go generate.
+package tfsdkgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Spec describes the schemas of a provider.
+type Spec struct {
+	// Package is the name of the package the code is generated in.
+	Package string `json:"package"`
+
+	// Imports are the import paths of packages used by the validator and
+	// plan modifier expressions in the spec.
+	Imports []string `json:"imports,omitempty"`
+
+	// Provider is the provider's schema, if it should be generated.
+	Provider *SchemaSpec `json:"provider,omitempty"`
+
+	// Resources are the schemas of the provider's resources, keyed by
+	// type name.
+	Resources map[string]SchemaSpec `json:"resources,omitempty"`
+
+	// DataSources are the schemas of the provider's data sources, keyed
+	// by type name.
+	DataSources map[string]SchemaSpec `json:"data_sources,omitempty"`
+}
+
+// SchemaSpec describes a schema.
+type SchemaSpec struct {
+	// GoType is the name of the Go type the GetSchema method is generated
+	// for. If it's set, the type must be declared outside the generated
+	// code. If it's empty, an empty struct type is generated for resources
+	// and data sources, named like exampleThingResourceType for the
+	// resource example_thing, and the provider's type is assumed to be
+	// named provider.
+	GoType string `json:"go_type,omitempty"`
+
+	Version             int64  `json:"version,omitempty"`
+	Description         string `json:"description,omitempty"`
+	MarkdownDescription string `json:"markdown_description,omitempty"`
+	DeprecationMessage  string `json:"deprecation_message,omitempty"`
+
+	// Attributes are the attributes of the schema, keyed by name.
+	Attributes map[string]AttributeSpec `json:"attributes"`
+}
+
+// TypeSpec describes the type of an attribute.
+type TypeSpec struct {
+	// Type is one of string, number, int64, float64, bool, list, set,
+	// map, or object.
+	Type string `json:"type,omitempty"`
+
+	// ElementType is the type of the elements of lists, sets, and maps.
+	ElementType *TypeSpec `json:"element_type,omitempty"`
+
+	// AttributeTypes are the types of the attributes of objects.
+	AttributeTypes map[string]TypeSpec `json:"attribute_types,omitempty"`
+}
+
+// AttributeSpec describes an attribute. Attributes either have a Type, or
+// Nesting and Attributes.
+type AttributeSpec struct {
+	TypeSpec
+
+	// Nesting is one of single, list, set, or map, for attributes with
+	// nested attributes.
+	Nesting string `json:"nesting,omitempty"`
+
+	// Attributes are the nested attributes, keyed by name.
+	Attributes map[string]AttributeSpec `json:"attributes,omitempty"`
+
+	// MinItems and MaxItems limit the number of elements of list, set,
+	// and map nested attributes.
+	MinItems int `json:"min_items,omitempty"`
+	MaxItems int `json:"max_items,omitempty"`
+
+	Required            bool   `json:"required,omitempty"`
+	Optional            bool   `json:"optional,omitempty"`
+	Computed            bool   `json:"computed,omitempty"`
+	Sensitive           bool   `json:"sensitive,omitempty"`
+	Description         string `json:"description,omitempty"`
+	MarkdownDescription string `json:"markdown_description,omitempty"`
+	DeprecationMessage  string `json:"deprecation_message,omitempty"`
+
+	// Validators are Go expressions for the attribute's validators, like
+	// `stringvalidator.LengthAtLeast(1)`. Their packages must be listed in
+	// Spec.Imports.
+	Validators []string `json:"validators,omitempty"`
+
+	// PlanModifiers are Go expressions for the attribute's plan
+	// modifiers, like `tfsdk.RequiresReplace()`.
+	PlanModifiers []string `json:"plan_modifiers,omitempty"`
+}
+
+// ParseSpec decodes and validates a JSON spec. Unknown fields are errors, so
+// misspelled settings aren't silently ignored.
+func ParseSpec(in []byte) (Spec, error) {
+	var spec Spec
+
+	decoder := json.NewDecoder(bytes.NewReader(in))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&spec); err != nil {
+		return Spec{}, fmt.Errorf("error decoding spec: %w", err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return Spec{}, err
+	}
+
+	return spec, nil
+}
+
+var (
+	namePattern       = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Validate returns an error describing the first problem with the spec that
+// would stop Generate from generating valid code.
+func (s Spec) Validate() error {
+	if !identifierPattern.MatchString(s.Package) {
+		return fmt.Errorf("invalid package name %q", s.Package)
+	}
+
+	if s.Provider != nil {
+		if err := s.Provider.validate(); err != nil {
+			return fmt.Errorf("provider: %w", err)
+		}
+	}
+
+	for _, typeName := range sortedKeys(s.Resources) {
+		if err := validateTypeName(typeName); err != nil {
+			return fmt.Errorf("resource %s: %w", typeName, err)
+		}
+
+		if err := s.Resources[typeName].validate(); err != nil {
+			return fmt.Errorf("resource %s: %w", typeName, err)
+		}
+	}
+
+	for _, typeName := range sortedKeys(s.DataSources) {
+		if err := validateTypeName(typeName); err != nil {
+			return fmt.Errorf("data source %s: %w", typeName, err)
+		}
+
+		if err := s.DataSources[typeName].validate(); err != nil {
+			return fmt.Errorf("data source %s: %w", typeName, err)
+		}
+	}
+
+	return nil
+}
+
+func validateTypeName(typeName string) error {
+	if !namePattern.MatchString(typeName) {
+		return fmt.Errorf("invalid type name, must only use lowercase letters, underscores, and numbers, and must start with a letter")
+	}
+
+	return nil
+}
+
+func (s SchemaSpec) validate() error {
+	if s.GoType != "" && !identifierPattern.MatchString(s.GoType) {
+		return fmt.Errorf("invalid go_type %q", s.GoType)
+	}
+
+	if len(s.Attributes) == 0 {
+		return fmt.Errorf("must have at least one attribute")
+	}
+
+	return validateAttributes(s.Attributes)
+}
+
+func validateAttributes(attributes map[string]AttributeSpec) error {
+	fieldNames := map[string]string{}
+
+	for _, name := range sortedKeys(attributes) {
+		if err := attributes[name].validate(name); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		fieldName := goName(name)
+		if other, ok := fieldNames[fieldName]; ok {
+			return fmt.Errorf("%s: has the same Go field name as %s, %s", name, other, fieldName)
+		}
+		fieldNames[fieldName] = name
+	}
+
+	return nil
+}
+
+func (a AttributeSpec) validate(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid attribute name, must only use lowercase letters, underscores, and numbers, and must start with a letter")
+	}
+
+	switch {
+	case a.Required && (a.Optional || a.Computed):
+		return fmt.Errorf("can't be both required and optional or computed")
+	case !a.Required && !a.Optional && !a.Computed:
+		return fmt.Errorf("must be required, optional, or computed")
+	}
+
+	if a.Nesting == "" {
+		if len(a.Attributes) > 0 {
+			return fmt.Errorf("attributes need a nesting mode")
+		}
+
+		return a.TypeSpec.validate()
+	}
+
+	if a.Type != "" {
+		return fmt.Errorf("can't have both a type and a nesting mode")
+	}
+
+	switch a.Nesting {
+	case "single":
+		if a.MinItems != 0 || a.MaxItems != 0 {
+			return fmt.Errorf("min_items and max_items can't be used with single nesting")
+		}
+	case "list", "set", "map":
+	default:
+		return fmt.Errorf("invalid nesting mode %q, must be one of single, list, set, or map", a.Nesting)
+	}
+
+	if len(a.Attributes) == 0 {
+		return fmt.Errorf("nested attributes must have at least one attribute")
+	}
+
+	return validateAttributes(a.Attributes)
+}
+
+func (t TypeSpec) validate() error {
+	switch t.Type {
+	case "string", "number", "int64", "float64", "bool":
+		if t.ElementType != nil || len(t.AttributeTypes) > 0 {
+			return fmt.Errorf("%s can't have element or attribute types", t.Type)
+		}
+	case "list", "set", "map":
+		if t.ElementType == nil {
+			return fmt.Errorf("%s needs an element type", t.Type)
+		}
+
+		if err := t.ElementType.validate(); err != nil {
+			return fmt.Errorf("element type: %w", err)
+		}
+	case "object":
+		if len(t.AttributeTypes) == 0 {
+			return fmt.Errorf("object needs attribute types")
+		}
+
+		for _, name := range sortedKeys(t.AttributeTypes) {
+			if !namePattern.MatchString(name) {
+				return fmt.Errorf("invalid attribute name %q", name)
+			}
+
+			if err := t.AttributeTypes[name].validate(); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	case "":
+		return fmt.Errorf("needs a type, or a nesting mode and attributes")
+	default:
+		return fmt.Errorf("invalid type %q", t.Type)
+	}
+
+	return nil
+}
+
+// goName converts a snake_case name to a CamelCase Go name, like
+// ip_address to IpAddress.
+func goName(name string) string {
+	var b strings.Builder
+
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return b.String()
+}
+
+// lowerGoName converts a snake_case name to a camelCase Go name, like
+// example_thing to exampleThing.
+func lowerGoName(name string) string {
+	upper := goName(name)
+	if upper == "" {
+		return upper
+	}
+
+	return strings.ToLower(upper[:1]) + upper[1:]
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+
+	switch m := m.(type) {
+	case map[string]SchemaSpec:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]AttributeSpec:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]TypeSpec:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
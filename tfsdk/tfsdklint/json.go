@@ -0,0 +1,97 @@
+package tfsdklint
+
+import "github.com/hashicorp/terraform-plugin-framework/internal/schemajson"
+
+// LintJSON lints the schemas of the provider at `address`, such as
+// registry.terraform.io/hashicorp/random, in `in`, which is in the format
+// output by tfsdk.ProviderSchemaJSON and `terraform providers schema -json`.
+// If `address` is empty, `in` must contain a single provider, which is
+// linted whatever its address.
+//
+// The JSON doesn't include validators, plan modifiers, or deprecation
+// messages, so the computed_with_validators, requires_replace_computed, and
+// deprecated_without_replacement rules can't find anything. Attributes in
+// blocks, which providers built with tfsdk don't have, are linted like
+// nested attributes.
+func LintJSON(in []byte, address string, opts Options) ([]Finding, error) {
+	provider, err := schemajson.ProviderFromJSON(in, address)
+	if err != nil {
+		return nil, err
+	}
+
+	l := newLinter(opts)
+
+	if provider.Provider != nil {
+		l.schemaJSON(SchemaTypeProvider, "", provider.Provider)
+	}
+
+	if provider.ProviderMeta != nil {
+		l.schemaJSON(SchemaTypeProviderMeta, "", provider.ProviderMeta)
+	}
+
+	for typeName, schema := range provider.ResourceSchemas {
+		l.schemaJSON(SchemaTypeResource, typeName, schema)
+	}
+
+	for typeName, schema := range provider.DataSourceSchemas {
+		l.schemaJSON(SchemaTypeDataSource, typeName, schema)
+	}
+
+	return l.sortedFindings(), nil
+}
+
+func (l *linter) schemaJSON(schemaType SchemaType, typeName string, schema *schemajson.Schema) {
+	loc := location{schemaType: schemaType, typeName: typeName}
+
+	if typeName != "" {
+		l.checkName(loc, typeName)
+	}
+
+	block := schema.Block
+	if block == nil {
+		block = &schemajson.Block{}
+	}
+
+	l.checkSchema(loc, schemaInfo{description: block.Description})
+	l.blockJSON(loc, block)
+}
+
+func (l *linter) blockJSON(loc location, block *schemajson.Block) {
+	l.attributesJSON(loc, block.Attributes)
+
+	for name, blockType := range block.BlockTypes {
+		blockLoc := loc.attribute(name)
+
+		l.checkName(blockLoc, name)
+
+		if blockType != nil && blockType.Block != nil {
+			if blockType.Block.Description == "" {
+				l.add(RuleMissingDescription, blockLoc, "block has no description")
+			}
+
+			l.blockJSON(blockLoc, blockType.Block)
+		}
+	}
+}
+
+func (l *linter) attributesJSON(loc location, attributes map[string]*schemajson.Attribute) {
+	for name, attribute := range attributes {
+		if attribute == nil {
+			continue
+		}
+
+		attrLoc := loc.attribute(name)
+
+		l.checkName(attrLoc, name)
+		l.checkAttribute(attrLoc, name, attributeInfo{
+			optional:    attribute.Optional,
+			computed:    attribute.Computed,
+			sensitive:   attribute.Sensitive,
+			description: attribute.Description,
+		})
+
+		if attribute.NestedType != nil {
+			l.attributesJSON(attrLoc, attribute.NestedType.Attributes)
+		}
+	}
+}
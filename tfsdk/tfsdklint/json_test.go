@@ -0,0 +1,151 @@
+package tfsdklint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestLintJSON(t *testing.T) {
+	t.Parallel()
+
+	const schemas = `{
+		"format_version": "1.0",
+		"provider_schemas": {
+			"registry.terraform.io/example/example": {
+				"provider": {
+					"block": {
+						"description": "The example provider.",
+						"attributes": {
+							"api_key": {"type": "string", "optional": true, "sensitive": true, "description": "The API key."}
+						}
+					}
+				},
+				"resource_schemas": {
+					"example_thing": {
+						"block": {
+							"description": "Manages a thing.",
+							"attributes": {
+								"password": {"type": "string", "required": true},
+								"rule": {
+									"nested_type": {
+										"nesting_mode": "list",
+										"attributes": {
+											"Port": {"type": "number", "required": true, "description": "The port."}
+										}
+									},
+									"optional": true,
+									"description": "The rules."
+								}
+							},
+							"block_types": {
+								"timeouts": {
+									"nesting_mode": "single",
+									"block": {
+										"attributes": {
+											"create": {"type": "string", "optional": true, "description": "How long to wait."}
+										}
+									}
+								}
+							}
+						}
+					}
+				},
+				"data_source_schemas": {
+					"example_thing": {
+						"block": {}
+					}
+				}
+			},
+			"registry.terraform.io/example/other": {}
+		}
+	}`
+
+	type testCase struct {
+		address     string
+		expected    []Finding
+		expectedErr string
+	}
+
+	tests := map[string]testCase{
+		"provider": {
+			address: "registry.terraform.io/example/example",
+			expected: []Finding{
+				{Rule: RuleMissingDescription, SchemaType: SchemaTypeResource, TypeName: "example_thing", Path: "password", Message: "attribute has no description"},
+				{Rule: RuleSecretNotSensitive, SchemaType: SchemaTypeResource, TypeName: "example_thing", Path: "password", Message: "attribute looks like a secret, but isn't sensitive"},
+				{Rule: RuleNameNotSnakeCase, SchemaType: SchemaTypeResource, TypeName: "example_thing", Path: "rule.Port", Message: `name "Port" isn't snake_case`},
+				{Rule: RuleMissingDescription, SchemaType: SchemaTypeResource, TypeName: "example_thing", Path: "timeouts", Message: "block has no description"},
+				{Rule: RuleMissingDescription, SchemaType: SchemaTypeDataSource, TypeName: "example_thing", Message: "schema has no description"},
+			},
+		},
+		"empty-provider": {
+			address: "registry.terraform.io/example/other",
+		},
+		"missing-provider": {
+			address:     "registry.terraform.io/example/missing",
+			expectedErr: `no schema for provider "registry.terraform.io/example/missing"`,
+		},
+		"ambiguous-provider": {
+			expectedErr: "expected schemas for 1 provider, got 2; specify the provider address",
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LintJSON([]byte(schemas), tc.address, Options{})
+
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+
+			if diff := cmp.Diff(gotErr, tc.expectedErr); diff != "" {
+				t.Errorf("Unexpected error diff (+wanted, -got): %s", diff)
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+// TestLintJSONProvider checks that linting a provider's exported schema JSON
+// finds the same problems as linting the provider, except for the rules that
+// need more than the JSON has.
+func TestLintJSONProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := Options{
+		Disabled: []RuleCode{
+			RuleComputedWithValidators,
+			RuleRequiresReplaceComputed,
+			RuleDeprecatedWithoutReplacement,
+		},
+	}
+
+	expected, err := LintProvider(ctx, testProvider{}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	schemas, diags := tfsdk.ProviderSchemaJSON(ctx, testProvider{}, "provider")
+	if err := diags.ToError(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := LintJSON(schemas, "", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+	}
+}
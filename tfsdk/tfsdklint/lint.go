@@ -0,0 +1,476 @@
+// Package tfsdklint checks provider schemas for common mistakes that aren't
+// errors, but make providers harder to use, like attributes without
+// descriptions, or secrets that aren't marked as sensitive.
+//
+// Every finding is identified by the code of the rule that found it, and
+// rules can be disabled, or individual findings ignored, with Options.
+// Linting a provider from a test reports its findings as test failures:
+//
+//	func TestProviderLint(t *testing.T) {
+//		findings, err := tfsdklint.LintProvider(context.Background(), New(), tfsdklint.Options{})
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//
+//		for _, finding := range findings {
+//			t.Error(finding)
+//		}
+//	}
+//
+// Schemas exported as JSON, by tfsdk.ProviderSchemaJSON or `terraform
+// providers schema -json`, can be linted with LintJSON or the cmd/tfsdklint
+// command, although the JSON doesn't include everything some rules check.
+package tfsdklint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// SchemaType is the kind of schema a finding is in.
+type SchemaType string
+
+const (
+	SchemaTypeProvider     SchemaType = "provider"
+	SchemaTypeProviderMeta SchemaType = "provider_meta"
+	SchemaTypeResource     SchemaType = "resource"
+	SchemaTypeDataSource   SchemaType = "data_source"
+)
+
+// RuleCode identifies a rule.
+type RuleCode string
+
+const (
+	// RuleMissingDescription finds schemas and attributes without a
+	// description or markdown description, which documentation and
+	// editors show to practitioners.
+	RuleMissingDescription RuleCode = "missing_description"
+
+	// RuleSecretNotSensitive finds attributes whose names look like they
+	// hold secrets, like password or api_key, that aren't sensitive, so
+	// their values are shown in plans and logs.
+	RuleSecretNotSensitive RuleCode = "secret_not_sensitive"
+
+	// RuleComputedWithValidators finds computed attributes that aren't
+	// optional and have validators. Validators only check configuration
+	// values, which computed attributes don't have, so they never fail.
+	// It isn't checked for JSON schemas, which don't include validators.
+	RuleComputedWithValidators RuleCode = "computed_with_validators"
+
+	// RuleNameNotSnakeCase finds resource, data source, and attribute
+	// names that aren't snake_case, like double_underscore__names, or
+	// trailing_underscores_.
+	RuleNameNotSnakeCase RuleCode = "name_not_snake_case"
+
+	// RuleRequiresReplaceComputed finds computed attributes that aren't
+	// optional with a RequiresReplace or RequiresReplaceIf plan
+	// modifier. Practitioners can't change their values, so the resource
+	// is replaced whenever the provider's computed value changes, which
+	// is rarely intended. It isn't checked for JSON schemas, which don't
+	// include plan modifiers.
+	RuleRequiresReplaceComputed RuleCode = "requires_replace_computed"
+
+	// RuleDeprecatedWithoutReplacement finds schemas and attributes whose
+	// deprecation messages don't tell practitioners what to do instead:
+	// messages that don't mention using, replacing, migrating to, or
+	// removing something. It isn't checked for JSON schemas, which don't
+	// include deprecation messages.
+	RuleDeprecatedWithoutReplacement RuleCode = "deprecated_without_replacement"
+)
+
+// Rule describes a rule.
+type Rule struct {
+	Code        RuleCode
+	Description string
+}
+
+// Rules are all the rules, in the order they're documented.
+var Rules = []Rule{
+	{RuleMissingDescription, "schemas and attributes need a description"},
+	{RuleSecretNotSensitive, "attributes that look like secrets need to be sensitive"},
+	{RuleComputedWithValidators, "computed attributes that aren't optional never run their validators"},
+	{RuleNameNotSnakeCase, "resource, data source, and attribute names need to be snake_case"},
+	{RuleRequiresReplaceComputed, "computed attributes that aren't optional shouldn't require replacement"},
+	{RuleDeprecatedWithoutReplacement, "deprecation messages need to say what to use instead"},
+}
+
+// DefaultSecretNames are the words that mark attributes as secrets for the
+// secret_not_sensitive rule, if Options.SecretNames is empty.
+var DefaultSecretNames = []string{
+	"access_key",
+	"api_key",
+	"passphrase",
+	"password",
+	"private_key",
+	"secret",
+	"token",
+}
+
+// Options configure the linter. The zero value checks every rule.
+type Options struct {
+	// Disabled are the codes of the rules that aren't checked.
+	Disabled []RuleCode
+
+	// SecretNames are the words that mark attributes as secrets for the
+	// secret_not_sensitive rule. Attribute names match when they contain
+	// them as whole words, separated by underscores, so password matches
+	// admin_password but not passwordless. It defaults to
+	// DefaultSecretNames.
+	SecretNames []string
+
+	// Ignore drops the findings it returns true for, such as exceptions
+	// to rules that are otherwise followed.
+	Ignore func(Finding) bool
+}
+
+// Finding is a problem found by a rule.
+type Finding struct {
+	// Rule is the code of the rule that found the problem.
+	Rule RuleCode `json:"rule"`
+
+	// SchemaType is the kind of schema the problem is in. It's empty for
+	// schemas linted with LintSchema.
+	SchemaType SchemaType `json:"schema_type,omitempty"`
+
+	// TypeName is the type name of the resource or data source the
+	// problem is in. It's empty for the provider and provider_meta
+	// schemas, and for schemas linted with LintSchema.
+	TypeName string `json:"type_name,omitempty"`
+
+	// Path is the path to the attribute the problem is in, with the names
+	// of nested attributes separated by periods, such as `rule.port`. It's
+	// empty for problems with the schema itself.
+	Path string `json:"path,omitempty"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// String returns the finding in the form
+// `resource test_thing: rule.port: message (rule_code)`.
+func (f Finding) String() string {
+	var parts []string
+
+	if f.SchemaType != "" {
+		schema := strings.ReplaceAll(string(f.SchemaType), "_", " ")
+
+		if f.TypeName != "" {
+			schema += " " + f.TypeName
+		}
+
+		parts = append(parts, schema)
+	}
+
+	if f.Path != "" {
+		parts = append(parts, f.Path)
+	}
+
+	parts = append(parts, fmt.Sprintf("%s (%s)", f.Message, f.Rule))
+
+	return strings.Join(parts, ": ")
+}
+
+// LintProvider lints the schemas of `provider`, including its resources and
+// data sources, returning the findings ordered by schema type, type name,
+// path, and rule.
+func LintProvider(ctx context.Context, provider tfsdk.Provider, opts Options) ([]Finding, error) {
+	l := newLinter(opts)
+
+	schema, diags := provider.GetSchema(ctx)
+	if err := diags.ToError(); err != nil {
+		return nil, fmt.Errorf("error getting provider schema: %w", err)
+	}
+
+	l.schema(SchemaTypeProvider, "", schema)
+
+	if pm, ok := provider.(tfsdk.ProviderWithProviderMeta); ok {
+		schema, diags := pm.GetMetaSchema(ctx)
+		if err := diags.ToError(); err != nil {
+			return nil, fmt.Errorf("error getting provider_meta schema: %w", err)
+		}
+
+		l.schema(SchemaTypeProviderMeta, "", schema)
+	}
+
+	resourceTypes, diags := provider.GetResources(ctx)
+	if err := diags.ToError(); err != nil {
+		return nil, fmt.Errorf("error getting resources: %w", err)
+	}
+
+	for typeName, resourceType := range resourceTypes {
+		schema, diags := resourceType.GetSchema(ctx)
+		if err := diags.ToError(); err != nil {
+			return nil, fmt.Errorf("error getting schema of resource %q: %w", typeName, err)
+		}
+
+		l.schema(SchemaTypeResource, typeName, schema)
+	}
+
+	dataSourceTypes, diags := provider.GetDataSources(ctx)
+	if err := diags.ToError(); err != nil {
+		return nil, fmt.Errorf("error getting data sources: %w", err)
+	}
+
+	for typeName, dataSourceType := range dataSourceTypes {
+		schema, diags := dataSourceType.GetSchema(ctx)
+		if err := diags.ToError(); err != nil {
+			return nil, fmt.Errorf("error getting schema of data source %q: %w", typeName, err)
+		}
+
+		l.schema(SchemaTypeDataSource, typeName, schema)
+	}
+
+	return l.sortedFindings(), nil
+}
+
+// LintSchema lints `schema`, returning the findings ordered by path and
+// rule.
+func LintSchema(ctx context.Context, schema tfsdk.Schema, opts Options) []Finding {
+	l := newLinter(opts)
+
+	l.schema("", "", schema)
+
+	return l.sortedFindings()
+}
+
+// linter collects the findings of the enabled rules.
+type linter struct {
+	disabled    map[RuleCode]bool
+	secretNames []string
+	ignore      func(Finding) bool
+
+	findings []Finding
+}
+
+func newLinter(opts Options) *linter {
+	l := &linter{
+		disabled:    map[RuleCode]bool{},
+		secretNames: opts.SecretNames,
+		ignore:      opts.Ignore,
+	}
+
+	for _, code := range opts.Disabled {
+		l.disabled[code] = true
+	}
+
+	if len(l.secretNames) == 0 {
+		l.secretNames = DefaultSecretNames
+	}
+
+	return l
+}
+
+// location identifies the schema and attribute being linted.
+type location struct {
+	schemaType SchemaType
+	typeName   string
+	path       string
+}
+
+func (loc location) attribute(name string) location {
+	if loc.path != "" {
+		name = loc.path + "." + name
+	}
+
+	loc.path = name
+
+	return loc
+}
+
+func (l *linter) add(rule RuleCode, loc location, format string, a ...interface{}) {
+	if l.disabled[rule] {
+		return
+	}
+
+	finding := Finding{
+		Rule:       rule,
+		SchemaType: loc.schemaType,
+		TypeName:   loc.typeName,
+		Path:       loc.path,
+		Message:    fmt.Sprintf(format, a...),
+	}
+
+	if l.ignore != nil && l.ignore(finding) {
+		return
+	}
+
+	l.findings = append(l.findings, finding)
+}
+
+var schemaTypeOrder = map[SchemaType]int{
+	SchemaTypeProvider:     1,
+	SchemaTypeProviderMeta: 2,
+	SchemaTypeResource:     3,
+	SchemaTypeDataSource:   4,
+}
+
+func (l *linter) sortedFindings() []Finding {
+	sort.Slice(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+
+		switch {
+		case a.SchemaType != b.SchemaType:
+			return schemaTypeOrder[a.SchemaType] < schemaTypeOrder[b.SchemaType]
+		case a.TypeName != b.TypeName:
+			return a.TypeName < b.TypeName
+		case a.Path != b.Path:
+			return a.Path < b.Path
+		default:
+			return a.Rule < b.Rule
+		}
+	})
+
+	return l.findings
+}
+
+// schemaInfo is what the rules check about a schema, from a tfsdk.Schema or
+// from JSON.
+type schemaInfo struct {
+	description string
+
+	// deprecationMessage is the deprecation message. It's unknown for
+	// JSON schemas, which only say whether schemas are deprecated.
+	deprecationMessage string
+}
+
+// attributeInfo is what the rules check about an attribute, from a
+// tfsdk.Attribute or from JSON.
+type attributeInfo struct {
+	optional  bool
+	computed  bool
+	sensitive bool
+
+	description        string
+	deprecationMessage string
+
+	// validators and requiresReplace are the number of validators, and
+	// whether the attribute has a RequiresReplace or RequiresReplaceIf
+	// plan modifier. They're unknown for JSON schemas.
+	validators      int
+	requiresReplace bool
+}
+
+func (l *linter) schema(schemaType SchemaType, typeName string, schema tfsdk.Schema) {
+	loc := location{schemaType: schemaType, typeName: typeName}
+
+	if typeName != "" {
+		l.checkName(loc, typeName)
+	}
+
+	l.checkSchema(loc, schemaInfo{
+		description:        firstNonEmpty(schema.Description, schema.MarkdownDescription),
+		deprecationMessage: schema.DeprecationMessage,
+	})
+
+	l.attributes(loc, schema.Attributes)
+}
+
+func (l *linter) attributes(loc location, attributes map[string]tfsdk.Attribute) {
+	for name, attribute := range attributes {
+		attrLoc := loc.attribute(name)
+
+		l.checkName(attrLoc, name)
+		l.checkAttribute(attrLoc, name, attributeInfo{
+			optional:           attribute.Optional,
+			computed:           attribute.Computed,
+			sensitive:          attribute.Sensitive,
+			description:        firstNonEmpty(attribute.Description, attribute.MarkdownDescription),
+			deprecationMessage: attribute.DeprecationMessage,
+			validators:         len(attribute.Validators),
+			requiresReplace:    hasRequiresReplace(attribute.PlanModifiers),
+		})
+
+		if attribute.Attributes != nil {
+			l.attributes(attrLoc, attribute.Attributes.GetAttributes())
+		}
+	}
+}
+
+func hasRequiresReplace(modifiers tfsdk.AttributePlanModifiers) bool {
+	for _, modifier := range modifiers {
+		switch modifier.(type) {
+		case tfsdk.RequiresReplaceModifier, *tfsdk.RequiresReplaceModifier,
+			tfsdk.RequiresReplaceIfModifier, *tfsdk.RequiresReplaceIfModifier:
+			return true
+		}
+	}
+
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+var snakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+func (l *linter) checkName(loc location, name string) {
+	if !snakeCasePattern.MatchString(name) {
+		l.add(RuleNameNotSnakeCase, loc, "name %q isn't snake_case", name)
+	}
+}
+
+func (l *linter) checkSchema(loc location, schema schemaInfo) {
+	if schema.description == "" {
+		l.add(RuleMissingDescription, loc, "schema has no description")
+	}
+
+	if schema.deprecationMessage != "" && !hasReplacementText(schema.deprecationMessage) {
+		l.add(RuleDeprecatedWithoutReplacement, loc, "deprecation message doesn't say what to use instead")
+	}
+}
+
+func (l *linter) checkAttribute(loc location, name string, attribute attributeInfo) {
+	if attribute.description == "" {
+		l.add(RuleMissingDescription, loc, "attribute has no description")
+	}
+
+	if !attribute.sensitive && l.isSecretName(name) {
+		l.add(RuleSecretNotSensitive, loc, "attribute looks like a secret, but isn't sensitive")
+	}
+
+	computedOnly := attribute.computed && !attribute.optional
+
+	if computedOnly && attribute.validators > 0 {
+		l.add(RuleComputedWithValidators, loc, "computed attribute has validators, which only run on configuration values")
+	}
+
+	if computedOnly && attribute.requiresReplace {
+		l.add(RuleRequiresReplaceComputed, loc, "computed attribute requires replacement, so the resource is replaced whenever its value changes")
+	}
+
+	if attribute.deprecationMessage != "" && !hasReplacementText(attribute.deprecationMessage) {
+		l.add(RuleDeprecatedWithoutReplacement, loc, "deprecation message doesn't say what to use instead")
+	}
+}
+
+func (l *linter) isSecretName(name string) bool {
+	words := "_" + name + "_"
+
+	for _, secret := range l.secretNames {
+		if strings.Contains(words, "_"+secret+"_") {
+			return true
+		}
+	}
+
+	return false
+}
+
+var replacementPattern = regexp.MustCompile(`(?i)\b(use|using|instead|replace|replaced|migrate|remove)\b`)
+
+// hasReplacementText returns true if the deprecation message `message`
+// looks like it tells practitioners what to do instead.
+func hasReplacementText(message string) bool {
+	return replacementPattern.MatchString(message)
+}
@@ -0,0 +1,363 @@
+package tfsdklint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type testValidator struct {
+	tfsdk.AttributeValidator
+}
+
+func TestLintSchema(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		schema   tfsdk.Schema
+		opts     Options
+		expected []Finding
+	}
+
+	tests := map[string]testCase{
+		"clean": {
+			schema: tfsdk.Schema{
+				Description: "Manages a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"id": {
+						Type:        types.StringType,
+						Computed:    true,
+						Description: "The ID of the thing.",
+					},
+					"api_key": {
+						Type:                types.StringType,
+						Required:            true,
+						Sensitive:           true,
+						MarkdownDescription: "The `key` to use.",
+						PlanModifiers:       tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+					},
+					"size": {
+						Type:               types.NumberType,
+						Optional:           true,
+						Computed:           true,
+						Description:        "The size of the thing.",
+						DeprecationMessage: "Use capacity instead.",
+						Validators:         []tfsdk.AttributeValidator{testValidator{}},
+					},
+				},
+			},
+		},
+		"missing-description": {
+			schema: tfsdk.Schema{
+				Attributes: map[string]tfsdk.Attribute{
+					"rule": {
+						Optional: true,
+						Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+							"port": {
+								Type:     types.NumberType,
+								Required: true,
+							},
+						}, tfsdk.ListNestedAttributesOptions{}),
+					},
+				},
+			},
+			expected: []Finding{
+				{Rule: RuleMissingDescription, Message: "schema has no description"},
+				{Rule: RuleMissingDescription, Path: "rule", Message: "attribute has no description"},
+				{Rule: RuleMissingDescription, Path: "rule.port", Message: "attribute has no description"},
+			},
+		},
+		"secret-not-sensitive": {
+			schema: tfsdk.Schema{
+				Description: "Manages a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"admin_password": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "The password.",
+					},
+					"passwordless": {
+						Type:        types.BoolType,
+						Optional:    true,
+						Description: "Whether to log in without a password.",
+					},
+					"client_secret_id": {
+						Type:        types.StringType,
+						Optional:    true,
+						Description: "The ID of the secret.",
+					},
+				},
+			},
+			expected: []Finding{
+				{Rule: RuleSecretNotSensitive, Path: "admin_password", Message: "attribute looks like a secret, but isn't sensitive"},
+				{Rule: RuleSecretNotSensitive, Path: "client_secret_id", Message: "attribute looks like a secret, but isn't sensitive"},
+			},
+		},
+		"secret-names": {
+			schema: tfsdk.Schema{
+				Description: "Manages a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"admin_password": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "The password.",
+					},
+					"license": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "The license.",
+					},
+				},
+			},
+			opts: Options{
+				SecretNames: []string{"license"},
+			},
+			expected: []Finding{
+				{Rule: RuleSecretNotSensitive, Path: "license", Message: "attribute looks like a secret, but isn't sensitive"},
+			},
+		},
+		"computed": {
+			schema: tfsdk.Schema{
+				Description: "Manages a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"id": {
+						Type:          types.StringType,
+						Computed:      true,
+						Description:   "The ID of the thing.",
+						Validators:    []tfsdk.AttributeValidator{testValidator{}},
+						PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplaceIf(nil, "", "")},
+					},
+				},
+			},
+			expected: []Finding{
+				{Rule: RuleComputedWithValidators, Path: "id", Message: "computed attribute has validators, which only run on configuration values"},
+				{Rule: RuleRequiresReplaceComputed, Path: "id", Message: "computed attribute requires replacement, so the resource is replaced whenever its value changes"},
+			},
+		},
+		"name-not-snake-case": {
+			schema: tfsdk.Schema{
+				Description: "Manages a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"ip__address": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "The IP address.",
+					},
+					"port_": {
+						Type:        types.NumberType,
+						Required:    true,
+						Description: "The port.",
+					},
+					"ipv6_address": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "The IPv6 address.",
+					},
+				},
+			},
+			expected: []Finding{
+				{Rule: RuleNameNotSnakeCase, Path: "ip__address", Message: `name "ip__address" isn't snake_case`},
+				{Rule: RuleNameNotSnakeCase, Path: "port_", Message: `name "port_" isn't snake_case`},
+			},
+		},
+		"deprecated-without-replacement": {
+			schema: tfsdk.Schema{
+				Description:        "Manages a thing.",
+				DeprecationMessage: "This resource is deprecated.",
+				Attributes: map[string]tfsdk.Attribute{
+					"size": {
+						Type:               types.NumberType,
+						Optional:           true,
+						Description:        "The size of the thing.",
+						DeprecationMessage: "This attribute will be removed in the next major version.",
+					},
+					"color": {
+						Type:               types.StringType,
+						Optional:           true,
+						Description:        "The color of the thing.",
+						DeprecationMessage: "Colors have been replaced by themes.",
+					},
+				},
+			},
+			expected: []Finding{
+				{Rule: RuleDeprecatedWithoutReplacement, Message: "deprecation message doesn't say what to use instead"},
+				{Rule: RuleDeprecatedWithoutReplacement, Path: "size", Message: "deprecation message doesn't say what to use instead"},
+			},
+		},
+		"disabled": {
+			schema: tfsdk.Schema{
+				Attributes: map[string]tfsdk.Attribute{
+					"password": {
+						Type:     types.StringType,
+						Required: true,
+					},
+				},
+			},
+			opts: Options{
+				Disabled: []RuleCode{RuleMissingDescription},
+			},
+			expected: []Finding{
+				{Rule: RuleSecretNotSensitive, Path: "password", Message: "attribute looks like a secret, but isn't sensitive"},
+			},
+		},
+		"ignore": {
+			schema: tfsdk.Schema{
+				Description: "Manages a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"password": {
+						Type:     types.StringType,
+						Required: true,
+					},
+				},
+			},
+			opts: Options{
+				Ignore: func(f Finding) bool {
+					return f.Path == "password" && f.Rule == RuleMissingDescription
+				},
+			},
+			expected: []Finding{
+				{Rule: RuleSecretNotSensitive, Path: "password", Message: "attribute looks like a secret, but isn't sensitive"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := LintSchema(context.Background(), tc.schema, tc.opts)
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
+
+type testProvider struct{}
+
+func (p testProvider) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"token": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "The API token.",
+			},
+		},
+	}, nil
+}
+
+func (p testProvider) Configure(context.Context, tfsdk.ConfigureProviderRequest, *tfsdk.ConfigureProviderResponse) {
+}
+
+func (p testProvider) GetResources(context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
+	return map[string]tfsdk.ResourceType{
+		"test_thing": testType{
+			schema: tfsdk.Schema{
+				Description: "Manages a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"id": {
+						Type:          types.StringType,
+						Computed:      true,
+						Description:   "The ID of the thing.",
+						PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (p testProvider) GetDataSources(context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
+	return map[string]tfsdk.DataSourceType{
+		"test_Thing": testType{
+			schema: tfsdk.Schema{
+				Description: "Reads a thing.",
+				Attributes: map[string]tfsdk.Attribute{
+					"id": {
+						Type:     types.StringType,
+						Required: true,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+type testType struct {
+	schema tfsdk.Schema
+}
+
+func (r testType) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return r.schema, nil
+}
+
+func (r testType) NewResource(context.Context, tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return nil, nil
+}
+
+func (r testType) NewDataSource(context.Context, tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return nil, nil
+}
+
+func TestLintProvider(t *testing.T) {
+	t.Parallel()
+
+	got, err := LintProvider(context.Background(), testProvider{}, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []Finding{
+		{Rule: RuleMissingDescription, SchemaType: SchemaTypeProvider, Message: "schema has no description"},
+		{Rule: RuleSecretNotSensitive, SchemaType: SchemaTypeProvider, Path: "token", Message: "attribute looks like a secret, but isn't sensitive"},
+		{Rule: RuleRequiresReplaceComputed, SchemaType: SchemaTypeResource, TypeName: "test_thing", Path: "id", Message: "computed attribute requires replacement, so the resource is replaced whenever its value changes"},
+		{Rule: RuleNameNotSnakeCase, SchemaType: SchemaTypeDataSource, TypeName: "test_Thing", Message: `name "test_Thing" isn't snake_case`},
+		{Rule: RuleMissingDescription, SchemaType: SchemaTypeDataSource, TypeName: "test_Thing", Path: "id", Message: "attribute has no description"},
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		finding  Finding
+		expected string
+	}
+
+	tests := map[string]testCase{
+		"attribute": {
+			finding:  Finding{Rule: RuleMissingDescription, SchemaType: SchemaTypeDataSource, TypeName: "test_thing", Path: "rule.port", Message: "attribute has no description"},
+			expected: "data source test_thing: rule.port: attribute has no description (missing_description)",
+		},
+		"schema": {
+			finding:  Finding{Rule: RuleMissingDescription, SchemaType: SchemaTypeProviderMeta, Message: "schema has no description"},
+			expected: "provider meta: schema has no description (missing_description)",
+		},
+		"lint-schema": {
+			finding:  Finding{Rule: RuleNameNotSnakeCase, Path: "port_", Message: `name "port_" isn't snake_case`},
+			expected: `port_: name "port_" isn't snake_case (name_not_snake_case)`,
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.finding.String(), tc.expected); diff != "" {
+				t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+			}
+		})
+	}
+}
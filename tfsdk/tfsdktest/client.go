@@ -0,0 +1,493 @@
+package tfsdktest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/internal/consistency"
+	"github.com/hashicorp/terraform-plugin-framework/internal/pathstring"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Client is a fake Terraform client for unit testing providers. It makes
+// single RPCs to a provider's protocol server, encoding configurations and
+// states written as plain Go data, as described by ResourceTest, using the
+// schemas returned by GetProviderSchema, and decoding the responses.
+//
+// Unlike Terraform, Client doesn't call any RPCs it isn't asked to, so
+// providers that need to be configured should have ConfigureProvider
+// called first.
+//
+// Errors are only returned when requests or responses can't be encoded or
+// decoded; the diagnostics returned by the provider are returned
+// separately.
+type Client struct {
+	server  tfprotov6.ProviderServer
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// NewClient returns a Client for `server`, returning an error if its
+// schemas can't be retrieved.
+func NewClient(ctx context.Context, server tfprotov6.ProviderServer) (*Client, error) {
+	resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting provider schema: %w", err)
+	}
+
+	if diags := Diagnostics(resp.Diagnostics); diags.HasError() {
+		return nil, fmt.Errorf("error getting provider schema:\n\n%s", diags.Errors())
+	}
+
+	return &Client{
+		server:  server,
+		schemas: resp,
+	}, nil
+}
+
+// NewProviderClient returns a Client for an in-process protocol server for
+// `provider`, failing the test if its schemas can't be retrieved.
+func NewProviderClient(t *testing.T, provider tfsdk.Provider) *Client {
+	t.Helper()
+
+	return NewProviderClientWithOpts(t, provider, tfsdk.ServeOpts{})
+}
+
+// NewProviderClientWithOpts is like NewProviderClient, but the protocol
+// server is configured by `opts`, for example to enable StrictConsistency or
+// CollapseDiagnostics. opts.Name is ignored.
+func NewProviderClientWithOpts(t *testing.T, provider tfsdk.Provider, opts tfsdk.ServeOpts) *Client {
+	t.Helper()
+
+	client, err := NewClient(context.Background(), tfsdk.NewProtocol6ServerWithOpts(provider, opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// Value is a value decoded from a provider's response.
+type Value struct {
+	// Raw is the value, as a tftypes.Value.
+	Raw tftypes.Value
+}
+
+// IsNull returns true if the value is null, such as the state of a
+// resource that no longer exists.
+func (v Value) IsNull() bool {
+	return v.Raw.Type() == nil || v.Raw.IsNull()
+}
+
+// Map returns the value as plain Go data, in the form described by
+// ResourceTest, with unknown values represented as tftypes.UnknownValue. It
+// returns nil if the value is null.
+func (v Value) Map() (map[string]interface{}, error) {
+	if v.Raw.Type() == nil {
+		return nil, nil
+	}
+
+	return fromValue(v.Raw)
+}
+
+// Get returns the value of the attribute at `path`, written like
+// `rule[0].port` or `tags["env"]`, as plain Go data. Set elements are written
+// with their JSON type and value, like `zones[value("string", "a")]`.
+func (v Value) Get(path string) (interface{}, error) {
+	attrPath, err := pathstring.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	attrValue, ok := consistency.ValueAtPath(v.Raw, attrPath)
+	if !ok {
+		return nil, fmt.Errorf("no value at %s", path)
+	}
+
+	return interfaceValue(attrValue)
+}
+
+// PlanResult is the result of planning a change to a resource.
+type PlanResult struct {
+	// PlannedState is the planned state of the resource.
+	PlannedState Value
+
+	// PlannedPrivate is the private data for the planned state.
+	PlannedPrivate []byte
+
+	// RequiresReplace are the paths of the attributes that require the
+	// resource to be replaced.
+	RequiresReplace []*tftypes.AttributePath
+
+	// Diagnostics are the diagnostics returned by the provider.
+	Diagnostics Diagnostics
+}
+
+// RequiresReplacePaths returns the paths in RequiresReplace, written like
+// `rule[0].port` or `tags["env"]`.
+func (p PlanResult) RequiresReplacePaths() []string {
+	paths := make([]string, 0, len(p.RequiresReplace))
+
+	for _, path := range p.RequiresReplace {
+		paths = append(paths, pathstring.String(path))
+	}
+
+	return paths
+}
+
+// RequiresReplaceAt returns true if the attribute at `path`, written like
+// `rule[0].port`, is in RequiresReplace.
+func (p PlanResult) RequiresReplaceAt(path string) bool {
+	for _, replacePath := range p.RequiresReplacePaths() {
+		if replacePath == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImportedResource is a resource returned by importing.
+type ImportedResource struct {
+	// TypeName is the type of the resource.
+	TypeName string
+
+	// State is the imported state of the resource.
+	State Value
+
+	// Private is the private data for the resource.
+	Private []byte
+}
+
+// ValidateProviderConfig calls ValidateProviderConfig with `config`.
+func (c *Client) ValidateProviderConfig(ctx context.Context, config map[string]interface{}) (Diagnostics, error) {
+	configDV, err := c.encode(c.providerBlock(), config, "provider configuration")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{
+		Config: configDV,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Diagnostics, nil
+}
+
+// ConfigureProvider calls ConfigureProvider with `config`.
+func (c *Client) ConfigureProvider(ctx context.Context, config map[string]interface{}) (Diagnostics, error) {
+	configDV, err := c.encode(c.providerBlock(), config, "provider configuration")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: terraformVersion,
+		Config:           configDV,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Diagnostics, nil
+}
+
+// ValidateResourceConfig calls ValidateResourceConfig for the resource type
+// `typeName` with `config`.
+func (c *Client) ValidateResourceConfig(ctx context.Context, typeName string, config map[string]interface{}) (Diagnostics, error) {
+	block, err := c.resourceBlock(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	configDV, err := c.encode(block, config, "configuration")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   configDV,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Diagnostics, nil
+}
+
+// ValidateDataSourceConfig calls ValidateDataResourceConfig for the data
+// source type `typeName` with `config`.
+func (c *Client) ValidateDataSourceConfig(ctx context.Context, typeName string, config map[string]interface{}) (Diagnostics, error) {
+	block, err := c.dataSourceBlock(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	configDV, err := c.encode(block, config, "configuration")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.server.ValidateDataResourceConfig(ctx, &tfprotov6.ValidateDataResourceConfigRequest{
+		TypeName: typeName,
+		Config:   configDV,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Diagnostics, nil
+}
+
+// ReadDataSource calls ReadDataSource for the data source type `typeName`
+// with `config`, returning the state it read.
+func (c *Client) ReadDataSource(ctx context.Context, typeName string, config map[string]interface{}) (Value, Diagnostics, error) {
+	block, err := c.dataSourceBlock(typeName)
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	configDV, err := c.encode(block, config, "configuration")
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	resp, err := c.server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   configDV,
+	})
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	state, err := decode(block, resp.State, "state")
+
+	return state, resp.Diagnostics, err
+}
+
+// ReadResource calls ReadResource for the resource type `typeName` with the
+// current `state`, returning the new state.
+func (c *Client) ReadResource(ctx context.Context, typeName string, state map[string]interface{}) (Value, Diagnostics, error) {
+	block, err := c.resourceBlock(typeName)
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	stateDV, err := c.encode(block, nullIfNil(state), "state")
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	resp, err := c.server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: stateDV,
+	})
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	newState, err := decode(block, resp.NewState, "state")
+
+	return newState, resp.Diagnostics, err
+}
+
+// PlanResourceChange calls PlanResourceChange for the resource type
+// `typeName` to change it from the `prior` state to `config`. The proposed
+// new state is built the way Terraform builds it. A nil `prior` plans
+// creating the resource, and a nil `config` plans destroying it.
+func (c *Client) PlanResourceChange(ctx context.Context, typeName string, prior, config map[string]interface{}) (PlanResult, error) {
+	block, err := c.resourceBlock(typeName)
+	if err != nil {
+		return PlanResult{}, err
+	}
+
+	typ := blockType(block)
+
+	priorVal, err := toValue(typ, nullIfNil(prior), tftypes.NewAttributePath())
+	if err != nil {
+		return PlanResult{}, fmt.Errorf("invalid prior state: %w", err)
+	}
+	configVal, err := toValue(typ, nullIfNil(config), tftypes.NewAttributePath())
+	if err != nil {
+		return PlanResult{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	priorDV, err := tfprotov6.NewDynamicValue(typ, priorVal)
+	if err != nil {
+		return PlanResult{}, err
+	}
+	proposedDV, err := tfprotov6.NewDynamicValue(typ, proposedNewState(block, priorVal, configVal))
+	if err != nil {
+		return PlanResult{}, err
+	}
+	configDV, err := tfprotov6.NewDynamicValue(typ, configVal)
+	if err != nil {
+		return PlanResult{}, err
+	}
+
+	resp, err := c.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &priorDV,
+		ProposedNewState: &proposedDV,
+		Config:           &configDV,
+	})
+	if err != nil {
+		return PlanResult{}, err
+	}
+
+	planned, err := decode(block, resp.PlannedState, "planned state")
+
+	return PlanResult{
+		PlannedState:    planned,
+		PlannedPrivate:  resp.PlannedPrivate,
+		RequiresReplace: resp.RequiresReplace,
+		Diagnostics:     resp.Diagnostics,
+	}, err
+}
+
+// ApplyResourceChange calls ApplyResourceChange for the resource type
+// `typeName` to apply `plan`, the result of PlanResourceChange with the
+// same `prior` state and `config`, returning the new state.
+func (c *Client) ApplyResourceChange(ctx context.Context, typeName string, prior map[string]interface{}, plan PlanResult, config map[string]interface{}) (Value, Diagnostics, error) {
+	block, err := c.resourceBlock(typeName)
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	priorDV, err := c.encode(block, nullIfNil(prior), "prior state")
+	if err != nil {
+		return Value{}, nil, err
+	}
+	plannedDV, err := c.encode(block, plan.PlannedState.Raw, "planned state")
+	if err != nil {
+		return Value{}, nil, err
+	}
+	configDV, err := c.encode(block, nullIfNil(config), "configuration")
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	resp, err := c.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     priorDV,
+		PlannedState:   plannedDV,
+		Config:         configDV,
+		PlannedPrivate: plan.PlannedPrivate,
+	})
+	if err != nil {
+		return Value{}, nil, err
+	}
+
+	newState, err := decode(block, resp.NewState, "new state")
+
+	return newState, resp.Diagnostics, err
+}
+
+// ImportResourceState calls ImportResourceState for the resource type
+// `typeName` with `id`, returning the imported resources.
+func (c *Client) ImportResourceState(ctx context.Context, typeName string, id string) ([]ImportedResource, Diagnostics, error) {
+	resp, err := c.server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imported := make([]ImportedResource, 0, len(resp.ImportedResources))
+	for _, resource := range resp.ImportedResources {
+		block, err := c.resourceBlock(resource.TypeName)
+		if err != nil {
+			return nil, resp.Diagnostics, err
+		}
+
+		state, err := decode(block, resource.State, "imported state")
+		if err != nil {
+			return nil, resp.Diagnostics, err
+		}
+
+		imported = append(imported, ImportedResource{
+			TypeName: resource.TypeName,
+			State:    state,
+			Private:  resource.Private,
+		})
+	}
+
+	return imported, resp.Diagnostics, nil
+}
+
+func (c *Client) providerBlock() *tfprotov6.SchemaBlock {
+	if c.schemas.Provider == nil {
+		return nil
+	}
+
+	return c.schemas.Provider.Block
+}
+
+func (c *Client) resourceBlock(typeName string) (*tfprotov6.SchemaBlock, error) {
+	schema, ok := c.schemas.ResourceSchemas[typeName]
+	if !ok {
+		return nil, fmt.Errorf("provider has no resource type %q", typeName)
+	}
+
+	return schema.Block, nil
+}
+
+func (c *Client) dataSourceBlock(typeName string) (*tfprotov6.SchemaBlock, error) {
+	schema, ok := c.schemas.DataSourceSchemas[typeName]
+	if !ok {
+		return nil, fmt.Errorf("provider has no data source type %q", typeName)
+	}
+
+	return schema.Block, nil
+}
+
+// encode converts `in`, the `kind` of value being sent, to a DynamicValue
+// for `block`.
+func (c *Client) encode(block *tfprotov6.SchemaBlock, in interface{}, kind string) (*tfprotov6.DynamicValue, error) {
+	typ := blockType(block)
+
+	val, err := toValue(typ, in, tftypes.NewAttributePath())
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", kind, err)
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(typ, val)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s: %w", kind, err)
+	}
+
+	return &dv, nil
+}
+
+// decode converts `dv`, the `kind` of value received, to a Value of
+// `block`'s type. A nil `dv` is a null value.
+func decode(block *tfprotov6.SchemaBlock, dv *tfprotov6.DynamicValue, kind string) (Value, error) {
+	typ := blockType(block)
+
+	if dv == nil {
+		return Value{Raw: tftypes.NewValue(typ, nil)}, nil
+	}
+
+	val, err := dv.Unmarshal(typ)
+	if err != nil {
+		return Value{}, fmt.Errorf("error decoding %s: %w", kind, err)
+	}
+
+	return Value{Raw: val}, nil
+}
+
+// nullIfNil returns an untyped nil for nil maps, so they're converted to
+// null values rather than objects with null attributes.
+func nullIfNil(m map[string]interface{}) interface{} {
+	if m == nil {
+		return nil
+	}
+
+	return m
+}
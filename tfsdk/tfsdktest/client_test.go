@@ -0,0 +1,279 @@
+package tfsdktest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	client := NewProviderClient(t, &testProvider{})
+
+	diags, err := client.ConfigureProvider(context.Background(), map[string]interface{}{
+		"region": "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %s", diags)
+	}
+
+	return client
+}
+
+func TestClientValidateResourceConfig(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+
+	diags, err := client.ValidateResourceConfig(context.Background(), "test_thing", map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"a", "", "b", ""},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(diags.AtPath("tags[1]").Summaries(), []string{"Empty Tag"}); diff != "" {
+		t.Errorf("unexpected difference in tags[1] diagnostics (+got, -expected): %s", diff)
+	}
+	if diff := cmp.Diff(diags.AtPath("tags[3]").Summaries(), []string{"Empty Tag"}); diff != "" {
+		t.Errorf("unexpected difference in tags[3] diagnostics (+got, -expected): %s", diff)
+	}
+	if got := len(diags.Errors()); got != 2 {
+		t.Errorf("expected 2 errors, got %d: %s", got, diags)
+	}
+
+	_, err = client.ValidateResourceConfig(context.Background(), "test_other", nil)
+	if err == nil || err.Error() != `provider has no resource type "test_other"` {
+		t.Errorf("expected an error for an unknown resource type, got %v", err)
+	}
+}
+
+func TestClientResourceChange(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	config := map[string]interface{}{
+		"name": "foo",
+	}
+
+	plan, err := client.PlanResourceChange(ctx, "test_thing", nil, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if plan.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %s", plan.Diagnostics)
+	}
+
+	planned, err := plan.PlannedState.Map()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedPlanned := map[string]interface{}{
+		"id":   tftypes.UnknownValue,
+		"name": "foo",
+		"size": tftypes.UnknownValue,
+		"tags": nil,
+	}
+	if diff := cmp.Diff(planned, expectedPlanned); diff != "" {
+		t.Errorf("unexpected difference in planned state (+got, -expected): %s", diff)
+	}
+
+	state, diags, err := client.ApplyResourceChange(ctx, "test_thing", nil, plan, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %s", diags)
+	}
+
+	id, err := state.Get("id")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != "thing-foo" {
+		t.Errorf("expected id %q, got %v", "thing-foo", id)
+	}
+
+	prior, err := state.Map()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	plan, err = client.PlanResourceChange(ctx, "test_thing", prior, map[string]interface{}{
+		"name": "bar",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(plan.RequiresReplacePaths(), []string{"name"}); diff != "" {
+		t.Errorf("unexpected difference in requires replace (+got, -expected): %s", diff)
+	}
+	if !plan.RequiresReplaceAt("name") {
+		t.Errorf("expected name to require replacement")
+	}
+}
+
+func TestClientReadDataSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	_, diags, err := client.ReadDataSource(ctx, "test_thing", map[string]interface{}{
+		"name": "foo",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(diags.AtPath("name").Summaries(), []string{"Thing Not Found"}); diff != "" {
+		t.Errorf("unexpected difference in diagnostics (+got, -expected): %s", diff)
+	}
+
+	plan, err := client.PlanResourceChange(ctx, "test_thing", nil, map[string]interface{}{
+		"name": "foo",
+		"size": 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := client.ApplyResourceChange(ctx, "test_thing", nil, plan, map[string]interface{}{
+		"name": "foo",
+		"size": 3,
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	state, diags, err := client.ReadDataSource(ctx, "test_thing", map[string]interface{}{
+		"name": "foo",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %s", diags)
+	}
+
+	size, err := state.Get("size")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if size != int64(3) {
+		t.Errorf("expected size 3, got %v", size)
+	}
+}
+
+func TestClientImportResourceState(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+
+	imported, diags, err := client.ImportResourceState(context.Background(), "test_thing", "thing-foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %s", diags)
+	}
+
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+
+	state, err := imported[0].State.Map()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"id":   "thing-foo",
+		"name": nil,
+		"size": nil,
+		"tags": nil,
+	}
+	if diff := cmp.Diff(state, expected); diff != "" {
+		t.Errorf("unexpected difference in imported state (+got, -expected): %s", diff)
+	}
+}
+
+func TestValueGet(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"ports": tftypes.List{ElementType: tftypes.Number},
+		"tags":  tftypes.Map{AttributeType: tftypes.String},
+		"zones": tftypes.Set{ElementType: tftypes.String},
+	}}
+	val := Value{Raw: tftypes.NewValue(objType, map[string]tftypes.Value{
+		"ports": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+			tftypes.NewValue(tftypes.Number, big.NewFloat(1.5)),
+		}),
+		"tags": tftypes.NewValue(tftypes.Map{AttributeType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "prod"),
+		}),
+		"zones": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "a"),
+		}),
+	})}
+
+	tag, err := val.Get(`tags["env"]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tag != "prod" {
+		t.Errorf("expected %q, got %v", "prod", tag)
+	}
+
+	port, err := val.Get("ports[0]")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if port.(*big.Float).Cmp(big.NewFloat(1.5)) != 0 {
+		t.Errorf("expected 1.5, got %v", port)
+	}
+
+	zone, err := val.Get(`zones[value("string", "a")]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if zone != "a" {
+		t.Errorf("expected %q, got %v", "a", zone)
+	}
+
+	if _, err := val.Get("ports[1]"); err == nil {
+		t.Errorf("expected an error for a missing element")
+	}
+
+	if _, err := val.Get(`zones[value("string", "b")]`); err == nil {
+		t.Errorf("expected an error for a missing set element")
+	}
+}
+
+func TestNewProviderClientWithOpts(t *testing.T) {
+	t.Parallel()
+
+	client := NewProviderClientWithOpts(t, &testProvider{}, tfsdk.ServeOpts{
+		CollapseDiagnostics: true,
+	})
+
+	diags, err := client.ValidateResourceConfig(context.Background(), "test_thing", map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"a", "", "b", ""},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := len(diags.Errors()); got != 1 {
+		t.Errorf("expected 1 collapsed error, got %d: %s", got, diags)
+	}
+}
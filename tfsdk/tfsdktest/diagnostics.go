@@ -0,0 +1,107 @@
+package tfsdktest
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/internal/pathstring"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Diagnostics are the diagnostics returned by a provider.
+type Diagnostics []*tfprotov6.Diagnostic
+
+// HasError returns true if any of the diagnostics are errors.
+func (d Diagnostics) HasError() bool {
+	return len(d.Errors()) > 0
+}
+
+// Errors returns the diagnostics that are errors.
+func (d Diagnostics) Errors() Diagnostics {
+	return d.withSeverity(tfprotov6.DiagnosticSeverityError)
+}
+
+// Warnings returns the diagnostics that are warnings.
+func (d Diagnostics) Warnings() Diagnostics {
+	return d.withSeverity(tfprotov6.DiagnosticSeverityWarning)
+}
+
+func (d Diagnostics) withSeverity(severity tfprotov6.DiagnosticSeverity) Diagnostics {
+	var result Diagnostics
+
+	for _, diag := range d {
+		if diag.Severity == severity {
+			result = append(result, diag)
+		}
+	}
+
+	return result
+}
+
+// AtPath returns the diagnostics for the attribute at `path`, written like
+// `rule[0].port` or `tags["env"]`. An empty path returns the diagnostics
+// that aren't for an attribute.
+func (d Diagnostics) AtPath(path string) Diagnostics {
+	var result Diagnostics
+
+	for _, diag := range d {
+		if diagnosticPath(diag) == path {
+			result = append(result, diag)
+		}
+	}
+
+	return result
+}
+
+// Summaries returns the summaries of the diagnostics, in order.
+func (d Diagnostics) Summaries() []string {
+	summaries := make([]string, 0, len(d))
+
+	for _, diag := range d {
+		summaries = append(summaries, diag.Summary)
+	}
+
+	return summaries
+}
+
+// String returns the diagnostics, one per line, in the form
+// `Error: path: summary: detail`.
+func (d Diagnostics) String() string {
+	lines := make([]string, 0, len(d))
+
+	for _, diag := range d {
+		lines = append(lines, formatDiagnostic(diag))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func diagnosticPath(d *tfprotov6.Diagnostic) string {
+	if d.Attribute == nil || len(d.Attribute.Steps()) == 0 {
+		return ""
+	}
+
+	return pathstring.String(d.Attribute)
+}
+
+func formatDiagnostic(d *tfprotov6.Diagnostic) string {
+	var b strings.Builder
+
+	switch d.Severity {
+	case tfprotov6.DiagnosticSeverityError:
+		b.WriteString("Error: ")
+	case tfprotov6.DiagnosticSeverityWarning:
+		b.WriteString("Warning: ")
+	}
+
+	if path := diagnosticPath(d); path != "" {
+		b.WriteString(path + ": ")
+	}
+
+	b.WriteString(d.Summary)
+
+	if d.Detail != "" {
+		b.WriteString(": " + d.Detail)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package tfsdktest
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// proposedNewState returns the proposed new state Terraform would send in a
+// PlanResourceChangeRequest for `config`, given the `prior` state of the
+// resource: the configuration, with computed attributes that aren't
+// configured keeping their prior values.
+func proposedNewState(block *tfprotov6.SchemaBlock, prior, config tftypes.Value) tftypes.Value {
+	if prior.IsNull() || !prior.IsKnown() || config.IsNull() || !config.IsKnown() {
+		return config
+	}
+
+	priorAttrs := map[string]tftypes.Value{}
+	configAttrs := map[string]tftypes.Value{}
+	if err := prior.As(&priorAttrs); err != nil {
+		return config
+	}
+	if err := config.As(&configAttrs); err != nil {
+		return config
+	}
+
+	attrs := make(map[string]tftypes.Value, len(configAttrs))
+	for name, val := range configAttrs {
+		attrs[name] = val
+	}
+
+	for _, attr := range block.Attributes {
+		priorVal, configVal := priorAttrs[attr.Name], configAttrs[attr.Name]
+		attrs[attr.Name] = proposedAttribute(attr, priorVal, configVal)
+	}
+
+	for _, nested := range block.BlockTypes {
+		priorVal, configVal := priorAttrs[nested.TypeName], configAttrs[nested.TypeName]
+
+		switch nested.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+			attrs[nested.TypeName] = proposedNewState(nested.Block, priorVal, configVal)
+		case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeMap:
+			attrs[nested.TypeName] = proposedElements(priorVal, configVal, func(prior, config tftypes.Value) tftypes.Value {
+				return proposedNewState(nested.Block, prior, config)
+			})
+		}
+	}
+
+	return tftypes.NewValue(config.Type(), attrs)
+}
+
+// proposedAttribute returns the proposed new value of `attr`.
+func proposedAttribute(attr *tfprotov6.SchemaAttribute, prior, config tftypes.Value) tftypes.Value {
+	if attr.Computed && config.IsNull() {
+		return prior
+	}
+
+	if attr.NestedType == nil {
+		return config
+	}
+
+	objBlock := &tfprotov6.SchemaBlock{
+		Attributes: attr.NestedType.Attributes,
+	}
+
+	switch attr.NestedType.Nesting {
+	case tfprotov6.SchemaObjectNestingModeSingle:
+		return proposedNewState(objBlock, prior, config)
+	case tfprotov6.SchemaObjectNestingModeList, tfprotov6.SchemaObjectNestingModeMap:
+		return proposedElements(prior, config, func(prior, config tftypes.Value) tftypes.Value {
+			return proposedNewState(objBlock, prior, config)
+		})
+	default:
+		return config
+	}
+}
+
+// proposedElements applies `f` to each element of the list or map `config`
+// that has a corresponding element in `prior`. Other elements are left as
+// they are in `config`.
+func proposedElements(prior, config tftypes.Value, f func(prior, config tftypes.Value) tftypes.Value) tftypes.Value {
+	if prior.IsNull() || !prior.IsKnown() || config.IsNull() || !config.IsKnown() {
+		return config
+	}
+
+	if config.Type().Is(tftypes.List{}) {
+		var priorElems, configElems []tftypes.Value
+		if err := prior.As(&priorElems); err != nil {
+			return config
+		}
+		if err := config.As(&configElems); err != nil {
+			return config
+		}
+
+		elems := make([]tftypes.Value, 0, len(configElems))
+		for pos, elem := range configElems {
+			if pos < len(priorElems) {
+				elem = f(priorElems[pos], elem)
+			}
+			elems = append(elems, elem)
+		}
+		return tftypes.NewValue(config.Type(), elems)
+	}
+
+	priorElems := map[string]tftypes.Value{}
+	configElems := map[string]tftypes.Value{}
+	if err := prior.As(&priorElems); err != nil {
+		return config
+	}
+	if err := config.As(&configElems); err != nil {
+		return config
+	}
+
+	elems := make(map[string]tftypes.Value, len(configElems))
+	for key, elem := range configElems {
+		if priorElem, ok := priorElems[key]; ok {
+			elem = f(priorElem, elem)
+		}
+		elems[key] = elem
+	}
+	return tftypes.NewValue(config.Type(), elems)
+}
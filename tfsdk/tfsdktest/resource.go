@@ -0,0 +1,754 @@
+// Package tfsdktest contains helpers for testing providers built with tfsdk,
+// by driving their protocol servers in-process the way Terraform would.
+package tfsdktest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/internal/consistency"
+	"github.com/hashicorp/terraform-plugin-framework/internal/pathstring"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// terraformVersion is the Terraform version sent to ConfigureProvider.
+const terraformVersion = "1.0.0"
+
+// Step is a step of a resource's lifecycle, each of which is one or more
+// RPCs made to the provider.
+type Step string
+
+const (
+	// StepSchema calls GetProviderSchema.
+	StepSchema Step = "schema"
+
+	// StepValidateProvider calls ValidateProviderConfig with
+	// ResourceTest.ProviderConfig.
+	StepValidateProvider Step = "validate-provider"
+
+	// StepConfigure calls ConfigureProvider with
+	// ResourceTest.ProviderConfig.
+	StepConfigure Step = "configure"
+
+	// StepValidate calls ValidateResourceConfig with ResourceTest.Config.
+	StepValidate Step = "validate"
+
+	// StepPlan calls PlanResourceChange to create the resource. The planned
+	// state must be consistent with the configuration.
+	StepPlan Step = "plan"
+
+	// StepApply calls ApplyResourceChange to create the resource. The new
+	// state must be wholly known and consistent with the planned state.
+	StepApply Step = "apply"
+
+	// StepRefresh calls ReadResource after every apply. The resource must
+	// still exist.
+	StepRefresh Step = "refresh"
+
+	// StepPlanEmpty calls PlanResourceChange again after every refresh. The
+	// planned state must equal the refreshed state, and nothing may
+	// require replacement: like Terraform, only attributes in
+	// RequiresReplace whose planned values differ from their refreshed
+	// ones cause a replacement, so the framework's RequiresReplace plan
+	// modifier, which always marks its attribute, is allowed.
+	StepPlanEmpty Step = "plan-empty"
+
+	// StepUpdateValidate calls ValidateResourceConfig with
+	// ResourceTest.UpdateConfig.
+	StepUpdateValidate Step = "update-validate"
+
+	// StepUpdatePlan calls PlanResourceChange to update the resource to
+	// ResourceTest.UpdateConfig, with the same checks as StepPlan.
+	StepUpdatePlan Step = "update-plan"
+
+	// StepUpdateApply calls ApplyResourceChange to update the resource,
+	// with the same checks as StepApply.
+	StepUpdateApply Step = "update-apply"
+
+	// StepReplace calls ApplyResourceChange to destroy the resource when
+	// StepUpdatePlan changes an attribute that requires replacement. The
+	// new state must be null. StepUpdatePlan and StepUpdateApply are then
+	// repeated to create the resource again.
+	StepReplace Step = "replace"
+
+	// StepImport calls ImportResourceState with the import ID, then
+	// ReadResource with the imported state. The result must equal the
+	// current state, other than ResourceTest.ImportStateVerifyIgnore.
+	StepImport Step = "import"
+
+	// StepDestroyPlan calls PlanResourceChange to destroy the resource.
+	// The planned state must be null.
+	StepDestroyPlan Step = "destroy-plan"
+
+	// StepDestroy calls ApplyResourceChange to destroy the resource. The
+	// new state must be null.
+	StepDestroy Step = "destroy"
+)
+
+// ResourceTest describes a test of a resource's lifecycle.
+//
+// Configurations are written as plain Go data: map[string]interface{} for
+// objects, slices for lists, sets, and tuples, maps for maps, and strings,
+// bools, and numbers, including json.Number and *big.Float, for primitives.
+// nil is a null value and tftypes.UnknownValue is an unknown value.
+// Attributes that are left out are null. ConfigFromJSON parses
+// configurations written as JSON.
+type ResourceTest struct {
+	// ProviderConfig is the configuration of the provider.
+	ProviderConfig map[string]interface{}
+
+	// TypeName is the type of the resource being tested, such as
+	// "example_thing".
+	TypeName string
+
+	// Config is the configuration the resource is created with.
+	Config map[string]interface{}
+
+	// UpdateConfig, if set, is the configuration the resource is updated
+	// to after it's created.
+	UpdateConfig map[string]interface{}
+
+	// ImportID is the ID the resource is imported with. If it's empty and
+	// ImportStateIDFunc is nil, import isn't tested.
+	ImportID string
+
+	// ImportStateIDFunc returns the ID the resource is imported with,
+	// given its state, for resources whose IDs aren't known until they're
+	// created.
+	ImportStateIDFunc func(state map[string]interface{}) (string, error)
+
+	// ImportStateVerifyIgnore lists the attributes, such as
+	// `password` or `rule[0].secret`, that may differ between the state
+	// of the resource and the state it's imported as.
+	ImportStateVerifyIgnore []string
+
+	// Check, if set, is called with the state of the resource after every
+	// step that produces one. Returning an error fails the test.
+	Check func(step Step, state map[string]interface{}) error
+
+	// ServeOpts configures the protocol server for the provider, for
+	// example to enable StrictConsistency or CollapseDiagnostics. Name is
+	// ignored.
+	ServeOpts tfsdk.ServeOpts
+}
+
+// StepResult is the outcome of a step.
+type StepResult struct {
+	// Step is the step that was run.
+	Step Step
+
+	// Diagnostics are the diagnostics the provider returned.
+	Diagnostics Diagnostics
+
+	// State is the state of the resource after the step, for steps that
+	// produce one.
+	State tftypes.Value
+}
+
+// TestResource runs a resource through its whole lifecycle, the way
+// Terraform does, against an in-process protocol server for `provider`: it
+// validates and configures the provider, then validates, plans, applies,
+// and refreshes the resource, checks that planning again produces no
+// changes, optionally updates and imports it, and finally destroys it.
+//
+// The diagnostics and state of every step are logged, with sensitive values
+// masked, and the test fails at the first step that returns error
+// diagnostics or behaves inconsistently. The results of the steps that were run are returned.
+func TestResource(t *testing.T, provider tfsdk.Provider, test ResourceTest) []StepResult {
+	t.Helper()
+
+	r := &resourceRun{
+		server: tfsdk.NewProtocol6ServerWithOpts(provider, test.ServeOpts),
+		test:   test,
+	}
+
+	err := r.run(context.Background())
+
+	for _, result := range r.results {
+		for _, d := range result.Diagnostics {
+			t.Logf("%s: %s", result.Step, formatDiagnostic(d))
+		}
+		if result.State.Type() != nil {
+			t.Logf("%s: state: %s", result.Step, r.valueString(result.State, tftypes.NewAttributePath()))
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r.results
+}
+
+// runResource runs the lifecycle described by TestResource, returning the
+// results of the steps that were run and an error describing the first
+// failure, if any.
+func runResource(ctx context.Context, server tfprotov6.ProviderServer, test ResourceTest) ([]StepResult, error) {
+	r := &resourceRun{
+		server: server,
+		test:   test,
+	}
+
+	err := r.run(ctx)
+
+	return r.results, err
+}
+
+type resourceRun struct {
+	server  tfprotov6.ProviderServer
+	test    ResourceTest
+	results []StepResult
+
+	providerSchema *tfprotov6.Schema
+	schema         *tfprotov6.Schema
+	typ            tftypes.Object
+
+	state   tftypes.Value
+	private []byte
+}
+
+func (r *resourceRun) run(ctx context.Context) error {
+	if err := r.configureProvider(ctx); err != nil {
+		return err
+	}
+
+	config, err := r.value(StepValidate, r.test.Config)
+	if err != nil {
+		return err
+	}
+
+	r.state = tftypes.NewValue(r.typ, nil)
+
+	if err := r.apply(ctx, config, StepValidate, StepPlan, StepApply); err != nil {
+		return err
+	}
+
+	if r.test.UpdateConfig != nil {
+		config, err = r.value(StepUpdateValidate, r.test.UpdateConfig)
+		if err != nil {
+			return err
+		}
+
+		if err := r.apply(ctx, config, StepUpdateValidate, StepUpdatePlan, StepUpdateApply); err != nil {
+			return err
+		}
+	}
+
+	if r.test.ImportID != "" || r.test.ImportStateIDFunc != nil {
+		if err := r.importState(ctx); err != nil {
+			return err
+		}
+	}
+
+	return r.destroy(ctx)
+}
+
+func (r *resourceRun) configureProvider(ctx context.Context) error {
+	schemaResp, err := r.server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return stepError(StepSchema, err)
+	}
+	if err := r.result(StepSchema, schemaResp.Diagnostics, tftypes.Value{}); err != nil {
+		return err
+	}
+
+	r.providerSchema = schemaResp.Provider
+	r.schema = schemaResp.ResourceSchemas[r.test.TypeName]
+	if r.schema == nil {
+		return stepError(StepSchema, fmt.Errorf("provider has no resource type %q", r.test.TypeName))
+	}
+	r.typ = blockType(r.schema.Block)
+
+	var providerBlock *tfprotov6.SchemaBlock
+	if r.providerSchema != nil {
+		providerBlock = r.providerSchema.Block
+	}
+
+	providerConfig, err := toValue(blockType(providerBlock), r.test.ProviderConfig, tftypes.NewAttributePath())
+	if err != nil {
+		return stepError(StepValidateProvider, err)
+	}
+	providerConfigDV, err := tfprotov6.NewDynamicValue(providerConfig.Type(), providerConfig)
+	if err != nil {
+		return stepError(StepValidateProvider, err)
+	}
+
+	validateResp, err := r.server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{
+		Config: &providerConfigDV,
+	})
+	if err != nil {
+		return stepError(StepValidateProvider, err)
+	}
+	if err := r.result(StepValidateProvider, validateResp.Diagnostics, tftypes.Value{}); err != nil {
+		return err
+	}
+
+	configureResp, err := r.server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: terraformVersion,
+		Config:           &providerConfigDV,
+	})
+	if err != nil {
+		return stepError(StepConfigure, err)
+	}
+	return r.result(StepConfigure, configureResp.Diagnostics, tftypes.Value{})
+}
+
+// apply validates `config`, plans and applies the change from the current
+// state to it, then refreshes the new state and checks that planning again
+// produces no changes.
+func (r *resourceRun) apply(ctx context.Context, config tftypes.Value, validateStep, planStep, applyStep Step) error {
+	validateResp, err := r.server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: r.test.TypeName,
+		Config:   r.dynamicValue(config),
+	})
+	if err != nil {
+		return stepError(validateStep, err)
+	}
+	if err := r.result(validateStep, validateResp.Diagnostics, tftypes.Value{}); err != nil {
+		return err
+	}
+
+	planned, plannedPrivate, requiresReplace, err := r.plan(ctx, planStep, config)
+	if err != nil {
+		return err
+	}
+
+	if requiresReplacement(r.state, planned, requiresReplace) {
+		nullState := tftypes.NewValue(r.typ, nil)
+		if err := r.applyDestroy(ctx, StepReplace, nullState, nil); err != nil {
+			return err
+		}
+
+		planned, plannedPrivate, _, err = r.plan(ctx, planStep, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := r.inconsistencyError(planStep, "planned state is inconsistent with the configuration", consistency.PlannedValues(config, r.state, planned, consistency.ComputedAtPath(r.schema.Block))); err != nil {
+		return err
+	}
+
+	applyResp, err := r.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.test.TypeName,
+		PriorState:     r.dynamicValue(r.state),
+		PlannedState:   r.dynamicValue(planned),
+		Config:         r.dynamicValue(config),
+		PlannedPrivate: plannedPrivate,
+	})
+	if err != nil {
+		return stepError(applyStep, err)
+	}
+	newState, err := r.unmarshal(applyStep, applyResp.NewState)
+	if err != nil {
+		return err
+	}
+	if err := r.result(applyStep, applyResp.Diagnostics, newState); err != nil {
+		return err
+	}
+	if newState.IsNull() {
+		return stepError(applyStep, fmt.Errorf("new state is null"))
+	}
+	if !newState.IsFullyKnown() {
+		return stepError(applyStep, fmt.Errorf("new state has unknown values: %s", r.valueString(newState, tftypes.NewAttributePath())))
+	}
+	if err := r.inconsistencyError(applyStep, "new state is inconsistent with the planned state", consistency.KnownValues(planned, newState)); err != nil {
+		return err
+	}
+	r.state, r.private = newState, applyResp.Private
+
+	if err := r.check(applyStep); err != nil {
+		return err
+	}
+
+	if err := r.refresh(ctx); err != nil {
+		return err
+	}
+
+	planned, _, requiresReplace, err = r.plan(ctx, StepPlanEmpty, config)
+	if err != nil {
+		return err
+	}
+	if requiresReplacement(r.state, planned, requiresReplace) {
+		return stepError(StepPlanEmpty, fmt.Errorf("planned replacement of the refreshed resource, required by changes to %s", pathsString(changedPaths(r.state, planned, requiresReplace))))
+	}
+	if !planned.Equal(r.state) {
+		return stepError(StepPlanEmpty, fmt.Errorf("planned state differs from the refreshed state:\n\nrefreshed: %s\nplanned:   %s", r.valueString(r.state, tftypes.NewAttributePath()), r.valueString(planned, tftypes.NewAttributePath())))
+	}
+
+	return nil
+}
+
+// requiresReplacement returns true if any of the `requiresReplace` paths
+// have different values in `prior` and `planned`. Like Terraform, it
+// ignores paths whose values haven't changed.
+func requiresReplacement(prior, planned tftypes.Value, requiresReplace []*tftypes.AttributePath) bool {
+	return len(changedPaths(prior, planned, requiresReplace)) > 0
+}
+
+// changedPaths returns the `requiresReplace` paths that have different
+// values in `prior` and `planned`, or none if `prior` is null.
+func changedPaths(prior, planned tftypes.Value, requiresReplace []*tftypes.AttributePath) []*tftypes.AttributePath {
+	if prior.IsNull() {
+		return nil
+	}
+
+	var changed []*tftypes.AttributePath
+
+	for _, path := range requiresReplace {
+		priorVal, _, priorErr := tftypes.WalkAttributePath(prior, path)
+		plannedVal, _, plannedErr := tftypes.WalkAttributePath(planned, path)
+
+		if priorErr != nil || plannedErr != nil {
+			if (priorErr == nil) != (plannedErr == nil) {
+				changed = append(changed, path)
+			}
+			continue
+		}
+
+		priorTfVal, priorOK := priorVal.(tftypes.Value)
+		plannedTfVal, plannedOK := plannedVal.(tftypes.Value)
+		if !priorOK || !plannedOK || !priorTfVal.Equal(plannedTfVal) {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed
+}
+
+// pathsString returns `paths` written like `rule[0].port`, separated by
+// commas.
+func pathsString(paths []*tftypes.AttributePath) string {
+	strs := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		strs = append(strs, pathstring.String(path))
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// plan plans the change from the current state to `config`, returning the
+// planned state, the planned private data, and the paths that require
+// replacement.
+func (r *resourceRun) plan(ctx context.Context, step Step, config tftypes.Value) (tftypes.Value, []byte, []*tftypes.AttributePath, error) {
+	resp, err := r.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.test.TypeName,
+		PriorState:       r.dynamicValue(r.state),
+		ProposedNewState: r.dynamicValue(proposedNewState(r.schema.Block, r.state, config)),
+		Config:           r.dynamicValue(config),
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		return tftypes.Value{}, nil, nil, stepError(step, err)
+	}
+
+	planned, err := r.unmarshal(step, resp.PlannedState)
+	if err != nil {
+		return tftypes.Value{}, nil, nil, err
+	}
+
+	if err := r.result(step, resp.Diagnostics, planned); err != nil {
+		return tftypes.Value{}, nil, nil, err
+	}
+
+	return planned, resp.PlannedPrivate, resp.RequiresReplace, nil
+}
+
+// refresh reads the current state of the resource.
+func (r *resourceRun) refresh(ctx context.Context) error {
+	state, private, diags, err := r.read(ctx, r.state, r.private)
+	if err != nil {
+		return stepError(StepRefresh, err)
+	}
+	if err := r.result(StepRefresh, diags, state); err != nil {
+		return err
+	}
+	if state.IsNull() {
+		return stepError(StepRefresh, fmt.Errorf("resource no longer exists"))
+	}
+	r.state, r.private = state, private
+
+	return r.check(StepRefresh)
+}
+
+func (r *resourceRun) read(ctx context.Context, state tftypes.Value, private []byte) (tftypes.Value, []byte, []*tfprotov6.Diagnostic, error) {
+	resp, err := r.server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     r.test.TypeName,
+		CurrentState: r.dynamicValue(state),
+		Private:      private,
+	})
+	if err != nil {
+		return tftypes.Value{}, nil, nil, err
+	}
+
+	newState := tftypes.NewValue(r.typ, nil)
+	if resp.NewState != nil {
+		newState, err = resp.NewState.Unmarshal(r.typ)
+		if err != nil {
+			return tftypes.Value{}, nil, resp.Diagnostics, fmt.Errorf("error decoding state: %w", err)
+		}
+	}
+
+	return newState, resp.Private, resp.Diagnostics, nil
+}
+
+// importState imports the resource and reads the imported state, which
+// must match the current state.
+func (r *resourceRun) importState(ctx context.Context) error {
+	id := r.test.ImportID
+	if r.test.ImportStateIDFunc != nil {
+		state, err := fromValue(r.state)
+		if err != nil {
+			return stepError(StepImport, err)
+		}
+		id, err = r.test.ImportStateIDFunc(state)
+		if err != nil {
+			return stepError(StepImport, fmt.Errorf("error getting import ID: %w", err))
+		}
+	}
+
+	resp, err := r.server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: r.test.TypeName,
+		ID:       id,
+	})
+	if err != nil {
+		return stepError(StepImport, err)
+	}
+	if err := r.result(StepImport, resp.Diagnostics, tftypes.Value{}); err != nil {
+		return err
+	}
+
+	var imported *tfprotov6.ImportedResource
+	for _, resource := range resp.ImportedResources {
+		if resource.TypeName == r.test.TypeName {
+			imported = resource
+			break
+		}
+	}
+	if imported == nil {
+		return stepError(StepImport, fmt.Errorf("no %s resource was imported", r.test.TypeName))
+	}
+
+	importedState, err := r.unmarshal(StepImport, imported.State)
+	if err != nil {
+		return err
+	}
+
+	state, _, diags, err := r.read(ctx, importedState, imported.Private)
+	if err != nil {
+		return stepError(StepImport, err)
+	}
+	if err := r.result(StepImport, diags, state); err != nil {
+		return err
+	}
+	if state.IsNull() {
+		return stepError(StepImport, fmt.Errorf("imported resource doesn't exist"))
+	}
+
+	ignored := make([]*tftypes.AttributePath, 0, len(r.test.ImportStateVerifyIgnore))
+	for _, s := range r.test.ImportStateVerifyIgnore {
+		path, err := pathstring.Parse(s)
+		if err != nil {
+			return stepError(StepImport, fmt.Errorf("invalid ImportStateVerifyIgnore path %q: %w", s, err))
+		}
+		ignored = append(ignored, path)
+	}
+
+	for _, inconsistency := range consistency.KnownValues(r.state, state) {
+		if !isIgnored(inconsistency.Path, ignored) {
+			return stepError(StepImport, fmt.Errorf("imported state differs from the current state at %s: expected %s, got %s",
+				pathstring.String(inconsistency.Path),
+				r.valueString(inconsistency.Expected, inconsistency.Path),
+				r.valueString(inconsistency.Actual, inconsistency.Path),
+			))
+		}
+	}
+
+	return r.checkState(StepImport, state)
+}
+
+// destroy plans and applies destroying the resource.
+func (r *resourceRun) destroy(ctx context.Context) error {
+	nullState := tftypes.NewValue(r.typ, nil)
+
+	planResp, err := r.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.test.TypeName,
+		PriorState:       r.dynamicValue(r.state),
+		ProposedNewState: r.dynamicValue(nullState),
+		Config:           r.dynamicValue(nullState),
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		return stepError(StepDestroyPlan, err)
+	}
+	planned, err := r.unmarshal(StepDestroyPlan, planResp.PlannedState)
+	if err != nil {
+		return err
+	}
+	if err := r.result(StepDestroyPlan, planResp.Diagnostics, planned); err != nil {
+		return err
+	}
+	if !planned.IsNull() {
+		return stepError(StepDestroyPlan, fmt.Errorf("planned state isn't null: %s", r.valueString(planned, tftypes.NewAttributePath())))
+	}
+
+	return r.applyDestroy(ctx, StepDestroy, planned, planResp.PlannedPrivate)
+}
+
+// applyDestroy applies the destruction of the resource, as planned by
+// `planned`, which must be null.
+func (r *resourceRun) applyDestroy(ctx context.Context, step Step, planned tftypes.Value, plannedPrivate []byte) error {
+	applyResp, err := r.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.test.TypeName,
+		PriorState:     r.dynamicValue(r.state),
+		PlannedState:   r.dynamicValue(planned),
+		Config:         r.dynamicValue(planned),
+		PlannedPrivate: plannedPrivate,
+	})
+	if err != nil {
+		return stepError(step, err)
+	}
+	newState, err := r.unmarshal(step, applyResp.NewState)
+	if err != nil {
+		return err
+	}
+	if err := r.result(step, applyResp.Diagnostics, newState); err != nil {
+		return err
+	}
+	if !newState.IsNull() {
+		return stepError(step, fmt.Errorf("new state isn't null: %s", r.valueString(newState, tftypes.NewAttributePath())))
+	}
+	r.state, r.private = newState, nil
+
+	return nil
+}
+
+// result records the outcome of `step`, returning an error if there are
+// error diagnostics.
+func (r *resourceRun) result(step Step, diags Diagnostics, state tftypes.Value) error {
+	r.results = append(r.results, StepResult{
+		Step:        step,
+		Diagnostics: diags,
+		State:       state,
+	})
+
+	if diags.HasError() {
+		return stepError(step, fmt.Errorf("provider returned errors:\n\n%s", diags.Errors()))
+	}
+
+	return nil
+}
+
+// check calls ResourceTest.Check with the current state.
+func (r *resourceRun) check(step Step) error {
+	return r.checkState(step, r.state)
+}
+
+func (r *resourceRun) checkState(step Step, state tftypes.Value) error {
+	if r.test.Check == nil {
+		return nil
+	}
+
+	m, err := fromValue(state)
+	if err != nil {
+		return stepError(step, err)
+	}
+
+	if err := r.test.Check(step, m); err != nil {
+		return stepError(step, fmt.Errorf("check failed: %w", err))
+	}
+
+	return nil
+}
+
+// valueString returns `val`, found at `path` within the resource, written
+// like HCL, with the values of sensitive attributes masked.
+func (r *resourceRun) valueString(val tftypes.Value, path *tftypes.AttributePath) string {
+	var block *tfprotov6.SchemaBlock
+	if r.schema != nil {
+		block = r.schema.Block
+	}
+
+	return valuestring.Tftypes(val, path, consistency.SensitiveAtPath(block))
+}
+
+// value converts a configuration to a tftypes.Value of the resource's
+// type.
+func (r *resourceRun) value(step Step, config map[string]interface{}) (tftypes.Value, error) {
+	val, err := toValue(r.typ, config, tftypes.NewAttributePath())
+	if err != nil {
+		return tftypes.Value{}, stepError(step, fmt.Errorf("invalid configuration: %w", err))
+	}
+
+	if err := checkConfig(r.schema.Block, val, tftypes.NewAttributePath()); err != nil {
+		return tftypes.Value{}, stepError(step, fmt.Errorf("invalid configuration: %w", err))
+	}
+
+	return val, nil
+}
+
+func (r *resourceRun) dynamicValue(val tftypes.Value) *tfprotov6.DynamicValue {
+	dv, err := tfprotov6.NewDynamicValue(r.typ, val)
+	if err != nil {
+		// values are always built from r.typ, so this can't happen
+		panic(fmt.Sprintf("error encoding %s: %s", val, err))
+	}
+
+	return &dv
+}
+
+func (r *resourceRun) unmarshal(step Step, dv *tfprotov6.DynamicValue) (tftypes.Value, error) {
+	if dv == nil {
+		return tftypes.NewValue(r.typ, nil), nil
+	}
+
+	val, err := dv.Unmarshal(r.typ)
+	if err != nil {
+		return tftypes.Value{}, stepError(step, fmt.Errorf("error decoding state: %w", err))
+	}
+
+	return val, nil
+}
+
+func isIgnored(path *tftypes.AttributePath, ignored []*tftypes.AttributePath) bool {
+	steps := path.Steps()
+
+	for _, prefix := range ignored {
+		prefixSteps := prefix.Steps()
+		if len(prefixSteps) > len(steps) {
+			continue
+		}
+		if tftypes.NewAttributePathWithSteps(steps[:len(prefixSteps)]).Equal(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *resourceRun) inconsistencyError(step Step, summary string, inconsistencies []consistency.Inconsistency) error {
+	if len(inconsistencies) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(inconsistencies))
+	for _, inconsistency := range inconsistencies {
+		lines = append(lines, fmt.Sprintf("%s: expected %s, got %s",
+			pathstring.String(inconsistency.Path),
+			r.valueString(inconsistency.Expected, inconsistency.Path),
+			r.valueString(inconsistency.Actual, inconsistency.Path),
+		))
+	}
+
+	return stepError(step, fmt.Errorf("%s:\n\n%s", summary, strings.Join(lines, "\n")))
+}
+
+func stepError(step Step, err error) error {
+	return fmt.Errorf("%s: %w", step, err)
+}
@@ -0,0 +1,570 @@
+package tfsdktest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type testProvider struct {
+	// bug makes the resource misbehave in the named way
+	bug string
+
+	mu     sync.Mutex
+	things map[string]testThing
+}
+
+type testThing struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Size types.Number `tfsdk:"size"`
+	Tags types.List   `tfsdk:"tags"`
+}
+
+func (p *testProvider) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"region": {
+				Type:     types.StringType,
+				Required: true,
+			},
+		},
+	}, nil
+}
+
+func (p *testProvider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
+	p.things = map[string]testThing{}
+}
+
+func (p *testProvider) GetResources(_ context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
+	return map[string]tfsdk.ResourceType{
+		"test_thing": testThingType{
+			sensitiveName: p.bug == "create-changes-sensitive-name",
+		},
+	}, nil
+}
+
+func (p *testProvider) GetDataSources(_ context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
+	return map[string]tfsdk.DataSourceType{
+		"test_thing": testThingDataSourceType{},
+	}, nil
+}
+
+type testThingType struct {
+	sensitiveName bool
+}
+
+func (t testThingType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				Type:          types.StringType,
+				Required:      true,
+				Sensitive:     t.sensitiveName,
+				PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+			},
+			"size": {
+				Type:     types.NumberType,
+				Optional: true,
+				Computed: true,
+			},
+			"tags": {
+				Type:       types.ListType{ElemType: types.StringType},
+				Optional:   true,
+				Validators: []tfsdk.AttributeValidator{noEmptyStrings{}},
+			},
+		},
+	}, nil
+}
+
+func (testThingType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return testThingResource{p: p.(*testProvider)}, nil
+}
+
+type testThingResource struct {
+	p *testProvider
+}
+
+func (r testThingResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var thing testThing
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &thing)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	thing.ID = types.String{Value: "thing-" + thing.Name.Value}
+	if thing.Size.Unknown || thing.Size.Null {
+		thing.Size = types.Number{Value: bigInt(1)}
+	}
+	if r.p.bug == "create-changes-name" || r.p.bug == "create-changes-sensitive-name" {
+		thing.Name = types.String{Value: strings.ToUpper(thing.Name.Value)}
+	}
+
+	r.p.mu.Lock()
+	r.p.things[thing.ID.Value] = thing
+	r.p.mu.Unlock()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, thing)...)
+}
+
+func (r testThingResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	idVal, diags := req.State.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id := idVal.(types.String)
+
+	r.p.mu.Lock()
+	thing, ok := r.p.things[id.Value]
+	r.p.mu.Unlock()
+
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if r.p.bug == "read-drifts" {
+		thing.Tags = types.List{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "drift"}}}
+	}
+	if r.p.bug == "read-renames" {
+		thing.Name = types.String{Value: strings.ToUpper(thing.Name.Value)}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, thing)...)
+}
+
+func (r testThingResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var thing testThing
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &thing)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if thing.Size.Unknown || thing.Size.Null {
+		thing.Size = types.Number{Value: bigInt(1)}
+	}
+
+	r.p.mu.Lock()
+	r.p.things[thing.ID.Value] = thing
+	r.p.mu.Unlock()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, thing)...)
+}
+
+func (r testThingResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var thing testThing
+	resp.Diagnostics.Append(req.State.Get(ctx, &thing)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.p.mu.Lock()
+	delete(r.p.things, thing.ID.Value)
+	r.p.mu.Unlock()
+
+	if r.p.bug != "delete-keeps-state" {
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (r testThingResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	tagsPath := tftypes.NewAttributePath().WithAttributeName("tags")
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if r.p.bug == "plan-sets-tags" {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsPath, []string{"default"})...)
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// tags are case insensitive, so keep the prior ones if only their case
+	// changed
+	planTags, diags := req.Plan.GetAttribute(ctx, tagsPath)
+	resp.Diagnostics.Append(diags...)
+	stateTags, diags := req.State.GetAttribute(ctx, tagsPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.p.bug == "plan-keeps-tags" || equalFoldTags(planTags.(types.List), stateTags.(types.List)) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsPath, stateTags)...)
+	}
+}
+
+func equalFoldTags(a, b types.List) bool {
+	if a.Null || a.Unknown || b.Null || b.Unknown || len(a.Elems) != len(b.Elems) {
+		return false
+	}
+
+	for i := range a.Elems {
+		aTag, aOK := a.Elems[i].(types.String)
+		bTag, bOK := b.Elems[i].(types.String)
+
+		if !aOK || !bOK || !strings.EqualFold(aTag.Value, bTag.Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r testThingResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	resp.Diagnostics.Append(resp.State.Set(ctx, testThing{
+		ID:   types.String{Value: req.ID},
+		Name: types.String{Null: true},
+		Size: types.Number{Null: true},
+		Tags: types.List{ElemType: types.StringType, Null: true},
+	})...)
+}
+
+type testThingDataSourceType struct{}
+
+func (testThingDataSourceType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				Type:     types.StringType,
+				Required: true,
+			},
+			"size": {
+				Type:     types.NumberType,
+				Computed: true,
+			},
+			"tags": {
+				Type:     types.ListType{ElemType: types.StringType},
+				Computed: true,
+			},
+		},
+	}, nil
+}
+
+func (testThingDataSourceType) NewDataSource(_ context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return testThingDataSource{p: p.(*testProvider)}, nil
+}
+
+type testThingDataSource struct {
+	p *testProvider
+}
+
+func (d testThingDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	nameVal, diags := req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("name"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	name := nameVal.(types.String)
+
+	d.p.mu.Lock()
+	thing, ok := d.p.things["thing-"+name.Value]
+	d.p.mu.Unlock()
+
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("name"),
+			"Thing Not Found",
+			fmt.Sprintf("There is no thing named %q.", name.Value),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, thing)...)
+}
+
+// noEmptyStrings returns an error for every empty string in a list.
+type noEmptyStrings struct{}
+
+func (noEmptyStrings) Description(_ context.Context) string {
+	return "elements must not be empty"
+}
+
+func (noEmptyStrings) MarkdownDescription(_ context.Context) string {
+	return "elements must not be empty"
+}
+
+func (noEmptyStrings) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	list, ok := req.AttributeConfig.(types.List)
+	if !ok || list.Null || list.Unknown {
+		return
+	}
+
+	for pos, elem := range list.Elems {
+		if s, ok := elem.(types.String); ok && !s.Unknown && !s.Null && s.Value == "" {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath.WithElementKeyInt(int64(pos)),
+				"Empty Tag",
+				"Tags must not be empty.",
+			)
+		}
+	}
+}
+
+func bigInt(i int64) *big.Float {
+	return new(big.Float).SetInt64(i)
+}
+
+func TestTestResource(t *testing.T) {
+	t.Parallel()
+
+	var steps []Step
+
+	results := TestResource(t, &testProvider{}, ResourceTest{
+		ProviderConfig: map[string]interface{}{
+			"region": "us-east-1",
+		},
+		TypeName: "test_thing",
+		Config: MustConfigFromJSON(`{
+			"name": "foo",
+			"tags": ["a", "b"]
+		}`),
+		UpdateConfig: map[string]interface{}{
+			"name": "foo",
+			"size": 3,
+		},
+		ImportStateIDFunc: func(state map[string]interface{}) (string, error) {
+			return state["id"].(string), nil
+		},
+		Check: func(step Step, state map[string]interface{}) error {
+			steps = append(steps, step)
+
+			if state["id"] != "thing-foo" {
+				return fmt.Errorf("expected id %q, got %v", "thing-foo", state["id"])
+			}
+			return nil
+		},
+	})
+
+	expectedSteps := []Step{
+		StepApply,
+		StepRefresh,
+		StepUpdateApply,
+		StepRefresh,
+		StepImport,
+	}
+	if fmt.Sprint(steps) != fmt.Sprint(expectedSteps) {
+		t.Errorf("expected checks for %v, got %v", expectedSteps, steps)
+	}
+
+	last := results[len(results)-1]
+	if last.Step != StepDestroy {
+		t.Errorf("expected the last step to be %s, got %s", StepDestroy, last.Step)
+	}
+	if !last.State.IsNull() {
+		t.Errorf("expected a null state after destroy, got %s", last.State)
+	}
+}
+
+func TestRunResource(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		bug           string
+		test          ResourceTest
+		expectedError string
+	}{
+		"valid": {
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+				ImportID: "thing-foo",
+			},
+		},
+		"missing-resource-type": {
+			test: ResourceTest{
+				TypeName: "test_other",
+			},
+			expectedError: `schema: provider has no resource type "test_other"`,
+		},
+		"invalid-config": {
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name":  "foo",
+					"color": "red",
+				},
+			},
+			expectedError: `validate: invalid configuration: unsupported attribute "color"`,
+		},
+		"missing-required": {
+			test: ResourceTest{
+				Config: map[string]interface{}{},
+			},
+			expectedError: `validate: invalid configuration: AttributeName("name"): attribute is required`,
+		},
+		"create-changes-name": {
+			bug: "create-changes-name",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+			},
+			expectedError: `apply: new state is inconsistent with the planned state:
+
+name: expected "foo", got "FOO"`,
+		},
+		"create-changes-sensitive-name": {
+			bug: "create-changes-sensitive-name",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+			},
+			expectedError: `apply: new state is inconsistent with the planned state:
+
+name: expected <sensitive>, got <sensitive>`,
+		},
+		"read-drifts": {
+			bug: "read-drifts",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+			},
+			expectedError: `plan-empty: planned state differs from the refreshed state`,
+		},
+		"read-renames": {
+			bug: "read-renames",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+			},
+			expectedError: `plan-empty: planned replacement of the refreshed resource, required by changes to name`,
+		},
+		"update-requires-replace": {
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+				UpdateConfig: map[string]interface{}{
+					"name": "bar",
+				},
+			},
+		},
+		"import-mismatch": {
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+				ImportID: "thing-bar",
+			},
+			expectedError: `import: imported resource doesn't exist`,
+		},
+		"plan-sets-optional": {
+			bug: "plan-sets-tags",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+			},
+			expectedError: `plan: planned state is inconsistent with the configuration:
+
+tags: expected null, got ["default"]`,
+		},
+		"plan-sets-optional-strict": {
+			bug: "plan-sets-tags",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+				ServeOpts: tfsdk.ServeOpts{
+					StrictConsistency: true,
+				},
+			},
+			expectedError: `plan: provider returned errors:
+
+Error: tags: Provider Produced Invalid Plan: When planning changes to test_thing, the provider planned a value for tags that doesn't match the configuration`,
+		},
+		"update-keeps-equivalent-tags": {
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+					"tags": []interface{}{"web"},
+				},
+				UpdateConfig: map[string]interface{}{
+					"name": "foo",
+					"tags": []interface{}{"WEB"},
+				},
+			},
+		},
+		"update-keeps-removed-tags": {
+			bug: "plan-keeps-tags",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+					"tags": []interface{}{"web"},
+				},
+				UpdateConfig: map[string]interface{}{
+					"name": "foo",
+				},
+			},
+			expectedError: `update-plan: planned state is inconsistent with the configuration:
+
+tags: expected null, got ["web"]`,
+		},
+		"delete-keeps-state": {
+			bug: "delete-keeps-state",
+			test: ResourceTest{
+				Config: map[string]interface{}{
+					"name": "foo",
+				},
+			},
+			expectedError: `destroy: new state isn't null`,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if test.test.TypeName == "" {
+				test.test.TypeName = "test_thing"
+			}
+			test.test.ProviderConfig = map[string]interface{}{
+				"region": "us-east-1",
+			}
+
+			_, err := runResource(context.Background(), tfsdk.NewProtocol6ServerWithOpts(&testProvider{bug: test.bug}, test.test.ServeOpts), test.test)
+
+			if err == nil {
+				if test.expectedError != "" {
+					t.Fatalf("expected error %q, got none", test.expectedError)
+				}
+				return
+			}
+
+			if test.expectedError == "" {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !strings.HasPrefix(err.Error(), test.expectedError) {
+				t.Errorf("expected error starting with %q, got %q", test.expectedError, err)
+			}
+		})
+	}
+}
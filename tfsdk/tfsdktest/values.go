@@ -0,0 +1,327 @@
+package tfsdktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	refl "github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ConfigFromJSON parses a configuration written as a JSON object, for use as
+// ResourceTest.Config and the like. Numbers are kept as json.Number, so they
+// don't lose precision.
+func ConfigFromJSON(s string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewBufferString(s))
+	dec.UseNumber()
+
+	var config map[string]interface{}
+	if err := dec.Decode(&config); err != nil {
+		return nil, fmt.Errorf("error parsing configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// MustConfigFromJSON is like ConfigFromJSON, but panics if `s` can't be
+// parsed.
+func MustConfigFromJSON(s string) map[string]interface{} {
+	config, err := ConfigFromJSON(s)
+	if err != nil {
+		panic(err)
+	}
+	return config
+}
+
+// blockType returns the tftypes.Type for values of `block`.
+func blockType(block *tfprotov6.SchemaBlock) tftypes.Object {
+	attrTypes := map[string]tftypes.Type{}
+
+	if block == nil {
+		return tftypes.Object{AttributeTypes: attrTypes}
+	}
+
+	for _, attr := range block.Attributes {
+		attrTypes[attr.Name] = attributeType(attr)
+	}
+
+	for _, nested := range block.BlockTypes {
+		nestedType := blockType(nested.Block)
+
+		switch nested.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeList:
+			attrTypes[nested.TypeName] = tftypes.List{ElementType: nestedType}
+		case tfprotov6.SchemaNestedBlockNestingModeSet:
+			attrTypes[nested.TypeName] = tftypes.Set{ElementType: nestedType}
+		case tfprotov6.SchemaNestedBlockNestingModeMap:
+			attrTypes[nested.TypeName] = tftypes.Map{AttributeType: nestedType}
+		default:
+			attrTypes[nested.TypeName] = nestedType
+		}
+	}
+
+	return tftypes.Object{AttributeTypes: attrTypes}
+}
+
+// attributeType returns the tftypes.Type for values of `attr`.
+func attributeType(attr *tfprotov6.SchemaAttribute) tftypes.Type {
+	if attr.NestedType == nil {
+		return attr.Type
+	}
+
+	attrTypes := map[string]tftypes.Type{}
+	for _, nested := range attr.NestedType.Attributes {
+		attrTypes[nested.Name] = attributeType(nested)
+	}
+	objType := tftypes.Object{AttributeTypes: attrTypes}
+
+	switch attr.NestedType.Nesting {
+	case tfprotov6.SchemaObjectNestingModeList:
+		return tftypes.List{ElementType: objType}
+	case tfprotov6.SchemaObjectNestingModeSet:
+		return tftypes.Set{ElementType: objType}
+	case tfprotov6.SchemaObjectNestingModeMap:
+		return tftypes.Map{AttributeType: objType}
+	default:
+		return objType
+	}
+}
+
+// toValue converts the plain Go data in `in`, which is found at `path`, to
+// a tftypes.Value of type `typ`. nil becomes a null value, and
+// tftypes.UnknownValue becomes an unknown value. Object attributes missing
+// from `in` are null.
+func toValue(typ tftypes.Type, in interface{}, path *tftypes.AttributePath) (tftypes.Value, error) {
+	if in == nil || in == tftypes.UnknownValue {
+		return tftypes.NewValue(typ, in), nil
+	}
+
+	if val, ok := in.(tftypes.Value); ok {
+		if val.Type() == nil {
+			return tftypes.NewValue(typ, nil), nil
+		}
+		if !val.Type().Is(typ) {
+			return tftypes.Value{}, path.NewErrorf("expected a value of type %s, got %s", typ, val.Type())
+		}
+		return val, nil
+	}
+
+	v := reflect.ValueOf(in)
+
+	switch {
+	case typ.Is(tftypes.String):
+		if v.Kind() != reflect.String {
+			return tftypes.Value{}, path.NewErrorf("expected a string, got %T", in)
+		}
+		return tftypes.NewValue(typ, v.String()), nil
+	case typ.Is(tftypes.Bool):
+		if v.Kind() != reflect.Bool {
+			return tftypes.Value{}, path.NewErrorf("expected a bool, got %T", in)
+		}
+		return tftypes.NewValue(typ, v.Bool()), nil
+	case typ.Is(tftypes.Number):
+		n, err := toNumber(in, v)
+		if err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+		return tftypes.NewValue(typ, n), nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return tftypes.Value{}, path.NewErrorf("expected a slice, got %T", in)
+		}
+
+		var elemTypes []tftypes.Type
+		switch t := typ.(type) {
+		case tftypes.List:
+			elemTypes = repeatType(t.ElementType, v.Len())
+		case tftypes.Set:
+			elemTypes = repeatType(t.ElementType, v.Len())
+		case tftypes.Tuple:
+			if len(t.ElementTypes) != v.Len() {
+				return tftypes.Value{}, path.NewErrorf("expected %d elements, got %d", len(t.ElementTypes), v.Len())
+			}
+			elemTypes = t.ElementTypes
+		}
+
+		elems := make([]tftypes.Value, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, err := toValue(elemTypes[i], v.Index(i).Interface(), path.WithElementKeyInt(int64(i)))
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems = append(elems, elem)
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case typ.Is(tftypes.Map{}):
+		keys, err := mapKeys(v, in, path)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		elemType := typ.(tftypes.Map).AttributeType
+		elems := make(map[string]tftypes.Value, len(keys))
+		for _, key := range keys {
+			elem, err := toValue(elemType, v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())).Interface(), path.WithElementKeyString(key))
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems[key] = elem
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case typ.Is(tftypes.Object{}):
+		keys, err := mapKeys(v, in, path)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		attrTypes := typ.(tftypes.Object).AttributeTypes
+		for _, key := range keys {
+			if _, ok := attrTypes[key]; !ok {
+				return tftypes.Value{}, path.NewErrorf("unsupported attribute %q", key)
+			}
+		}
+
+		attrs := make(map[string]tftypes.Value, len(attrTypes))
+		for name, attrType := range attrTypes {
+			var attrIn interface{}
+			if elem := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())); elem.IsValid() {
+				attrIn = elem.Interface()
+			}
+
+			attr, err := toValue(attrType, attrIn, path.WithAttributeName(name))
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			attrs[name] = attr
+		}
+		return tftypes.NewValue(typ, attrs), nil
+	default:
+		return tftypes.Value{}, path.NewErrorf("unsupported type %s", typ)
+	}
+}
+
+// toNumber converts a Go number, *big.Float, or json.Number to a *big.Float.
+func toNumber(in interface{}, v reflect.Value) (*big.Float, error) {
+	switch n := in.(type) {
+	case *big.Float:
+		return n, nil
+	case json.Number:
+		f, _, err := big.ParseFloat(string(n), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing number %q: %w", n, err)
+		}
+		return f, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Float).SetUint64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return big.NewFloat(v.Float()), nil
+	default:
+		return nil, fmt.Errorf("expected a number, got %T", in)
+	}
+}
+
+// mapKeys returns the sorted keys of `v`, which must be a map with string
+// keys.
+func mapKeys(v reflect.Value, in interface{}, path *tftypes.AttributePath) ([]string, error) {
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, path.NewErrorf("expected a map with string keys, got %T", in)
+	}
+
+	keys := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func repeatType(typ tftypes.Type, n int) []tftypes.Type {
+	types := make([]tftypes.Type, n)
+	for i := range types {
+		types[i] = typ
+	}
+	return types
+}
+
+// fromValue converts `val`, an object, back into plain Go data, the same way
+// interface{} values are built by Get, with unknown values represented as
+// tftypes.UnknownValue. Null objects become nil maps.
+func fromValue(val tftypes.Value) (map[string]interface{}, error) {
+	result, err := interfaceValue(val)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	m, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %s", val.Type())
+	}
+
+	return m, nil
+}
+
+// interfaceValue converts `val` back into plain Go data, as described by
+// fromValue.
+func interfaceValue(val tftypes.Value) (interface{}, error) {
+	result, diags := refl.InterfaceValue(val, refl.Options{
+		UnknownInterfaceValue: tftypes.UnknownValue,
+	})
+	if err := diags.ToError(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkConfig returns an error if `config`, a configuration for `block`
+// found at `path`, is one Terraform would reject without asking the
+// provider: one missing a required attribute, or setting a computed
+// attribute that isn't optional.
+func checkConfig(block *tfprotov6.SchemaBlock, config tftypes.Value, path *tftypes.AttributePath) error {
+	if block == nil || config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	attrs := map[string]tftypes.Value{}
+	if err := config.As(&attrs); err != nil {
+		return path.NewError(err)
+	}
+
+	for _, attr := range block.Attributes {
+		attrPath := path.WithAttributeName(attr.Name)
+		val := attrs[attr.Name]
+
+		switch {
+		case attr.Required && val.IsNull():
+			return attrPath.NewErrorf("attribute is required")
+		case attr.Computed && !attr.Optional && !val.IsNull():
+			return attrPath.NewErrorf("attribute is computed, and can't be configured")
+		}
+
+		if attr.NestedType != nil && attr.NestedType.Nesting == tfprotov6.SchemaObjectNestingModeSingle {
+			nested := &tfprotov6.SchemaBlock{
+				Attributes: attr.NestedType.Attributes,
+			}
+			if err := checkConfig(nested, val, attrPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
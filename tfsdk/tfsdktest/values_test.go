@@ -0,0 +1,116 @@
+package tfsdktest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestToValue(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":  tftypes.String,
+		"count": tftypes.Number,
+		"ports": tftypes.List{ElementType: tftypes.Number},
+		"tags":  tftypes.Map{AttributeType: tftypes.String},
+	}}
+
+	tests := map[string]struct {
+		in            interface{}
+		expected      tftypes.Value
+		expectedError string
+	}{
+		"nil": {
+			in:       nil,
+			expected: tftypes.NewValue(objType, nil),
+		},
+		"unknown": {
+			in:       tftypes.UnknownValue,
+			expected: tftypes.NewValue(objType, tftypes.UnknownValue),
+		},
+		"missing-attributes": {
+			in: map[string]interface{}{
+				"name": "foo",
+			},
+			expected: tftypes.NewValue(objType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "foo"),
+				"count": tftypes.NewValue(tftypes.Number, nil),
+				"ports": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, nil),
+				"tags":  tftypes.NewValue(tftypes.Map{AttributeType: tftypes.String}, nil),
+			}),
+		},
+		"json": {
+			in: MustConfigFromJSON(`{"count": 1.5, "ports": [80, 443], "tags": {"env": "prod"}}`),
+			expected: tftypes.NewValue(objType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, nil),
+				"count": tftypes.NewValue(tftypes.Number, big.NewFloat(1.5)),
+				"ports": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+					tftypes.NewValue(tftypes.Number, big.NewFloat(80)),
+					tftypes.NewValue(tftypes.Number, big.NewFloat(443)),
+				}),
+				"tags": tftypes.NewValue(tftypes.Map{AttributeType: tftypes.String}, map[string]tftypes.Value{
+					"env": tftypes.NewValue(tftypes.String, "prod"),
+				}),
+			}),
+		},
+		"go-types": {
+			in: map[string]interface{}{
+				"count": uint8(3),
+				"ports": []int{80},
+				"tags":  map[string]string{"env": "prod"},
+			},
+			expected: tftypes.NewValue(objType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, nil),
+				"count": tftypes.NewValue(tftypes.Number, big.NewFloat(3)),
+				"ports": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+					tftypes.NewValue(tftypes.Number, big.NewFloat(80)),
+				}),
+				"tags": tftypes.NewValue(tftypes.Map{AttributeType: tftypes.String}, map[string]tftypes.Value{
+					"env": tftypes.NewValue(tftypes.String, "prod"),
+				}),
+			}),
+		},
+		"wrong-type": {
+			in: map[string]interface{}{
+				"ports": []interface{}{80, "443"},
+			},
+			expectedError: `AttributeName("ports").ElementKeyInt(1): expected a number, got string`,
+		},
+		"extra-attribute": {
+			in: map[string]interface{}{
+				"color": "red",
+			},
+			expectedError: `unsupported attribute "color"`,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := toValue(objType, test.in, tftypes.NewAttributePath())
+
+			if err != nil {
+				if test.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if err.Error() != test.expectedError {
+					t.Fatalf("expected error %q, got %q", test.expectedError, err)
+				}
+				return
+			}
+
+			if test.expectedError != "" {
+				t.Fatalf("expected error %q, got none", test.expectedError)
+			}
+
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("unexpected difference (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
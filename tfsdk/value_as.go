@@ -14,6 +14,12 @@ import (
 // the contents of `val`, using the reflection rules
 // defined for `Get` and `GetAttribute`.
 func ValueAs(ctx context.Context, val attr.Value, target interface{}) diag.Diagnostics {
+	return ValueAsWithOptions(ctx, val, target, GetOptions{})
+}
+
+// ValueAsWithOptions populates the Go value passed as `target` with the
+// contents of `val`, using the reflection rules defined for `GetWithOptions`.
+func ValueAsWithOptions(ctx context.Context, val attr.Value, target interface{}, opts GetOptions) diag.Diagnostics {
 	raw, err := val.ToTerraformValue(ctx)
 	if err != nil {
 		return diag.Diagnostics{diag.NewErrorDiagnostic("Error converting value",
@@ -26,5 +32,5 @@ func ValueAs(ctx context.Context, val attr.Value, target interface{}) diag.Diagn
 			fmt.Sprintf("An unexpected error was encountered converting a %T to its equivalent Terraform representation. This is always a bug in the provider.\n\nError: %s", val, err))}
 	}
 	v := tftypes.NewValue(typ, raw)
-	return reflect.Into(ctx, val.Type(ctx), v, target, reflect.Options{})
+	return reflect.Into(ctx, val.Type(ctx), v, target, opts.reflectOptions())
 }
@@ -83,3 +83,81 @@ func TestValueAs(t *testing.T) {
 		})
 	}
 }
+
+func TestValueAsWithOptions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val           attr.Value
+		target        interface{}
+		opts          GetOptions
+		expected      interface{}
+		expectedDiags diag.Diagnostics
+	}
+
+	tests := map[string]testCase{
+		"unknown-as-empty": {
+			val:      types.String{Unknown: true},
+			target:   newStringPointer("hello"),
+			opts:     GetOptions{UnhandledUnknownAsEmpty: true},
+			expected: newStringPointer(""),
+		},
+		"rounding": {
+			val:      types.Number{Value: big.NewFloat(2.5)},
+			target:   newInt64Pointer(0),
+			opts:     GetOptions{AllowRoundingNumbers: true},
+			expected: newInt64Pointer(2),
+		},
+		"lenient": {
+			val: types.Object{
+				AttrTypes: map[string]attr.Type{
+					"name":  types.StringType,
+					"extra": types.StringType,
+				},
+				Attrs: map[string]attr.Value{
+					"name":  types.String{Value: "hello"},
+					"extra": types.String{Value: "ignored"},
+				},
+			},
+			target: &struct {
+				Name string `tfsdk:"name"`
+			}{},
+			opts: GetOptions{Mode: GetModeLenient},
+			expected: &struct {
+				Name string `tfsdk:"name"`
+			}{Name: "hello"},
+		},
+		"interface-unknown": {
+			val: types.Object{
+				AttrTypes: map[string]attr.Type{
+					"name": types.StringType,
+					"id":   types.StringType,
+				},
+				Attrs: map[string]attr.Value{
+					"name": types.String{Value: "hello"},
+					"id":   types.String{Unknown: true},
+				},
+			},
+			target:   &map[string]interface{}{},
+			opts:     GetOptions{UnknownInterfaceValue: tftypes.UnknownValue},
+			expected: &map[string]interface{}{"name": "hello", "id": tftypes.UnknownValue},
+		},
+	}
+
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := ValueAsWithOptions(context.Background(), tc.val, tc.target, tc.opts)
+
+			if diff := cmp.Diff(diags, tc.expectedDiags); diff != "" {
+				t.Fatalf("unexpected diagnostics (+got, -expected): %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.target, tc.expected); diff != "" {
+				t.Errorf("unexpected value (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
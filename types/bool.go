@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -74,3 +75,17 @@ func (b Bool) Equal(other attr.Value) bool {
 	}
 	return b.Value == o.Value
 }
+
+// String returns a human-readable representation of the Bool, such as true,
+// null, or <unknown>.
+func (b Bool) String() string {
+	if b.Unknown {
+		return valuestring.Unknown
+	}
+
+	if b.Null {
+		return valuestring.Null
+	}
+
+	return valuestring.Bool(b.Value)
+}
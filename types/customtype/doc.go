@@ -0,0 +1,5 @@
+// Package customtype contains helpers for implementing custom attr.Type and
+// attr.Value implementations on top of an existing base type, such as
+// types.StringType, overriding only the behaviors that differ. Behaviors are
+// overridden by setting the fields of Type; Type can't be embedded.
+package customtype
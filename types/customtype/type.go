@@ -0,0 +1,132 @@
+package customtype
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ attr.TypeWithValidate = Type{}
+)
+
+// Type is an attr.Type that wraps a base attr.Type, such as
+// types.StringType, and delegates to it everything the custom type doesn't
+// override. Values created by a Type are Values, unless NewValue is set.
+//
+// Behaviors are overridden by setting Type's fields, not by embedding Type
+// in another struct and redefining its methods: Equal only recognizes Types,
+// and the Values a Type creates return the Type itself from their Type
+// method, so a type embedding Type isn't equal to itself, and its values
+// report the wrong type. Custom types that need methods Type doesn't
+// provide need to implement attr.Type themselves.
+type Type struct {
+	// Base is the attr.Type being wrapped. It determines the Terraform
+	// type of the custom type, and is used to create and validate values.
+	// Base is required.
+	Base attr.Type
+
+	// Name is the human-friendly name of the custom type, returned by
+	// String. Two Types are equal if their Names are the same and their
+	// Base types are equal.
+	Name string
+
+	// NewValue, if set, is called with every Value created by the Type,
+	// and returns the attr.Value to use instead. This lets custom types
+	// return their own attr.Value implementations, usually a struct
+	// embedding Value. The returned attr.Value's Type method must return
+	// the Type.
+	NewValue func(context.Context, Value) (attr.Value, error)
+
+	// ValidateFunc, if set, is called to validate values after the Base
+	// type's validation, if it has any, returns no errors.
+	ValidateFunc func(context.Context, tftypes.Value, *tftypes.AttributePath) diag.Diagnostics
+}
+
+// TerraformType returns the tftypes.Type of the Base type.
+func (t Type) TerraformType(ctx context.Context) tftypes.Type {
+	return t.Base.TerraformType(ctx)
+}
+
+// ValueFromTerraform creates a value using the Base type, wraps it in a
+// Value, and passes that to NewValue if it is set.
+func (t Type) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	base, err := t.Base.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	val := Value{
+		Base:      base,
+		CreatedBy: t,
+	}
+
+	if t.NewValue == nil {
+		return val, nil
+	}
+
+	res, err := t.NewValue(ctx, val)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s value: %w", t, err)
+	}
+
+	return res, nil
+}
+
+// Equal returns true if `o` is also a Type, with the same Name and an equal
+// Base type.
+func (t Type) Equal(o attr.Type) bool {
+	other, ok := o.(Type)
+	if !ok {
+		return false
+	}
+
+	if t.Name != other.Name {
+		return false
+	}
+
+	if t.Base == nil || other.Base == nil {
+		return t.Base == nil && other.Base == nil
+	}
+
+	return t.Base.Equal(other.Base)
+}
+
+// String returns the Name of the Type, or a description of the Base type if
+// Name is empty.
+func (t Type) String() string {
+	if t.Name != "" {
+		return t.Name
+	}
+
+	return fmt.Sprintf("customtype.Type[%s]", t.Base)
+}
+
+// ApplyTerraform5AttributePathStep applies the given AttributePathStep to the
+// Base type.
+func (t Type) ApplyTerraform5AttributePathStep(step tftypes.AttributePathStep) (interface{}, error) {
+	return t.Base.ApplyTerraform5AttributePathStep(step)
+}
+
+// Validate runs the Base type's validation, if it has any, followed by
+// ValidateFunc.
+func (t Type) Validate(ctx context.Context, in tftypes.Value, path *tftypes.AttributePath) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if typeWithValidate, ok := t.Base.(attr.TypeWithValidate); ok {
+		diags.Append(typeWithValidate.Validate(ctx, in, path)...)
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if t.ValidateFunc != nil {
+		diags.Append(t.ValidateFunc(ctx, in, path)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,249 @@
+package customtype_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/attrtest"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/customtype"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ipAddress is an example custom value, embedding customtype.Value.
+type ipAddress struct {
+	customtype.Value
+}
+
+func (v ipAddress) IP() net.IP {
+	s, ok := v.Base.(types.String)
+	if !ok || s.Null || s.Unknown {
+		return nil
+	}
+	return net.ParseIP(s.Value)
+}
+
+var ipAddressType = customtype.Type{
+	Base: types.StringType,
+	Name: "IPAddressType",
+	NewValue: func(_ context.Context, v customtype.Value) (attr.Value, error) {
+		return ipAddress{Value: v}, nil
+	},
+	ValidateFunc: func(_ context.Context, in tftypes.Value, path *tftypes.AttributePath) diag.Diagnostics {
+		var diags diag.Diagnostics
+		if !in.IsKnown() || in.IsNull() {
+			return diags
+		}
+		var s string
+		if err := in.As(&s); err != nil {
+			diags.AddAttributeError(path, "Invalid IP Address", err.Error())
+			return diags
+		}
+		if net.ParseIP(s) == nil {
+			diags.AddAttributeError(path, "Invalid IP Address", "Value must be an IP address, got: "+s)
+		}
+		return diags
+	},
+}
+
+func TestTypeConformance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		attrtest.TestType(t, customtype.Type{Base: types.StringType, Name: "WrappedString"},
+			tftypes.NewValue(tftypes.String, "hello"),
+		)
+	})
+
+	t.Run("wrapped-list", func(t *testing.T) {
+		t.Parallel()
+
+		attrtest.TestType(t, customtype.Type{Base: types.ListType{ElemType: types.Int64Type}},
+			tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, 1),
+				tftypes.NewValue(tftypes.Number, 2),
+			}),
+		)
+	})
+
+	t.Run("new-value", func(t *testing.T) {
+		t.Parallel()
+
+		attrtest.TestType(t, ipAddressType,
+			tftypes.NewValue(tftypes.String, "10.0.0.1"),
+			tftypes.NewValue(tftypes.String, "::1"),
+		)
+	})
+}
+
+func TestTypeValueFromTerraform(t *testing.T) {
+	t.Parallel()
+
+	got, err := ipAddressType.ValueFromTerraform(context.Background(), tftypes.NewValue(tftypes.String, "10.0.0.1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	ip, ok := got.(ipAddress)
+	if !ok {
+		t.Fatalf("Expected ipAddress, got %T", got)
+	}
+
+	if !ip.IP().Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("Expected 10.0.0.1, got %s", ip.IP())
+	}
+}
+
+func TestTypeEqual(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		receiver customtype.Type
+		input    attr.Type
+		expected bool
+	}
+	tests := map[string]testCase{
+		"equal": {
+			receiver: customtype.Type{Base: types.StringType, Name: "Test"},
+			input:    customtype.Type{Base: types.StringType, Name: "Test"},
+			expected: true,
+		},
+		"different-name": {
+			receiver: customtype.Type{Base: types.StringType, Name: "Test"},
+			input:    customtype.Type{Base: types.StringType, Name: "Other"},
+			expected: false,
+		},
+		"different-base": {
+			receiver: customtype.Type{Base: types.StringType, Name: "Test"},
+			input:    customtype.Type{Base: types.BoolType, Name: "Test"},
+			expected: false,
+		},
+		"base-type": {
+			receiver: customtype.Type{Base: types.StringType, Name: "Test"},
+			input:    types.StringType,
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.receiver.Equal(test.input)
+			if got != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestTypeValidate(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("test")
+
+	type testCase struct {
+		typ           customtype.Type
+		in            tftypes.Value
+		expectedDiags diag.Diagnostics
+	}
+	tests := map[string]testCase{
+		"valid": {
+			typ: ipAddressType,
+			in:  tftypes.NewValue(tftypes.String, "10.0.0.1"),
+		},
+		"invalid": {
+			typ: ipAddressType,
+			in:  tftypes.NewValue(tftypes.String, "hello"),
+			expectedDiags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, "Invalid IP Address", "Value must be an IP address, got: hello"),
+			},
+		},
+		"base-validation": {
+			typ: customtype.Type{Base: types.SetType{ElemType: types.StringType}},
+			in: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "hello"),
+				tftypes.NewValue(tftypes.String, "hello"),
+			}),
+			expectedDiags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyValue(tftypes.NewValue(tftypes.String, "hello")),
+					"Duplicate Set Element",
+					"This attribute contains duplicate values of: \"hello\"",
+				),
+			},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.typ.Validate(context.Background(), test.in, path)
+			if diff := cmp.Diff(got, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected diagnostics (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValueEqual(t *testing.T) {
+	t.Parallel()
+
+	typ := customtype.Type{Base: types.StringType, Name: "Test"}
+
+	type testCase struct {
+		receiver customtype.Value
+		input    attr.Value
+		expected bool
+	}
+	tests := map[string]testCase{
+		"equal": {
+			receiver: customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ},
+			input:    customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ},
+			expected: true,
+		},
+		"embedded": {
+			receiver: customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ},
+			input:    ipAddress{customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ}},
+			expected: true,
+		},
+		"different-value": {
+			receiver: customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ},
+			input:    customtype.Value{Base: types.String{Value: "world"}, CreatedBy: typ},
+			expected: false,
+		},
+		"different-type": {
+			receiver: customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ},
+			input:    customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: ipAddressType},
+			expected: false,
+		},
+		"base-value": {
+			receiver: customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ},
+			input:    types.String{Value: "hello"},
+			expected: false,
+		},
+		"nil": {
+			receiver: customtype.Value{Base: types.String{Value: "hello"}, CreatedBy: typ},
+			input:    nil,
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.receiver.Equal(test.input)
+			if got != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package customtype
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/attrvalue"
+)
+
+var _ attr.Value = Value{}
+
+// Value is an attr.Value created by a Type. It wraps the value created by the
+// Type's Base type and delegates to it.
+//
+// Custom types that need their own attr.Value implementation, for example to
+// add methods, can embed Value in a struct and return that struct from
+// Type.NewValue.
+type Value struct {
+	// Base is the attr.Value created by the Base type of CreatedBy.
+	Base attr.Value
+
+	// CreatedBy is the Type that created the Value.
+	CreatedBy Type
+}
+
+// Type returns the Type that created the Value.
+func (v Value) Type(_ context.Context) attr.Type {
+	return v.CreatedBy
+}
+
+// ToTerraformValue returns the data contained in the Base value.
+func (v Value) ToTerraformValue(ctx context.Context) (interface{}, error) {
+	return v.Base.ToTerraformValue(ctx)
+}
+
+// Equal returns true if `o` is a Value, or embeds a Value, created by an equal
+// Type and with an equal Base value.
+func (v Value) Equal(o attr.Value) bool {
+	other, ok := o.(interface{ customValue() Value })
+	if !ok {
+		return false
+	}
+
+	otherValue := other.customValue()
+
+	if !v.CreatedBy.Equal(otherValue.CreatedBy) {
+		return false
+	}
+
+	if v.Base == nil || otherValue.Base == nil {
+		return v.Base == nil && otherValue.Base == nil
+	}
+
+	return v.Base.Equal(otherValue.Base)
+}
+
+// String returns a human-readable representation of the Base value.
+func (v Value) String() string {
+	return attrvalue.String(context.Background(), v.Base)
+}
+
+// customValue returns the Value. It is promoted to types embedding Value,
+// which lets Equal compare them.
+func (v Value) customValue() Value {
+	return v
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -18,7 +20,7 @@ func float64Validate(ctx context.Context, in tftypes.Value, path *tftypes.Attrib
 			path,
 			"Float64 Type Validation Error",
 			"An unexpected error was encountered trying to validate an attribute value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
-				fmt.Sprintf("Expected Number value, received %T with value: %v", in, in),
+				fmt.Sprintf("Expected Number value, received %T with value: %s", in, valuestring.Tftypes(in, nil, nil)),
 		)
 		return diags
 	}
@@ -27,7 +29,7 @@ func float64Validate(ctx context.Context, in tftypes.Value, path *tftypes.Attrib
 		return diags
 	}
 
-	var value *big.Float
+	value := new(big.Float)
 	err := in.As(&value)
 
 	if err != nil {
@@ -46,7 +48,7 @@ func float64Validate(ctx context.Context, in tftypes.Value, path *tftypes.Attrib
 		diags.AddAttributeError(
 			path,
 			"Float64 Type Validation Error",
-			fmt.Sprintf("Value %s cannot be represented as a 64-bit floating point.", value),
+			fmt.Sprintf("Value %s cannot be represented as a 64-bit floating point.", valuestring.Number(value)),
 		)
 		return diags
 	}
@@ -63,7 +65,7 @@ func float64ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Valu
 		return Float64{Null: true}, nil
 	}
 
-	var bigF *big.Float
+	bigF := new(big.Float)
 	err := in.As(&bigF)
 
 	if err != nil {
@@ -73,7 +75,7 @@ func float64ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Valu
 	f, accuracy := bigF.Float64()
 
 	if accuracy != 0 {
-		return nil, fmt.Errorf("Value %s cannot be represented as a 64-bit floating point.", bigF)
+		return nil, fmt.Errorf("Value %s cannot be represented as a 64-bit floating point.", valuestring.Number(bigF))
 	}
 
 	return Float64{Value: f}, nil
@@ -129,7 +131,21 @@ func (f Float64) ToTerraformValue(ctx context.Context) (interface{}, error) {
 	return big.NewFloat(f.Value), nil
 }
 
-// Type returns a NumberType.
+// Type returns a Float64Type.
 func (f Float64) Type(ctx context.Context) attr.Type {
-	return Int64Type
+	return Float64Type
+}
+
+// String returns a human-readable representation of the Float64, such as 1.5,
+// null, or <unknown>.
+func (f Float64) String() string {
+	if f.Unknown {
+		return valuestring.Unknown
+	}
+
+	if f.Null {
+		return valuestring.Null
+	}
+
+	return strconv.FormatFloat(f.Value, 'g', -1, 64)
 }
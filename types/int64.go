@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -18,7 +20,7 @@ func int64Validate(ctx context.Context, in tftypes.Value, path *tftypes.Attribut
 			path,
 			"Int64 Type Validation Error",
 			"An unexpected error was encountered trying to validate an attribute value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
-				fmt.Sprintf("Expected Number value, received %T with value: %v", in, in),
+				fmt.Sprintf("Expected Number value, received %T with value: %s", in, valuestring.Tftypes(in, nil, nil)),
 		)
 		return diags
 	}
@@ -27,7 +29,7 @@ func int64Validate(ctx context.Context, in tftypes.Value, path *tftypes.Attribut
 		return diags
 	}
 
-	var value *big.Float
+	value := new(big.Float)
 	err := in.As(&value)
 
 	if err != nil {
@@ -44,7 +46,7 @@ func int64Validate(ctx context.Context, in tftypes.Value, path *tftypes.Attribut
 		diags.AddAttributeError(
 			path,
 			"Int64 Type Validation Error",
-			fmt.Sprintf("Value %s is not an integer.", value),
+			fmt.Sprintf("Value %s is not an integer.", valuestring.Number(value)),
 		)
 		return diags
 	}
@@ -55,7 +57,7 @@ func int64Validate(ctx context.Context, in tftypes.Value, path *tftypes.Attribut
 		diags.AddAttributeError(
 			path,
 			"Int64 Type Validation Error",
-			fmt.Sprintf("Value %s cannot be represented as a 64-bit integer.", value),
+			fmt.Sprintf("Value %s cannot be represented as a 64-bit integer.", valuestring.Number(value)),
 		)
 		return diags
 	}
@@ -72,7 +74,7 @@ func int64ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value,
 		return Int64{Null: true}, nil
 	}
 
-	var bigF *big.Float
+	bigF := new(big.Float)
 	err := in.As(&bigF)
 
 	if err != nil {
@@ -80,13 +82,13 @@ func int64ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value,
 	}
 
 	if !bigF.IsInt() {
-		return nil, fmt.Errorf("Value %s is not an integer.", bigF)
+		return nil, fmt.Errorf("Value %s is not an integer.", valuestring.Number(bigF))
 	}
 
 	i, accuracy := bigF.Int64()
 
 	if accuracy != 0 {
-		return nil, fmt.Errorf("Value %s cannot be represented as a 64-bit integer.", bigF)
+		return nil, fmt.Errorf("Value %s cannot be represented as a 64-bit integer.", valuestring.Number(bigF))
 	}
 
 	return Int64{Value: i}, nil
@@ -142,7 +144,21 @@ func (i Int64) ToTerraformValue(ctx context.Context) (interface{}, error) {
 	return new(big.Float).SetInt64(i.Value), nil
 }
 
-// Type returns a NumberType.
+// Type returns an Int64Type.
 func (i Int64) Type(ctx context.Context) attr.Type {
 	return Int64Type
 }
+
+// String returns a human-readable representation of the Int64, such as 10,
+// null, or <unknown>.
+func (i Int64) String() string {
+	if i.Unknown {
+		return valuestring.Unknown
+	}
+
+	if i.Null {
+		return valuestring.Null
+	}
+
+	return strconv.FormatInt(i.Value, 10)
+}
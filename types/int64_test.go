@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"math"
 	"math/big"
 	"testing"
 
@@ -28,6 +29,10 @@ func testInt64ValueFromTerraform(t *testing.T, direct bool) {
 			input:       tftypes.NewValue(tftypes.Number, 123),
 			expectation: Int64{Value: 123},
 		},
+		"value-max": {
+			input:       tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(math.MaxInt64)),
+			expectation: Int64{Value: math.MaxInt64},
+		},
 		"unknown": {
 			input:       tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
 			expectation: Int64{Unknown: true},
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -211,3 +212,17 @@ func (l List) Equal(o attr.Value) bool {
 	}
 	return true
 }
+
+// String returns a human-readable representation of the List, such as
+// ["a", <unknown>, null].
+func (l List) String() string {
+	if l.Unknown {
+		return valuestring.Unknown
+	}
+
+	if l.Null {
+		return valuestring.Null
+	}
+
+	return elemsString(l.Elems)
+}
@@ -0,0 +1,172 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+)
+
+var (
+	_ attr.Value                   = ListOf[String]{}
+	_ reflect.AttributeValueSetter = &ListOf[String]{}
+)
+
+// ListOf is a List whose elements are all of the attr.Value implementation
+// T, such as ListOf[String]. It can be used anywhere a List can, including
+// as a struct field with State.Get and Plan.Set, without type assertions on
+// its elements.
+//
+// No attr.Type creates ListOf values: Type returns a ListType, whose
+// ValueFromTerraform returns a List, so a ListOf doesn't round-trip through
+// its type on its own. Use NewListOf to convert the List, or a ListOf struct
+// field with the reflection rules, which convert it automatically.
+type ListOf[T attr.Value] struct {
+	// Unknown will be set to true if the entire list is an unknown value.
+	// If only some of the elements in the list are unknown, their known or
+	// unknown status will be represented however T surfaces that
+	// information.
+	Unknown bool
+
+	// Null will be set to true if the list is null, either because it was
+	// omitted from the configuration, state, or plan, or because it was
+	// explicitly set to null.
+	Null bool
+
+	// Elems are the elements in the list.
+	Elems []T
+
+	// ElemType is the attr.Type of the elements in the list. It must
+	// create values of type T.
+	ElemType attr.Type
+}
+
+// NewListOf returns a ListOf with the elements of `l`, returning an error
+// diagnostic if any of them are not of type T.
+func NewListOf[T attr.Value](l List) (ListOf[T], diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elems, err := typedElems[T](l.Elems)
+	if err != nil {
+		diags.AddError(
+			"List Element Conversion Error",
+			"An unexpected error was encountered trying to convert list elements. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return ListOf[T]{ElemType: l.ElemType}, diags
+	}
+
+	return ListOf[T]{
+		Unknown:  l.Unknown,
+		Null:     l.Null,
+		Elems:    elems,
+		ElemType: l.ElemType,
+	}, diags
+}
+
+// ToList returns the List equivalent of `l`.
+func (l ListOf[T]) ToList() List {
+	var elems []attr.Value
+
+	if l.Elems != nil {
+		elems = make([]attr.Value, 0, len(l.Elems))
+		for _, elem := range l.Elems {
+			elems = append(elems, elem)
+		}
+	}
+
+	return List{
+		Unknown:  l.Unknown,
+		Null:     l.Null,
+		Elems:    elems,
+		ElemType: l.ElemType,
+	}
+}
+
+// Len returns the number of elements in the list.
+func (l ListOf[T]) Len() int {
+	return len(l.Elems)
+}
+
+// Element returns the element at index `i`, and whether the list has an
+// element at that index.
+func (l ListOf[T]) Element(i int) (T, bool) {
+	if i < 0 || i >= len(l.Elems) {
+		var zero T
+		return zero, false
+	}
+
+	return l.Elems[i], true
+}
+
+// SetAttributeValue populates `l` from the List `v`. It is used by the
+// reflection rules for State.Get, Plan.Get, and Config.Get.
+func (l *ListOf[T]) SetAttributeValue(_ context.Context, v attr.Value) error {
+	list, ok := v.(List)
+	if !ok {
+		return fmt.Errorf("cannot populate %T from %T, expected types.List", l, v)
+	}
+
+	elems, err := typedElems[T](list.Elems)
+	if err != nil {
+		return err
+	}
+
+	*l = ListOf[T]{
+		Unknown:  list.Unknown,
+		Null:     list.Null,
+		Elems:    elems,
+		ElemType: list.ElemType,
+	}
+
+	return nil
+}
+
+// Type returns a ListType with the same element type as `l`. Its values
+// are Lists, not ListOfs.
+func (l ListOf[T]) Type(ctx context.Context) attr.Type {
+	return ListType{ElemType: l.ElemType}
+}
+
+// ToTerraformValue returns the data contained in the ListOf as a Go type that
+// tftypes.NewValue will accept.
+func (l ListOf[T]) ToTerraformValue(ctx context.Context) (interface{}, error) {
+	return l.ToList().ToTerraformValue(ctx)
+}
+
+// Equal returns true if `o` is a ListOf[T] with the same elements as `l`.
+func (l ListOf[T]) Equal(o attr.Value) bool {
+	other, ok := o.(ListOf[T])
+	if !ok {
+		return false
+	}
+
+	return l.ToList().Equal(other.ToList())
+}
+
+// String returns a human-readable representation of the ListOf, such as
+// ["a", "b"].
+func (l ListOf[T]) String() string {
+	return l.ToList().String()
+}
+
+// typedElems returns `in` as a []T, or an error if any element is not a T.
+func typedElems[T attr.Value](in []attr.Value) ([]T, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := make([]T, 0, len(in))
+
+	for pos, elem := range in {
+		typed, ok := elem.(T)
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("element %d is %T, not %T", pos, elem, zero)
+		}
+		out = append(out, typed)
+	}
+
+	return out, nil
+}
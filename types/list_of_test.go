@@ -0,0 +1,277 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewListOf(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		input         List
+		expected      ListOf[String]
+		expectedDiags diag.Diagnostics
+	}
+	tests := map[string]testCase{
+		"value": {
+			input: List{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Unknown: true},
+				},
+			},
+			expected: ListOf[String]{
+				ElemType: StringType,
+				Elems: []String{
+					{Value: "hello"},
+					{Unknown: true},
+				},
+			},
+		},
+		"null": {
+			input:    List{ElemType: StringType, Null: true},
+			expected: ListOf[String]{ElemType: StringType, Null: true},
+		},
+		"unknown": {
+			input:    List{ElemType: StringType, Unknown: true},
+			expected: ListOf[String]{ElemType: StringType, Unknown: true},
+		},
+		"wrong-element-type": {
+			input: List{
+				ElemType: BoolType,
+				Elems: []attr.Value{
+					Bool{Value: true},
+				},
+			},
+			expected: ListOf[String]{ElemType: BoolType},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"List Element Conversion Error",
+					"An unexpected error was encountered trying to convert list elements. This is always an error in the provider. Please report the following to the provider developer:\n\nelement 0 is types.Bool, not types.String",
+				),
+			},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := NewListOf[String](test.input)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("Unexpected result (+got, -expected): %s", diff)
+			}
+			if diags.HasError() {
+				return
+			}
+			if diff := cmp.Diff(got.ToList(), test.input); diff != "" {
+				t.Errorf("Unexpected ToList result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestListOfType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	list := ListOf[String]{
+		ElemType: StringType,
+		Elems:    []String{{Value: "hello"}},
+	}
+
+	raw, err := list.ToTerraformValue(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	got, err := list.Type(ctx).ValueFromTerraform(ctx, tftypes.NewValue(list.Type(ctx).TerraformType(ctx), raw))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// the type creates a List, which NewListOf converts back
+	if _, ok := got.(List); !ok {
+		t.Fatalf("Expected a List, got %T", got)
+	}
+
+	roundTripped, diags := NewListOf[String](got.(List))
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	if diff := cmp.Diff(roundTripped, list); diff != "" {
+		t.Errorf("Unexpected diff (+wanted, -got): %s", diff)
+	}
+}
+
+func TestListOfElement(t *testing.T) {
+	t.Parallel()
+
+	list := ListOf[String]{
+		ElemType: StringType,
+		Elems: []String{
+			{Value: "hello"},
+			{Value: "world"},
+		},
+	}
+
+	if list.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", list.Len())
+	}
+
+	got, ok := list.Element(1)
+	if !ok || got.Value != "world" {
+		t.Errorf("Expected element 1 to be \"world\", got %v (%v)", got, ok)
+	}
+
+	_, ok = list.Element(2)
+	if ok {
+		t.Errorf("Expected no element at index 2")
+	}
+}
+
+func TestListOfEqual(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		receiver ListOf[String]
+		input    attr.Value
+		expected bool
+	}
+	tests := map[string]testCase{
+		"equal": {
+			receiver: ListOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    ListOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			expected: true,
+		},
+		"different-elements": {
+			receiver: ListOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    ListOf[String]{ElemType: StringType, Elems: []String{{Value: "world"}}},
+			expected: false,
+		},
+		"list": {
+			receiver: ListOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    List{ElemType: StringType, Elems: []attr.Value{String{Value: "hello"}}},
+			expected: false,
+		},
+		"nil": {
+			receiver: ListOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    nil,
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.receiver.Equal(test.input)
+			if got != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestListOfReflection(t *testing.T) {
+	t.Parallel()
+
+	type model struct {
+		Names ListOf[String]         `tfsdk:"names"`
+		Tags  MapOf[String]          `tfsdk:"tags"`
+		IDs   SetOf[Int64]           `tfsdk:"ids"`
+		Rules ListOf[Object]         `tfsdk:"rules"`
+		Empty ListOf[String]         `tfsdk:"empty"`
+		Other map[string]interface{} `tfsdk:"-"`
+	}
+
+	ruleType := ObjectType{AttrTypes: map[string]attr.Type{"port": Int64Type}}
+	typ := ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"names": ListType{ElemType: StringType},
+			"tags":  MapType{ElemType: StringType},
+			"ids":   SetType{ElemType: Int64Type},
+			"rules": ListType{ElemType: ruleType},
+			"empty": ListType{ElemType: StringType},
+		},
+	}
+	ctx := context.Background()
+	val := tftypes.NewValue(typ.TerraformType(ctx), map[string]tftypes.Value{
+		"names": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "hello"),
+			tftypes.NewValue(tftypes.String, nil),
+		}),
+		"tags": tftypes.NewValue(tftypes.Map{AttributeType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "prod"),
+		}),
+		"ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{
+			tftypes.NewValue(tftypes.Number, 1),
+		}),
+		"rules": tftypes.NewValue(tftypes.List{ElementType: ruleType.TerraformType(ctx)}, []tftypes.Value{
+			tftypes.NewValue(ruleType.TerraformType(ctx), map[string]tftypes.Value{
+				"port": tftypes.NewValue(tftypes.Number, 443),
+			}),
+		}),
+		"empty": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+	})
+
+	var got model
+	diags := reflect.Into(ctx, typ, val, &got, reflect.Options{})
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	expected := model{
+		Names: ListOf[String]{
+			ElemType: StringType,
+			Elems:    []String{{Value: "hello"}, {Null: true}},
+		},
+		Tags: MapOf[String]{
+			ElemType: StringType,
+			Elems:    map[string]String{"env": {Value: "prod"}},
+		},
+		IDs: SetOf[Int64]{
+			ElemType: Int64Type,
+			Elems:    []Int64{{Value: 1}},
+		},
+		Rules: ListOf[Object]{
+			ElemType: ruleType,
+			Elems: []Object{
+				{
+					AttrTypes: ruleType.AttrTypes,
+					Attrs:     map[string]attr.Value{"port": Int64{Value: 443}},
+				},
+			},
+		},
+		Empty: ListOf[String]{ElemType: StringType, Null: true},
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Fatalf("Unexpected result (+got, -expected): %s", diff)
+	}
+
+	roundTripped, diags := reflect.FromValue(ctx, typ, got, tftypes.NewAttributePath())
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	raw, err := roundTripped.ToTerraformValue(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if got := tftypes.NewValue(typ.TerraformType(ctx), raw); !got.Equal(val) {
+		t.Errorf("Expected %s, got %s", val, got)
+	}
+}
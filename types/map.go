@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -224,3 +225,17 @@ func (m Map) Equal(o attr.Value) bool {
 	}
 	return true
 }
+
+// String returns a human-readable representation of the Map, such as
+// { "key" = "value" }.
+func (m Map) String() string {
+	if m.Unknown {
+		return valuestring.Unknown
+	}
+
+	if m.Null {
+		return valuestring.Null
+	}
+
+	return attrsString(m.Elems)
+}
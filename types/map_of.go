@@ -0,0 +1,184 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+)
+
+var (
+	_ attr.Value                   = MapOf[String]{}
+	_ reflect.AttributeValueSetter = &MapOf[String]{}
+)
+
+// MapOf is a Map whose elements are all of the attr.Value implementation T,
+// such as MapOf[String]. It can be used anywhere a Map can, including as a
+// struct field with State.Get and Plan.Set, without type assertions on its
+// elements.
+//
+// No attr.Type creates MapOf values: Type returns a MapType, whose
+// ValueFromTerraform returns a Map, so a MapOf doesn't round-trip through
+// its type on its own. Use NewMapOf to convert the Map, or a MapOf struct
+// field with the reflection rules, which convert it automatically.
+type MapOf[T attr.Value] struct {
+	// Unknown will be set to true if the entire map is an unknown value.
+	// If only some of the elements in the map are unknown, their known or
+	// unknown status will be represented however T surfaces that
+	// information.
+	Unknown bool
+
+	// Null will be set to true if the map is null, either because it was
+	// omitted from the configuration, state, or plan, or because it was
+	// explicitly set to null.
+	Null bool
+
+	// Elems are the elements in the map.
+	Elems map[string]T
+
+	// ElemType is the attr.Type of the elements in the map. It must create
+	// values of type T.
+	ElemType attr.Type
+}
+
+// NewMapOf returns a MapOf with the elements of `m`, returning an error
+// diagnostic if any of them are not of type T.
+func NewMapOf[T attr.Value](m Map) (MapOf[T], diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elems, err := typedMapElems[T](m.Elems)
+	if err != nil {
+		diags.AddError(
+			"Map Element Conversion Error",
+			"An unexpected error was encountered trying to convert map elements. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return MapOf[T]{ElemType: m.ElemType}, diags
+	}
+
+	return MapOf[T]{
+		Unknown:  m.Unknown,
+		Null:     m.Null,
+		Elems:    elems,
+		ElemType: m.ElemType,
+	}, diags
+}
+
+// ToMap returns the Map equivalent of `m`.
+func (m MapOf[T]) ToMap() Map {
+	var elems map[string]attr.Value
+
+	if m.Elems != nil {
+		elems = make(map[string]attr.Value, len(m.Elems))
+		for key, elem := range m.Elems {
+			elems[key] = elem
+		}
+	}
+
+	return Map{
+		Unknown:  m.Unknown,
+		Null:     m.Null,
+		Elems:    elems,
+		ElemType: m.ElemType,
+	}
+}
+
+// Len returns the number of elements in the map.
+func (m MapOf[T]) Len() int {
+	return len(m.Elems)
+}
+
+// Element returns the element with the key `key`, and whether the map has an
+// element with that key.
+func (m MapOf[T]) Element(key string) (T, bool) {
+	elem, ok := m.Elems[key]
+	return elem, ok
+}
+
+// Keys returns the keys of the map in sorted order, for deterministic
+// iteration over its elements.
+func (m MapOf[T]) Keys() []string {
+	keys := make([]string, 0, len(m.Elems))
+
+	for key := range m.Elems {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// SetAttributeValue populates `m` from the Map `v`. It is used by the
+// reflection rules for State.Get, Plan.Get, and Config.Get.
+func (m *MapOf[T]) SetAttributeValue(_ context.Context, v attr.Value) error {
+	mapValue, ok := v.(Map)
+	if !ok {
+		return fmt.Errorf("cannot populate %T from %T, expected types.Map", m, v)
+	}
+
+	elems, err := typedMapElems[T](mapValue.Elems)
+	if err != nil {
+		return err
+	}
+
+	*m = MapOf[T]{
+		Unknown:  mapValue.Unknown,
+		Null:     mapValue.Null,
+		Elems:    elems,
+		ElemType: mapValue.ElemType,
+	}
+
+	return nil
+}
+
+// Type returns a MapType with the same element type as `m`. Its values
+// are Maps, not MapOfs.
+func (m MapOf[T]) Type(ctx context.Context) attr.Type {
+	return MapType{ElemType: m.ElemType}
+}
+
+// ToTerraformValue returns the data contained in the MapOf as a Go type that
+// tftypes.NewValue will accept.
+func (m MapOf[T]) ToTerraformValue(ctx context.Context) (interface{}, error) {
+	return m.ToMap().ToTerraformValue(ctx)
+}
+
+// Equal returns true if `o` is a MapOf[T] with the same elements as `m`.
+func (m MapOf[T]) Equal(o attr.Value) bool {
+	other, ok := o.(MapOf[T])
+	if !ok {
+		return false
+	}
+
+	return m.ToMap().Equal(other.ToMap())
+}
+
+// String returns a human-readable representation of the MapOf, such as
+// { "key" = "value" }.
+func (m MapOf[T]) String() string {
+	return m.ToMap().String()
+}
+
+// typedMapElems returns `in` as a map[string]T, or an error if any element is
+// not a T.
+func typedMapElems[T attr.Value](in map[string]attr.Value) (map[string]T, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]T, len(in))
+
+	for key, elem := range in {
+		typed, ok := elem.(T)
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("element %q is %T, not %T", key, elem, zero)
+		}
+		out[key] = typed
+	}
+
+	return out, nil
+}
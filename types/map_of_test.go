@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+)
+
+func TestNewMapOf(t *testing.T) {
+	t.Parallel()
+
+	input := Map{
+		ElemType: StringType,
+		Elems: map[string]attr.Value{
+			"b": String{Value: "world"},
+			"a": String{Value: "hello"},
+		},
+	}
+
+	got, diags := NewMapOf[String](input)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	if diff := cmp.Diff(got.Keys(), []string{"a", "b"}); diff != "" {
+		t.Errorf("Unexpected keys (+got, -expected): %s", diff)
+	}
+
+	elem, ok := got.Element("b")
+	if !ok || elem.Value != "world" {
+		t.Errorf("Expected element \"b\" to be \"world\", got %v (%v)", elem, ok)
+	}
+
+	if _, ok := got.Element("c"); ok {
+		t.Errorf("Expected no element \"c\"")
+	}
+
+	if diff := cmp.Diff(got.ToMap(), input); diff != "" {
+		t.Errorf("Unexpected ToMap result (+got, -expected): %s", diff)
+	}
+
+	if _, diags := NewMapOf[Bool](input); !diags.HasError() {
+		t.Errorf("Expected error converting map of strings to MapOf[Bool]")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -15,7 +16,7 @@ func numberValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value
 	if in.IsNull() {
 		return Number{Null: true}, nil
 	}
-	n := big.NewFloat(0)
+	n := new(big.Float)
 	err := in.As(&n)
 	if err != nil {
 		return nil, err
@@ -78,3 +79,17 @@ func (n Number) Equal(other attr.Value) bool {
 	}
 	return n.Value.Cmp(o.Value) == 0
 }
+
+// String returns a human-readable representation of the Number, such as 1.5,
+// null, or <unknown>.
+func (n Number) String() string {
+	if n.Unknown {
+		return valuestring.Unknown
+	}
+
+	if n.Null {
+		return valuestring.Null
+	}
+
+	return valuestring.Number(n.Value)
+}
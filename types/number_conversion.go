@@ -0,0 +1,129 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+)
+
+// NumberConversionOptions is a collection of toggles to control the behavior
+// of conversions between Number, Int64, and Float64 values.
+type NumberConversionOptions struct {
+	// AllowRoundingNumbers silently rounds numbers that don't fit
+	// perfectly in the type they're being converted to, rather than
+	// returning errors. Integers will always be rounded towards 0, and
+	// numbers outside the range of the type will be clamped to it.
+	AllowRoundingNumbers bool
+}
+
+// ToInt64 converts `n` to an Int64, returning an error diagnostic if the
+// number is not an integer or does not fit in 64 bits, unless
+// opts.AllowRoundingNumbers is true. Null and unknown values convert to null
+// and unknown values.
+func (n Number) ToInt64(opts NumberConversionOptions) (Int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case n.Unknown:
+		return Int64{Unknown: true}, diags
+	case n.Null:
+		return Int64{Null: true}, diags
+	case n.Value == nil:
+		diags.Append(numberConversionErrorDiagnostic(fmt.Errorf("cannot convert nil *big.Float to int64")))
+		return Int64{}, diags
+	}
+
+	i, err := reflect.BigFloatToInt64(n.Value, reflect.Options{
+		AllowRoundingNumbers: opts.AllowRoundingNumbers,
+	})
+	if err != nil {
+		diags.Append(numberConversionErrorDiagnostic(err))
+		return Int64{}, diags
+	}
+
+	return Int64{Value: i}, diags
+}
+
+// ToFloat64 converts `n` to a Float64, returning an error diagnostic if the
+// number cannot be represented exactly as a 64-bit floating point, unless
+// opts.AllowRoundingNumbers is true. Null and unknown values convert to null
+// and unknown values.
+func (n Number) ToFloat64(opts NumberConversionOptions) (Float64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case n.Unknown:
+		return Float64{Unknown: true}, diags
+	case n.Null:
+		return Float64{Null: true}, diags
+	case n.Value == nil:
+		diags.Append(numberConversionErrorDiagnostic(fmt.Errorf("cannot convert nil *big.Float to float64")))
+		return Float64{}, diags
+	}
+
+	f, err := reflect.BigFloatToFloat64(n.Value, reflect.Options{
+		AllowRoundingNumbers: opts.AllowRoundingNumbers,
+	})
+	if err != nil {
+		diags.Append(numberConversionErrorDiagnostic(err))
+		return Float64{}, diags
+	}
+
+	return Float64{Value: f}, diags
+}
+
+// ToNumber converts `i` to a Number. This conversion is always lossless.
+func (i Int64) ToNumber() Number {
+	switch {
+	case i.Unknown:
+		return Number{Unknown: true}
+	case i.Null:
+		return Number{Null: true}
+	}
+
+	return Number{Value: new(big.Float).SetInt64(i.Value)}
+}
+
+// ToFloat64 converts `i` to a Float64, returning an error diagnostic if the
+// integer cannot be represented exactly as a 64-bit floating point, unless
+// opts.AllowRoundingNumbers is true.
+func (i Int64) ToFloat64(opts NumberConversionOptions) (Float64, diag.Diagnostics) {
+	return i.ToNumber().ToFloat64(opts)
+}
+
+// ToNumber converts `f` to a Number. This conversion is lossless for every
+// value Terraform can represent. It panics if `f` is NaN, which Terraform
+// numbers can't hold; Float64 values read from Terraform are never NaN.
+func (f Float64) ToNumber() Number {
+	switch {
+	case f.Unknown:
+		return Number{Unknown: true}
+	case f.Null:
+		return Number{Null: true}
+	}
+
+	return Number{Value: big.NewFloat(f.Value)}
+}
+
+// ToInt64 converts `f` to an Int64, returning an error diagnostic if the
+// value is not an integer or does not fit in 64 bits, unless
+// opts.AllowRoundingNumbers is true. NaN is always an error.
+func (f Float64) ToInt64(opts NumberConversionOptions) (Int64, diag.Diagnostics) {
+	if !f.Unknown && !f.Null && math.IsNaN(f.Value) {
+		return Int64{}, diag.Diagnostics{
+			numberConversionErrorDiagnostic(fmt.Errorf("cannot convert NaN to int64")),
+		}
+	}
+
+	return f.ToNumber().ToInt64(opts)
+}
+
+func numberConversionErrorDiagnostic(err error) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Number Conversion Error",
+		"An unexpected error was encountered trying to convert a number. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+	)
+}
@@ -0,0 +1,296 @@
+package types
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestNumberToInt64(t *testing.T) {
+	t.Parallel()
+
+	overflow := new(big.Float).Add(big.NewFloat(math.MaxInt64), big.NewFloat(1024))
+
+	type testCase struct {
+		input         Number
+		opts          NumberConversionOptions
+		expected      Int64
+		expectedDiags diag.Diagnostics
+	}
+	tests := map[string]testCase{
+		"value": {
+			input:    Number{Value: big.NewFloat(123)},
+			expected: Int64{Value: 123},
+		},
+		"unknown": {
+			input:    Number{Unknown: true},
+			expected: Int64{Unknown: true},
+		},
+		"null": {
+			input:    Number{Null: true},
+			expected: Int64{Null: true},
+		},
+		"fraction": {
+			input:    Number{Value: big.NewFloat(123.5)},
+			expected: Int64{},
+			expectedDiags: diag.Diagnostics{
+				numberConversionErrorDiagnostic(errorString("cannot store 123.5 in int64")),
+			},
+		},
+		"fraction-rounding": {
+			input:    Number{Value: big.NewFloat(-123.5)},
+			opts:     NumberConversionOptions{AllowRoundingNumbers: true},
+			expected: Int64{Value: -123},
+		},
+		"overflow": {
+			input:    Number{Value: overflow},
+			expected: Int64{},
+			expectedDiags: diag.Diagnostics{
+				numberConversionErrorDiagnostic(errorString("cannot store " + overflow.String() + " in int64")),
+			},
+		},
+		"overflow-rounding": {
+			input:    Number{Value: overflow},
+			opts:     NumberConversionOptions{AllowRoundingNumbers: true},
+			expected: Int64{Value: math.MaxInt64},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := test.input.ToInt64(test.opts)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("Unexpected result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestNumberToFloat64(t *testing.T) {
+	t.Parallel()
+
+	precise, _, err := big.ParseFloat("0.1", 10, 512, big.ToNearestEven)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	type testCase struct {
+		input         Number
+		opts          NumberConversionOptions
+		expected      Float64
+		expectedDiags diag.Diagnostics
+	}
+	tests := map[string]testCase{
+		"value": {
+			input:    Number{Value: big.NewFloat(123.456)},
+			expected: Float64{Value: 123.456},
+		},
+		"unknown": {
+			input:    Number{Unknown: true},
+			expected: Float64{Unknown: true},
+		},
+		"null": {
+			input:    Number{Null: true},
+			expected: Float64{Null: true},
+		},
+		"inexact": {
+			input:    Number{Value: precise},
+			expected: Float64{},
+			expectedDiags: diag.Diagnostics{
+				numberConversionErrorDiagnostic(errorString("cannot store " + precise.String() + " in float64")),
+			},
+		},
+		"inexact-rounding": {
+			input:    Number{Value: precise},
+			opts:     NumberConversionOptions{AllowRoundingNumbers: true},
+			expected: Float64{Value: 0.1},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := test.input.ToFloat64(test.opts)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("Unexpected result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestInt64ToNumber(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		input    Int64
+		expected Number
+	}
+	tests := map[string]testCase{
+		"value": {
+			input:    Int64{Value: math.MaxInt64},
+			expected: Number{Value: new(big.Float).SetInt64(math.MaxInt64)},
+		},
+		"unknown": {
+			input:    Int64{Unknown: true},
+			expected: Number{Unknown: true},
+		},
+		"null": {
+			input:    Int64{Null: true},
+			expected: Number{Null: true},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.input.ToNumber()
+			if !got.Equal(test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFloat64ToNumber(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		input    Float64
+		expected Number
+	}
+	tests := map[string]testCase{
+		"value": {
+			input:    Float64{Value: -0.125},
+			expected: Number{Value: big.NewFloat(-0.125)},
+		},
+		"unknown": {
+			input:    Float64{Unknown: true},
+			expected: Number{Unknown: true},
+		},
+		"null": {
+			input:    Float64{Null: true},
+			expected: Number{Null: true},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.input.ToNumber()
+			if !got.Equal(test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFloat64ToNumberNaN(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected converting NaN to panic")
+		}
+	}()
+
+	Float64{Value: math.NaN()}.ToNumber()
+}
+
+func TestFloat64ToInt64(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		input         Float64
+		opts          NumberConversionOptions
+		expected      Int64
+		expectedDiags diag.Diagnostics
+	}
+	tests := map[string]testCase{
+		"value": {
+			input:    Float64{Value: 123},
+			expected: Int64{Value: 123},
+		},
+		"fraction": {
+			input:    Float64{Value: 1.5},
+			expected: Int64{},
+			expectedDiags: diag.Diagnostics{
+				numberConversionErrorDiagnostic(errorString("cannot store 1.5 in int64")),
+			},
+		},
+		"fraction-rounding": {
+			input:    Float64{Value: 1.5},
+			opts:     NumberConversionOptions{AllowRoundingNumbers: true},
+			expected: Int64{Value: 1},
+		},
+		"null": {
+			input:    Float64{Null: true},
+			expected: Int64{Null: true},
+		},
+		"nan": {
+			input:    Float64{Value: math.NaN()},
+			expected: Int64{},
+			expectedDiags: diag.Diagnostics{
+				numberConversionErrorDiagnostic(errorString("cannot convert NaN to int64")),
+			},
+		},
+		"nan-rounding": {
+			input:    Float64{Value: math.NaN()},
+			opts:     NumberConversionOptions{AllowRoundingNumbers: true},
+			expected: Int64{},
+			expectedDiags: diag.Diagnostics{
+				numberConversionErrorDiagnostic(errorString("cannot convert NaN to int64")),
+			},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := test.input.ToInt64(test.opts)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("Unexpected result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestInt64ToFloat64(t *testing.T) {
+	t.Parallel()
+
+	got, diags := Int64{Value: 1<<53 + 1}.ToFloat64(NumberConversionOptions{})
+	if !diags.HasError() {
+		t.Errorf("Expected error converting %d to Float64, got %v", int64(1<<53+1), got)
+	}
+
+	got, diags = Int64{Value: 1<<53 + 1}.ToFloat64(NumberConversionOptions{AllowRoundingNumbers: true})
+	if diags.HasError() {
+		t.Errorf("Unexpected diagnostics: %v", diags)
+	}
+	if got.Value != 1<<53 {
+		t.Errorf("Expected %d, got %f", int64(1<<53), got.Value)
+	}
+}
+
+type errorString string
+
+func (e errorString) Error() string {
+	return string(e)
+}
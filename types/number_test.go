@@ -15,6 +15,8 @@ func numberComparer(i, j *big.Float) bool {
 	return (i == nil && j == nil) || (i != nil && j != nil && i.Cmp(j) == 0)
 }
 
+var preciseNumber, _, _ = big.ParseFloat("123456789012345678901234567890.123456789", 10, 512, big.ToNearestEven)
+
 func TestNumberValueFromTerraform(t *testing.T) {
 	t.Parallel()
 
@@ -32,6 +34,10 @@ func testNumberValueFromTerraform(t *testing.T, direct bool) {
 			input:       tftypes.NewValue(tftypes.Number, 123),
 			expectation: Number{Value: big.NewFloat(123)},
 		},
+		"value-precise": {
+			input:       tftypes.NewValue(tftypes.Number, preciseNumber),
+			expectation: Number{Value: preciseNumber},
+		},
 		"unknown": {
 			input:       tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
 			expectation: Number{Unknown: true},
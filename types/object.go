@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -271,3 +272,17 @@ func (o Object) Equal(c attr.Value) bool {
 
 	return true
 }
+
+// String returns a human-readable representation of the Object, such as
+// { name = "example" }.
+func (o Object) String() string {
+	if o.Unknown {
+		return valuestring.Unknown
+	}
+
+	if o.Null {
+		return valuestring.Null
+	}
+
+	return attrsString(o.Attrs)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -107,7 +108,9 @@ func (t SetType) String() string {
 }
 
 // Validate implements type validation. This type requires all elements to be
-// unique.
+// unique. Duplicate elements are masked in the returned diagnostics if
+// they're sensitive, as reported by the function stored in `ctx` by the
+// framework.
 func (s SetType) Validate(ctx context.Context, in tftypes.Value, path *tftypes.AttributePath) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -136,28 +139,48 @@ func (s SetType) Validate(ctx context.Context, in tftypes.Value, path *tftypes.A
 		return diags
 	}
 
-	// Attempting to use map[tftypes.Value]struct{} for duplicate detection yields:
-	//   panic: runtime error: hash of unhashable type tftypes.primitive
-	// Instead, use for loops.
-	for indexOuter, elemOuter := range elems {
+	sensitive := valuestring.SensitiveFromContext(ctx)
+
+	// tftypes.Value is not hashable, so duplicates are detected using a
+	// canonical encoding of each element instead.
+	seen := make(map[string]struct{}, len(elems))
+
+	for _, elem := range elems {
 		// Only evaluate fully known values for duplicates.
-		if !elemOuter.IsFullyKnown() {
+		if !elem.IsFullyKnown() {
 			continue
 		}
 
-		for indexInner := indexOuter + 1; indexInner < len(elems); indexInner++ {
-			elemInner := elems[indexInner]
-
-			if !elemInner.Equal(elemOuter) {
-				continue
-			}
+		key, err := tftypesValueKey(elem)
 
+		if err != nil {
 			diags.AddAttributeError(
-				path.WithElementKeyValue(elemInner),
-				"Duplicate Set Element",
-				fmt.Sprintf("This attribute contains duplicate values of: %s", elemInner),
+				path,
+				"Set Type Validation Error",
+				"An unexpected error was encountered trying to validate an attribute value. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
 			)
+			return diags
+		}
+
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			continue
 		}
+
+		elemPath := path.WithElementKeyValue(elem)
+		detail := fmt.Sprintf("This attribute contains duplicate values of: %s", valuestring.Tftypes(elem, elemPath, sensitive))
+
+		// the element's path holds its value, so report sensitive
+		// elements at the set
+		if sensitive != nil && sensitive(elemPath) {
+			elemPath = path
+		}
+
+		diags.AddAttributeError(
+			elemPath,
+			"Duplicate Set Element",
+			detail,
+		)
 	}
 
 	return diags
@@ -239,7 +262,11 @@ func (s Set) ToTerraformValue(ctx context.Context) (interface{}, error) {
 }
 
 // Equal must return true if the AttributeValue is considered
-// semantically equal to the AttributeValue passed as an argument.
+// semantically equal to the AttributeValue passed as an argument. Sets are
+// equal if every element of each is equal to an element of the other. When
+// ElemType is one of the framework's types, elements are compared by their
+// Terraform values; otherwise, like with custom types, they're compared
+// with their Equal methods.
 func (s Set) Equal(o attr.Value) bool {
 	other, ok := o.(Set)
 	if !ok {
@@ -260,15 +287,210 @@ func (s Set) Equal(o attr.Value) bool {
 	if len(s.Elems) != len(other.Elems) {
 		return false
 	}
+
+	if len(s.Elems) == 0 {
+		return true
+	}
+
+	ctx := context.Background()
+
+	// Compare the canonical Terraform values of the elements when
+	// that's what Equal would do, otherwise fall back to attr.Value.Equal.
+	// Both sides are checked, so sets holding duplicates compare the same
+	// either way.
+	if s.ElemType != nil && comparesByTerraformValue(s.ElemType) {
+		keys, err := s.elementKeys(ctx)
+
+		if err == nil {
+			otherKeys, err := other.elementKeys(ctx)
+
+			if err == nil {
+				if len(keys) != len(otherKeys) {
+					return false
+				}
+
+				for key := range keys {
+					if _, ok := otherKeys[key]; !ok {
+						return false
+					}
+				}
+
+				return true
+			}
+		}
+	}
+
 	for _, elem := range s.Elems {
-		if !other.contains(elem) {
+		if !other.containsEqual(elem) {
+			return false
+		}
+	}
+
+	for _, elem := range other.Elems {
+		if !s.containsEqual(elem) {
 			return false
 		}
 	}
+
 	return true
 }
 
-func (s Set) contains(v attr.Value) bool {
+// Contains returns true if the Set has an element whose Terraform value is
+// equal to `v`. An unknown or null Set contains no elements.
+func (s Set) Contains(ctx context.Context, v attr.Value) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if s.Unknown || s.Null {
+		return false, diags
+	}
+
+	if s.ElemType == nil {
+		diags.AddError(
+			"Set Operation Error",
+			"An unexpected error was encountered trying to compare set elements. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+				"cannot look up an element in a set with no element type",
+		)
+		return false, diags
+	}
+
+	keys, err := s.elementKeys(ctx)
+
+	if err != nil {
+		diags.Append(setOperationErrorDiagnostic(err))
+		return false, diags
+	}
+
+	key, err := setElementKey(ctx, s.ElemType, v)
+
+	if err != nil {
+		diags.Append(setOperationErrorDiagnostic(err))
+		return false, diags
+	}
+
+	_, ok := keys[key]
+
+	return ok, diags
+}
+
+// Union returns a Set containing the elements of `s` followed by the elements
+// of `other` that are not in `s`.
+//
+// If either Set is unknown, the result is unknown. A null Set is treated as
+// an empty Set. Both Sets must have the same element type.
+func (s Set) Union(ctx context.Context, other Set) (Set, diag.Diagnostics) {
+	return s.operation(ctx, other, func(inS, inOther bool) bool {
+		return inS || inOther
+	})
+}
+
+// Intersection returns a Set containing the elements of `s` that are also in
+// `other`.
+//
+// If either Set is unknown, the result is unknown. A null Set is treated as
+// an empty Set. Both Sets must have the same element type.
+func (s Set) Intersection(ctx context.Context, other Set) (Set, diag.Diagnostics) {
+	return s.operation(ctx, other, func(inS, inOther bool) bool {
+		return inS && inOther
+	})
+}
+
+// Difference returns a Set containing the elements of `s` that are not in
+// `other`.
+//
+// If either Set is unknown, the result is unknown. A null Set is treated as
+// an empty Set. Both Sets must have the same element type.
+func (s Set) Difference(ctx context.Context, other Set) (Set, diag.Diagnostics) {
+	return s.operation(ctx, other, func(inS, inOther bool) bool {
+		return inS && !inOther
+	})
+}
+
+// operation returns a Set containing every element of `s` and `other`, in
+// that order and without duplicates, for which `include` returns true.
+func (s Set) operation(ctx context.Context, other Set, include func(inS, inOther bool) bool) (Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	result := Set{
+		ElemType: s.ElemType,
+	}
+
+	if s.ElemType == nil || !s.ElemType.Equal(other.ElemType) {
+		diags.AddError(
+			"Set Operation Error",
+			"An unexpected error was encountered trying to combine sets. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+				fmt.Sprintf("cannot combine set with element type %v and set with element type %v", s.ElemType, other.ElemType),
+		)
+		return result, diags
+	}
+
+	if s.Unknown || other.Unknown {
+		result.Unknown = true
+		return result, diags
+	}
+
+	sKeys, err := s.elementKeys(ctx)
+
+	if err != nil {
+		diags.Append(setOperationErrorDiagnostic(err))
+		return result, diags
+	}
+
+	otherKeys, err := other.elementKeys(ctx)
+
+	if err != nil {
+		diags.Append(setOperationErrorDiagnostic(err))
+		return result, diags
+	}
+
+	seen := make(map[string]struct{}, len(sKeys)+len(otherKeys))
+	result.Elems = []attr.Value{}
+
+	for _, candidates := range []Set{s, other} {
+		for _, elem := range candidates.Elems {
+			key, err := setElementKey(ctx, s.ElemType, elem)
+
+			if err != nil {
+				diags.Append(setOperationErrorDiagnostic(err))
+				return Set{ElemType: s.ElemType}, diags
+			}
+
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			_, inS := sKeys[key]
+			_, inOther := otherKeys[key]
+
+			if include(inS, inOther) {
+				result.Elems = append(result.Elems, elem)
+			}
+		}
+	}
+
+	return result, diags
+}
+
+// elementKeys returns the keys of the elements of the Set, as generated by
+// setElementKey.
+func (s Set) elementKeys(ctx context.Context) (map[string]struct{}, error) {
+	keys := make(map[string]struct{}, len(s.Elems))
+
+	for _, elem := range s.Elems {
+		key, err := setElementKey(ctx, s.ElemType, elem)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys[key] = struct{}{}
+	}
+
+	return keys, nil
+}
+
+func (s Set) containsEqual(v attr.Value) bool {
 	for _, elem := range s.Elems {
 		if elem.Equal(v) {
 			return true
@@ -277,3 +499,24 @@ func (s Set) contains(v attr.Value) bool {
 
 	return false
 }
+
+func setOperationErrorDiagnostic(err error) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Set Operation Error",
+		"An unexpected error was encountered trying to compare set elements. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+	)
+}
+
+// String returns a human-readable representation of the Set, such as
+// ["a", "b"].
+func (s Set) String() string {
+	if s.Unknown {
+		return valuestring.Unknown
+	}
+
+	if s.Null {
+		return valuestring.Null
+	}
+
+	return elemsString(s.Elems)
+}
@@ -0,0 +1,178 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// setElementKey returns a string that uniquely identifies the Terraform
+// value of `v`, for use as a map key when comparing set elements. Two
+// elements with the same key have equal Terraform values.
+func setElementKey(ctx context.Context, elemType attr.Type, v attr.Value) (string, error) {
+	val, err := v.ToTerraformValue(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	typ := elemType.TerraformType(ctx)
+
+	err = tftypes.ValidateValue(typ, val)
+	if err != nil {
+		return "", fmt.Errorf("error validating terraform type: %w", err)
+	}
+
+	return tftypesValueKey(tftypes.NewValue(typ, val))
+}
+
+// comparesByTerraformValue returns true if the Equal method of values of
+// `typ` returns true exactly when their Terraform values are equal, so they
+// can be compared using setElementKey. This holds for the framework's own
+// types, but not necessarily for others, like custom types.
+func comparesByTerraformValue(typ attr.Type) bool {
+	switch typ := typ.(type) {
+	case primitive:
+		return true
+	case ListType:
+		return comparesByTerraformValue(typ.ElemType)
+	case SetType:
+		return comparesByTerraformValue(typ.ElemType)
+	case MapType:
+		return comparesByTerraformValue(typ.ElemType)
+	case ObjectType:
+		for _, attrType := range typ.AttrTypes {
+			if !comparesByTerraformValue(attrType) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// tftypesValueKey returns a canonical string encoding of `in`. Strings are
+// quoted, numbers are encoded exactly regardless of their precision, and the
+// elements of sets and the keys of maps and objects are sorted, so that
+// values Terraform considers equal always produce the same encoding.
+func tftypesValueKey(in tftypes.Value) (string, error) {
+	var b strings.Builder
+
+	err := writeTftypesValueKey(&b, in)
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func writeTftypesValueKey(b *strings.Builder, in tftypes.Value) error {
+	if !in.IsKnown() {
+		b.WriteString("?")
+		return nil
+	}
+
+	if in.IsNull() {
+		b.WriteString("~")
+		return nil
+	}
+
+	typ := in.Type()
+
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := in.As(&s); err != nil {
+			return err
+		}
+		b.WriteString(strconv.Quote(s))
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := in.As(&n); err != nil {
+			return err
+		}
+		b.WriteString("n")
+		// Text('p', 0) is an exact encoding that doesn't depend on the
+		// precision of the *big.Float, but it distinguishes -0 from 0.
+		if n.Sign() == 0 {
+			b.WriteString("0")
+		} else {
+			b.WriteString(n.Text('p', 0))
+		}
+		b.WriteString(";")
+	case typ.Is(tftypes.Bool):
+		var v bool
+		if err := in.As(&v); err != nil {
+			return err
+		}
+		if v {
+			b.WriteString("T")
+		} else {
+			b.WriteString("F")
+		}
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := in.As(&elems); err != nil {
+			return err
+		}
+		b.WriteString("[")
+		for _, elem := range elems {
+			if err := writeTftypesValueKey(b, elem); err != nil {
+				return err
+			}
+			b.WriteString(",")
+		}
+		b.WriteString("]")
+	case typ.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		if err := in.As(&elems); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(elems))
+		for _, elem := range elems {
+			key, err := tftypesValueKey(elem)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteString("<")
+		for _, key := range keys {
+			b.WriteString(key)
+			b.WriteString(",")
+		}
+		b.WriteString(">")
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		elems := map[string]tftypes.Value{}
+		if err := in.As(&elems); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(elems))
+		for key := range elems {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteString("{")
+		for _, key := range keys {
+			b.WriteString(strconv.Quote(key))
+			b.WriteString("=")
+			if err := writeTftypesValueKey(b, elems[key]); err != nil {
+				return err
+			}
+			b.WriteString(",")
+		}
+		b.WriteString("}")
+	default:
+		return fmt.Errorf("unsupported type %s", typ)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/reflect"
+)
+
+var (
+	_ attr.Value                   = SetOf[String]{}
+	_ reflect.AttributeValueSetter = &SetOf[String]{}
+)
+
+// SetOf is a Set whose elements are all of the attr.Value implementation
+// T, such as SetOf[String]. It can be used anywhere a Set can, including
+// as a struct field with State.Get and Plan.Set, without type assertions on
+// its elements.
+//
+// No attr.Type creates SetOf values: Type returns a SetType, whose
+// ValueFromTerraform returns a Set, so a SetOf doesn't round-trip through
+// its type on its own. Use NewSetOf to convert the Set, or a SetOf struct
+// field with the reflection rules, which convert it automatically.
+type SetOf[T attr.Value] struct {
+	// Unknown will be set to true if the entire set is an unknown value.
+	// If only some of the elements in the set are unknown, their known or
+	// unknown status will be represented however T surfaces that
+	// information.
+	Unknown bool
+
+	// Null will be set to true if the set is null, either because it was
+	// omitted from the configuration, state, or plan, or because it was
+	// explicitly set to null.
+	Null bool
+
+	// Elems are the elements in the set.
+	Elems []T
+
+	// ElemType is the attr.Type of the elements in the set. It must
+	// create values of type T.
+	ElemType attr.Type
+}
+
+// NewSetOf returns a SetOf with the elements of `s`, returning an error
+// diagnostic if any of them are not of type T.
+func NewSetOf[T attr.Value](s Set) (SetOf[T], diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elems, err := typedElems[T](s.Elems)
+	if err != nil {
+		diags.AddError(
+			"Set Element Conversion Error",
+			"An unexpected error was encountered trying to convert set elements. This is always an error in the provider. Please report the following to the provider developer:\n\n"+err.Error(),
+		)
+		return SetOf[T]{ElemType: s.ElemType}, diags
+	}
+
+	return SetOf[T]{
+		Unknown:  s.Unknown,
+		Null:     s.Null,
+		Elems:    elems,
+		ElemType: s.ElemType,
+	}, diags
+}
+
+// ToSet returns the Set equivalent of `s`.
+func (s SetOf[T]) ToSet() Set {
+	var elems []attr.Value
+
+	if s.Elems != nil {
+		elems = make([]attr.Value, 0, len(s.Elems))
+		for _, elem := range s.Elems {
+			elems = append(elems, elem)
+		}
+	}
+
+	return Set{
+		Unknown:  s.Unknown,
+		Null:     s.Null,
+		Elems:    elems,
+		ElemType: s.ElemType,
+	}
+}
+
+// Len returns the number of elements in the set.
+func (s SetOf[T]) Len() int {
+	return len(s.Elems)
+}
+
+// Element returns the element at index `i` of Elems, and whether the set has
+// an element at that index. Sets are unordered, so the index only identifies
+// an element of this SetOf; use Contains to check for a value.
+func (s SetOf[T]) Element(i int) (T, bool) {
+	if i < 0 || i >= len(s.Elems) {
+		var zero T
+		return zero, false
+	}
+
+	return s.Elems[i], true
+}
+
+// Contains returns true if the set has an element whose Terraform value is
+// equal to `v`. An unknown or null set contains no elements.
+func (s SetOf[T]) Contains(ctx context.Context, v T) (bool, diag.Diagnostics) {
+	return s.ToSet().Contains(ctx, v)
+}
+
+// SetAttributeValue populates `s` from the Set `v`. It is used by the
+// reflection rules for State.Get, Plan.Get, and Config.Get.
+func (s *SetOf[T]) SetAttributeValue(_ context.Context, v attr.Value) error {
+	set, ok := v.(Set)
+	if !ok {
+		return fmt.Errorf("cannot populate %T from %T, expected types.Set", s, v)
+	}
+
+	elems, err := typedElems[T](set.Elems)
+	if err != nil {
+		return err
+	}
+
+	*s = SetOf[T]{
+		Unknown:  set.Unknown,
+		Null:     set.Null,
+		Elems:    elems,
+		ElemType: set.ElemType,
+	}
+
+	return nil
+}
+
+// Type returns a SetType with the same element type as `s`. Its values
+// are Sets, not SetOfs.
+func (s SetOf[T]) Type(ctx context.Context) attr.Type {
+	return SetType{ElemType: s.ElemType}
+}
+
+// ToTerraformValue returns the data contained in the SetOf as a Go type that
+// tftypes.NewValue will accept.
+func (s SetOf[T]) ToTerraformValue(ctx context.Context) (interface{}, error) {
+	return s.ToSet().ToTerraformValue(ctx)
+}
+
+// Equal returns true if `o` is a SetOf[T] with the same elements as `s`.
+func (s SetOf[T]) Equal(o attr.Value) bool {
+	other, ok := o.(SetOf[T])
+	if !ok {
+		return false
+	}
+
+	return s.ToSet().Equal(other.ToSet())
+}
+
+// String returns a human-readable representation of the SetOf, such as
+// ["a", "b"].
+func (s SetOf[T]) String() string {
+	return s.ToSet().String()
+}
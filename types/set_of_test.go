@@ -0,0 +1,247 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestNewSetOf(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		input         Set
+		expected      SetOf[String]
+		expectedDiags diag.Diagnostics
+	}
+	tests := map[string]testCase{
+		"value": {
+			input: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Unknown: true},
+				},
+			},
+			expected: SetOf[String]{
+				ElemType: StringType,
+				Elems: []String{
+					{Value: "hello"},
+					{Unknown: true},
+				},
+			},
+		},
+		"duplicates": {
+			input: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "hello"},
+				},
+			},
+			expected: SetOf[String]{
+				ElemType: StringType,
+				Elems: []String{
+					{Value: "hello"},
+					{Value: "hello"},
+				},
+			},
+		},
+		"null": {
+			input:    Set{ElemType: StringType, Null: true},
+			expected: SetOf[String]{ElemType: StringType, Null: true},
+		},
+		"unknown": {
+			input:    Set{ElemType: StringType, Unknown: true},
+			expected: SetOf[String]{ElemType: StringType, Unknown: true},
+		},
+		"wrong-element-type": {
+			input: Set{
+				ElemType: BoolType,
+				Elems: []attr.Value{
+					Bool{Value: true},
+				},
+			},
+			expected: SetOf[String]{ElemType: BoolType},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Set Element Conversion Error",
+					"An unexpected error was encountered trying to convert set elements. This is always an error in the provider. Please report the following to the provider developer:\n\nelement 0 is types.Bool, not types.String",
+				),
+			},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := NewSetOf[String](test.input)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("Unexpected result (+got, -expected): %s", diff)
+			}
+			if diags.HasError() {
+				return
+			}
+			if diff := cmp.Diff(got.ToSet(), test.input); diff != "" {
+				t.Errorf("Unexpected ToSet result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSetOfElement(t *testing.T) {
+	t.Parallel()
+
+	set := SetOf[String]{
+		ElemType: StringType,
+		Elems: []String{
+			{Value: "hello"},
+			{Value: "world"},
+		},
+	}
+
+	if set.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", set.Len())
+	}
+
+	got, ok := set.Element(1)
+	if !ok || got.Value != "world" {
+		t.Errorf("Expected element 1 to be \"world\", got %v (%v)", got, ok)
+	}
+
+	_, ok = set.Element(2)
+	if ok {
+		t.Errorf("Expected no element at index 2")
+	}
+}
+
+func TestSetOfContains(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		receiver SetOf[String]
+		input    String
+		expected bool
+	}
+	tests := map[string]testCase{
+		"contains": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}, {Value: "world"}}},
+			input:    String{Value: "world"},
+			expected: true,
+		},
+		"missing": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    String{Value: "world"},
+			expected: false,
+		},
+		"null-element": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Null: true}}},
+			input:    String{Null: true},
+			expected: true,
+		},
+		"null": {
+			receiver: SetOf[String]{ElemType: StringType, Null: true},
+			input:    String{Value: "hello"},
+			expected: false,
+		},
+		"unknown": {
+			receiver: SetOf[String]{ElemType: StringType, Unknown: true},
+			input:    String{Value: "hello"},
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := test.receiver.Contains(context.Background(), test.input)
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if got != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetOfEqual(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		receiver SetOf[String]
+		input    attr.Value
+		expected bool
+	}
+	tests := map[string]testCase{
+		"equal": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}, {Value: "world"}}},
+			input:    SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}, {Value: "world"}}},
+			expected: true,
+		},
+		"different-order": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}, {Value: "world"}}},
+			input:    SetOf[String]{ElemType: StringType, Elems: []String{{Value: "world"}, {Value: "hello"}}},
+			expected: true,
+		},
+		"different-elements": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    SetOf[String]{ElemType: StringType, Elems: []String{{Value: "world"}}},
+			expected: false,
+		},
+		"duplicates": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}, {Value: "hello"}}},
+			input:    SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}, {Value: "world"}}},
+			expected: false,
+		},
+		"null-null": {
+			receiver: SetOf[String]{ElemType: StringType, Null: true},
+			input:    SetOf[String]{ElemType: StringType, Null: true},
+			expected: true,
+		},
+		"null-empty": {
+			receiver: SetOf[String]{ElemType: StringType, Null: true},
+			input:    SetOf[String]{ElemType: StringType},
+			expected: false,
+		},
+		"unknown-unknown": {
+			receiver: SetOf[String]{ElemType: StringType, Unknown: true},
+			input:    SetOf[String]{ElemType: StringType, Unknown: true},
+			expected: true,
+		},
+		"unknown-null": {
+			receiver: SetOf[String]{ElemType: StringType, Unknown: true},
+			input:    SetOf[String]{ElemType: StringType, Null: true},
+			expected: false,
+		},
+		"set": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    Set{ElemType: StringType, Elems: []attr.Value{String{Value: "hello"}}},
+			expected: false,
+		},
+		"nil": {
+			receiver: SetOf[String]{ElemType: StringType, Elems: []String{{Value: "hello"}}},
+			input:    nil,
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.receiver.Equal(test.input)
+			if got != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -2,12 +2,15 @@ package types
 
 import (
 	"context"
+	"math/big"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -354,6 +357,7 @@ func TestSetTypeValidate(t *testing.T) {
 
 	testCases := map[string]struct {
 		in            tftypes.Value
+		sensitive     func(*tftypes.AttributePath) bool
 		expectedDiags diag.Diagnostics
 	}{
 		"null": {
@@ -388,10 +392,21 @@ func TestSetTypeValidate(t *testing.T) {
 				diag.NewAttributeErrorDiagnostic(
 					tftypes.NewAttributePath().WithAttributeName("test").WithElementKeyValue(tftypes.NewValue(tftypes.String, nil)),
 					"Duplicate Set Element",
-					"This attribute contains duplicate values of: tftypes.String<null>",
+					"This attribute contains duplicate values of: null",
 				),
 			},
 		},
+		"precise-numbers": {
+			in: tftypes.NewValue(
+				tftypes.Set{
+					ElementType: tftypes.Number,
+				},
+				[]tftypes.Value{
+					tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(1<<53)),
+					tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(1<<53+1)),
+				},
+			),
+		},
 		"unknown": {
 			in: tftypes.NewValue(
 				tftypes.Set{
@@ -478,7 +493,28 @@ func TestSetTypeValidate(t *testing.T) {
 				diag.NewAttributeErrorDiagnostic(
 					tftypes.NewAttributePath().WithAttributeName("test").WithElementKeyValue(tftypes.NewValue(tftypes.String, "hello")),
 					"Duplicate Set Element",
-					"This attribute contains duplicate values of: tftypes.String<\"hello\">",
+					"This attribute contains duplicate values of: \"hello\"",
+				),
+			},
+		},
+		"values-duplicates-sensitive": {
+			in: tftypes.NewValue(
+				tftypes.Set{
+					ElementType: tftypes.String,
+				},
+				[]tftypes.Value{
+					tftypes.NewValue(tftypes.String, "hello"),
+					tftypes.NewValue(tftypes.String, "hello"),
+				},
+			),
+			sensitive: func(path *tftypes.AttributePath) bool {
+				return len(path.Steps()) > 0
+			},
+			expectedDiags: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					tftypes.NewAttributePath().WithAttributeName("test"),
+					"Duplicate Set Element",
+					"This attribute contains duplicate values of: <sensitive>",
 				),
 			},
 		},
@@ -498,7 +534,7 @@ func TestSetTypeValidate(t *testing.T) {
 				diag.NewAttributeErrorDiagnostic(
 					tftypes.NewAttributePath().WithAttributeName("test").WithElementKeyValue(tftypes.NewValue(tftypes.String, "hello")),
 					"Duplicate Set Element",
-					"This attribute contains duplicate values of: tftypes.String<\"hello\">",
+					"This attribute contains duplicate values of: \"hello\"",
 				),
 			},
 		},
@@ -508,7 +544,12 @@ func TestSetTypeValidate(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			diags := SetType{}.Validate(context.Background(), testCase.in, tftypes.NewAttributePath().WithAttributeName("test"))
+			ctx := context.Background()
+			if testCase.sensitive != nil {
+				ctx = valuestring.WithSensitive(ctx, testCase.sensitive)
+			}
+
+			diags := SetType{}.Validate(ctx, testCase.in, tftypes.NewAttributePath().WithAttributeName("test"))
 
 			if diff := cmp.Diff(diags, testCase.expectedDiags); diff != "" {
 				t.Errorf("Unexpected diagnostics (+got, -expected): %s", diff)
@@ -614,6 +655,40 @@ func TestSetEqual(t *testing.T) {
 		expected bool
 	}
 	tests := map[string]testCase{
+		"duplicates-set-value": {
+			receiver: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "hello"},
+				},
+			},
+			input: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "world"},
+				},
+			},
+			expected: false,
+		},
+		"set-value-duplicates": {
+			receiver: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "world"},
+				},
+			},
+			input: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "hello"},
+				},
+			},
+			expected: false,
+		},
 		"set-value-set-value": {
 			receiver: Set{
 				ElemType: StringType,
@@ -666,6 +741,36 @@ func TestSetEqual(t *testing.T) {
 			},
 			expected: false,
 		},
+		"custom-element-equal": {
+			receiver: Set{
+				ElemType: caseInsensitiveStringType{},
+				Elems: []attr.Value{
+					caseInsensitiveString{String{Value: "hello"}},
+				},
+			},
+			input: Set{
+				ElemType: caseInsensitiveStringType{},
+				Elems: []attr.Value{
+					caseInsensitiveString{String{Value: "HELLO"}},
+				},
+			},
+			expected: true,
+		},
+		"custom-element-diff": {
+			receiver: Set{
+				ElemType: caseInsensitiveStringType{},
+				Elems: []attr.Value{
+					caseInsensitiveString{String{Value: "hello"}},
+				},
+			},
+			input: Set{
+				ElemType: caseInsensitiveStringType{},
+				Elems: []attr.Value{
+					caseInsensitiveString{String{Value: "world"}},
+				},
+			},
+			expected: false,
+		},
 		"set-value-type-diff": {
 			receiver: Set{
 				ElemType: StringType,
@@ -887,6 +992,65 @@ func TestSetEqual(t *testing.T) {
 			input:    nil,
 			expected: false,
 		},
+		"set-value-order-diff": {
+			receiver: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "world"},
+				},
+			},
+			input: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "world"},
+					String{Value: "hello"},
+				},
+			},
+			expected: true,
+		},
+		"set-value-number-precision-diff": {
+			receiver: Set{
+				ElemType: NumberType,
+				Elems: []attr.Value{
+					Number{Value: big.NewFloat(1.5)},
+				},
+			},
+			input: Set{
+				ElemType: NumberType,
+				Elems: []attr.Value{
+					Number{Value: new(big.Float).SetPrec(512).SetFloat64(1.5)},
+				},
+			},
+			expected: true,
+		},
+		"set-of-sets-value-order-diff": {
+			receiver: Set{
+				ElemType: SetType{ElemType: StringType},
+				Elems: []attr.Value{
+					Set{
+						ElemType: StringType,
+						Elems: []attr.Value{
+							String{Value: "hello"},
+							String{Value: "world"},
+						},
+					},
+				},
+			},
+			input: Set{
+				ElemType: SetType{ElemType: StringType},
+				Elems: []attr.Value{
+					Set{
+						ElemType: StringType,
+						Elems: []attr.Value{
+							String{Value: "world"},
+							String{Value: "hello"},
+						},
+					},
+				},
+			},
+			expected: true,
+		},
 	}
 	for name, test := range tests {
 		name, test := name, test
@@ -900,3 +1064,282 @@ func TestSetEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestSetContains(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		receiver      Set
+		input         attr.Value
+		expected      bool
+		expectedError bool
+	}
+	tests := map[string]testCase{
+		"present": {
+			receiver: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "world"},
+				},
+			},
+			input:    String{Value: "world"},
+			expected: true,
+		},
+		"absent": {
+			receiver: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Value: "world"},
+				},
+			},
+			input:    String{Value: "moon"},
+			expected: false,
+		},
+		"null-element": {
+			receiver: Set{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "hello"},
+					String{Null: true},
+				},
+			},
+			input:    String{Null: true},
+			expected: true,
+		},
+		"object-element": {
+			receiver: Set{
+				ElemType: ObjectType{AttrTypes: map[string]attr.Type{"port": NumberType}},
+				Elems: []attr.Value{
+					Object{
+						AttrTypes: map[string]attr.Type{"port": NumberType},
+						Attrs:     map[string]attr.Value{"port": Number{Value: big.NewFloat(443)}},
+					},
+				},
+			},
+			input: Object{
+				AttrTypes: map[string]attr.Type{"port": NumberType},
+				Attrs:     map[string]attr.Value{"port": Number{Value: big.NewFloat(443)}},
+			},
+			expected: true,
+		},
+		"unknown-set": {
+			receiver: Set{
+				ElemType: StringType,
+				Unknown:  true,
+			},
+			input:    String{Value: "hello"},
+			expected: false,
+		},
+		"no-element-type": {
+			receiver:      Set{},
+			input:         String{Value: "hello"},
+			expectedError: true,
+		},
+		"null-set": {
+			receiver: Set{
+				ElemType: StringType,
+				Null:     true,
+			},
+			input:    String{Value: "hello"},
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := test.receiver.Contains(context.Background(), test.input)
+			if diags.HasError() != test.expectedError {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if got != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	t.Parallel()
+
+	stringSet := func(values ...string) Set {
+		set := Set{
+			ElemType: StringType,
+			Elems:    []attr.Value{},
+		}
+		for _, value := range values {
+			set.Elems = append(set.Elems, String{Value: value})
+		}
+		return set
+	}
+
+	type testCase struct {
+		receiver             Set
+		input                Set
+		expectedUnion        Set
+		expectedIntersection Set
+		expectedDifference   Set
+		expectedDiags        diag.Diagnostics
+	}
+	tests := map[string]testCase{
+		"overlapping": {
+			receiver:             stringSet("a", "b", "c"),
+			input:                stringSet("c", "d", "b"),
+			expectedUnion:        stringSet("a", "b", "c", "d"),
+			expectedIntersection: stringSet("b", "c"),
+			expectedDifference:   stringSet("a"),
+		},
+		"disjoint": {
+			receiver:             stringSet("a", "b"),
+			input:                stringSet("c"),
+			expectedUnion:        stringSet("a", "b", "c"),
+			expectedIntersection: stringSet(),
+			expectedDifference:   stringSet("a", "b"),
+		},
+		"duplicates": {
+			receiver:             stringSet("a", "a", "b"),
+			input:                stringSet("b", "b"),
+			expectedUnion:        stringSet("a", "b"),
+			expectedIntersection: stringSet("b"),
+			expectedDifference:   stringSet("a"),
+		},
+		"null-receiver": {
+			receiver:             Set{ElemType: StringType, Null: true},
+			input:                stringSet("a"),
+			expectedUnion:        stringSet("a"),
+			expectedIntersection: stringSet(),
+			expectedDifference:   stringSet(),
+		},
+		"null-input": {
+			receiver:             stringSet("a"),
+			input:                Set{ElemType: StringType, Null: true},
+			expectedUnion:        stringSet("a"),
+			expectedIntersection: stringSet(),
+			expectedDifference:   stringSet("a"),
+		},
+		"unknown-receiver": {
+			receiver:             Set{ElemType: StringType, Unknown: true},
+			input:                stringSet("a"),
+			expectedUnion:        Set{ElemType: StringType, Unknown: true},
+			expectedIntersection: Set{ElemType: StringType, Unknown: true},
+			expectedDifference:   Set{ElemType: StringType, Unknown: true},
+		},
+		"unknown-input": {
+			receiver:             stringSet("a"),
+			input:                Set{ElemType: StringType, Unknown: true},
+			expectedUnion:        Set{ElemType: StringType, Unknown: true},
+			expectedIntersection: Set{ElemType: StringType, Unknown: true},
+			expectedDifference:   Set{ElemType: StringType, Unknown: true},
+		},
+		"element-type-mismatch": {
+			receiver: stringSet("a"),
+			input: Set{
+				ElemType: BoolType,
+				Elems:    []attr.Value{Bool{Value: true}},
+			},
+			expectedUnion:        Set{ElemType: StringType},
+			expectedIntersection: Set{ElemType: StringType},
+			expectedDifference:   Set{ElemType: StringType},
+			expectedDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Set Operation Error",
+					"An unexpected error was encountered trying to combine sets. This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+						"cannot combine set with element type types.StringType and set with element type types.BoolType",
+				),
+			},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			union, diags := test.receiver.Union(ctx, test.input)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected Union diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(union, test.expectedUnion); diff != "" {
+				t.Errorf("Unexpected Union result (+got, -expected): %s", diff)
+			}
+
+			intersection, diags := test.receiver.Intersection(ctx, test.input)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected Intersection diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(intersection, test.expectedIntersection); diff != "" {
+				t.Errorf("Unexpected Intersection result (+got, -expected): %s", diff)
+			}
+
+			difference, diags := test.receiver.Difference(ctx, test.input)
+			if diff := cmp.Diff(diags, test.expectedDiags); diff != "" {
+				t.Errorf("Unexpected Difference diagnostics (+got, -expected): %s", diff)
+			}
+			if diff := cmp.Diff(difference, test.expectedDifference); diff != "" {
+				t.Errorf("Unexpected Difference result (+got, -expected): %s", diff)
+			}
+		})
+	}
+}
+
+var benchEqual bool // Prevent compiler optimization
+
+func benchmarkSetEqual(b *testing.B, elementCount int) {
+	elems := make([]attr.Value, 0, elementCount)
+	reversed := make([]attr.Value, 0, elementCount)
+
+	for idx := 0; idx < elementCount; idx++ {
+		elems = append(elems, String{Value: strconv.Itoa(idx)})
+		reversed = append(reversed, String{Value: strconv.Itoa(elementCount - idx - 1)})
+	}
+
+	var equal bool // Prevent compiler optimization
+	set := Set{ElemType: StringType, Elems: elems}
+	other := Set{ElemType: StringType, Elems: reversed}
+
+	for n := 0; n < b.N; n++ {
+		equal = set.Equal(other)
+	}
+
+	benchEqual = equal
+}
+
+func BenchmarkSetEqual10(b *testing.B) {
+	benchmarkSetEqual(b, 10)
+}
+
+func BenchmarkSetEqual100(b *testing.B) {
+	benchmarkSetEqual(b, 100)
+}
+
+func BenchmarkSetEqual1000(b *testing.B) {
+	benchmarkSetEqual(b, 1000)
+}
+
+func BenchmarkSetEqual10000(b *testing.B) {
+	benchmarkSetEqual(b, 10000)
+}
+
+// caseInsensitiveStringType is a custom type whose values are equal if they
+// only differ in case.
+type caseInsensitiveStringType struct {
+	primitive
+}
+
+func (t caseInsensitiveStringType) Equal(o attr.Type) bool {
+	_, ok := o.(caseInsensitiveStringType)
+	return ok
+}
+
+type caseInsensitiveString struct {
+	String
+}
+
+func (s caseInsensitiveString) Equal(o attr.Value) bool {
+	other, ok := o.(caseInsensitiveString)
+	return ok && strings.EqualFold(s.Value, other.Value)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -70,3 +71,17 @@ func (s String) Equal(other attr.Value) bool {
 	}
 	return s.Value == o.Value
 }
+
+// String returns a human-readable representation of the String, such as
+// "example", null, or <unknown>.
+func (s String) String() string {
+	if s.Unknown {
+		return valuestring.Unknown
+	}
+
+	if s.Null {
+		return valuestring.Null
+	}
+
+	return valuestring.String(s.Value)
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/internal/attrvalue"
+	"github.com/hashicorp/terraform-plugin-framework/internal/valuestring"
+)
+
+// elemsString renders the elements of a List or Set.
+func elemsString(elems []attr.Value) string {
+	rendered := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		rendered = append(rendered, attrvalue.String(context.Background(), elem))
+	}
+	return valuestring.Sequence(rendered)
+}
+
+// attrsString renders the elements of a Map or the attributes of an Object.
+func attrsString(attrs map[string]attr.Value) string {
+	rendered := make(map[string]string, len(attrs))
+	for key, val := range attrs {
+		rendered[key] = attrvalue.String(context.Background(), val)
+	}
+	return valuestring.Attributes(rendered)
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+)
+
+func TestValueString(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		input    attr.Value
+		expected string
+	}
+	tests := map[string]testCase{
+		"string": {
+			input:    String{Value: "hello \"world\""},
+			expected: `"hello \"world\""`,
+		},
+		"string-null": {
+			input:    String{Null: true},
+			expected: "null",
+		},
+		"string-unknown": {
+			input:    String{Unknown: true},
+			expected: "<unknown>",
+		},
+		"bool": {
+			input:    Bool{Value: true},
+			expected: "true",
+		},
+		"number-int": {
+			input:    Number{Value: big.NewFloat(1234567)},
+			expected: "1234567",
+		},
+		"number-float": {
+			input:    Number{Value: big.NewFloat(1.5)},
+			expected: "1.5",
+		},
+		"number-null": {
+			input:    Number{Null: true},
+			expected: "null",
+		},
+		"int64": {
+			input:    Int64{Value: -10},
+			expected: "-10",
+		},
+		"float64": {
+			input:    Float64{Value: 0.25},
+			expected: "0.25",
+		},
+		"float64-unknown": {
+			input:    Float64{Unknown: true},
+			expected: "<unknown>",
+		},
+		"list": {
+			input: List{
+				ElemType: StringType,
+				Elems: []attr.Value{
+					String{Value: "a"},
+					String{Unknown: true},
+					String{Null: true},
+				},
+			},
+			expected: `["a", <unknown>, null]`,
+		},
+		"list-empty": {
+			input:    List{ElemType: StringType, Elems: []attr.Value{}},
+			expected: "[]",
+		},
+		"list-unknown": {
+			input:    List{ElemType: StringType, Unknown: true},
+			expected: "<unknown>",
+		},
+		"set": {
+			input: Set{
+				ElemType: NumberType,
+				Elems: []attr.Value{
+					Number{Value: big.NewFloat(1)},
+					Number{Value: big.NewFloat(2)},
+				},
+			},
+			expected: "[1, 2]",
+		},
+		"map": {
+			input: Map{
+				ElemType: StringType,
+				Elems: map[string]attr.Value{
+					"b":         String{Value: "2"},
+					"a":         String{Value: "1"},
+					"not valid": String{Null: true},
+				},
+			},
+			expected: `{ a = "1", b = "2", "not valid" = null }`,
+		},
+		"map-null": {
+			input:    Map{ElemType: StringType, Null: true},
+			expected: "null",
+		},
+		"object": {
+			input: Object{
+				AttrTypes: map[string]attr.Type{
+					"name": StringType,
+					"tags": ListType{ElemType: StringType},
+				},
+				Attrs: map[string]attr.Value{
+					"name": String{Value: "x"},
+					"tags": List{
+						ElemType: StringType,
+						Elems: []attr.Value{
+							String{Value: "y"},
+						},
+					},
+				},
+			},
+			expected: `{ name = "x", tags = ["y"] }`,
+		},
+		"object-empty": {
+			input:    Object{AttrTypes: map[string]attr.Type{}, Attrs: map[string]attr.Value{}},
+			expected: "{}",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.input.(interface{ String() string }).String()
+			if got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}